@@ -71,13 +71,18 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 						} else if field.AutoCreateTime > 0 || field.AutoUpdateTime > 0 {
 							_ = stmt.AddError(field.Set(stmt.Context, rv, curTime))
 							values.Values[i][idx], _ = field.ValueOf(stmt.Context, rv)
+						} else if isSixteenByteType(field.FieldType) || isIntervalField(field) || isVectorField(field) {
+							values.Values[i][idx] = convertToBind(stmt, field, values.Values[i][idx])
+						} else {
+							values.Values[i][idx] = convertEmptyString(stmt, field, values.Values[i][idx])
 						}
 					} else if field.AutoUpdateTime > 0 && updateTrackTime {
 						_ = stmt.AddError(field.Set(stmt.Context, rv, curTime))
 						values.Values[i][idx], _ = field.ValueOf(stmt.Context, rv)
 					} else {
-						values.Values[i][idx] = convertToLiteral(stmt, values.Values[i][idx], rv, field)
+						values.Values[i][idx] = convertToBind(stmt, field, convertToLiteral(stmt, values.Values[i][idx], rv, field))
 					}
+					values.Values[i][idx] = encryptValue(stmt, field, values.Values[i][idx])
 				}
 
 				for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
@@ -118,12 +123,19 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 						tcurTime := convertToLiteral(stmt, curTime, stmt.ReflectValue, field)
 						_ = stmt.AddError(field.Set(stmt.Context, stmt.ReflectValue, tcurTime))
 						values.Values[0][idx], _ = field.ValueOf(stmt.Context, stmt.ReflectValue)
+					} else if isSixteenByteType(field.FieldType) || isIntervalField(field) || isVectorField(field) {
+						values.Values[0][idx] = convertToBind(stmt, field, values.Values[0][idx])
+					} else {
+						values.Values[0][idx] = convertEmptyString(stmt, field, values.Values[0][idx])
 					}
 				} else if field.AutoUpdateTime > 0 && updateTrackTime {
 					tcurTime := convertToLiteral(stmt, curTime, stmt.ReflectValue, field)
 					_ = stmt.AddError(field.Set(stmt.Context, stmt.ReflectValue, tcurTime))
 					values.Values[0][idx], _ = field.ValueOf(stmt.Context, stmt.ReflectValue)
+				} else {
+					values.Values[0][idx] = convertToBind(stmt, field, values.Values[0][idx])
 				}
+				values.Values[0][idx] = encryptValue(stmt, field, values.Values[0][idx])
 			}
 
 			for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
@@ -207,6 +219,7 @@ func ConvertMapToValuesForCreate(stmt *gorm.Statement, mapValue map[string]inter
 		if stmt.Schema != nil {
 			if field := stmt.Schema.LookUpField(k); field != nil {
 				k = field.DBName
+				value = encryptValue(stmt, field, value)
 			}
 		}
 
@@ -0,0 +1,136 @@
+package oracle
+
+import (
+	"reflect"
+	"regexp"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const caseSensitiveKey = "gorm:oracle_case_sensitive"
+
+// CaseSensitive is a scope comparing string conditions of the query in binary, case-sensitive
+// order, for a connection opened with IgnoreCase (NLS_COMP=LINGUISTIC, NLS_SORT=BINARY_CI):
+//
+//	db.Scopes(oracle.CaseSensitive).Where(&User{Name: "Alice"}).Find(&users)
+//	// SELECT * FROM "USERS" WHERE NLSSORT("USERS"."NAME",'NLS_SORT=BINARY') = NLSSORT('Alice','NLS_SORT=BINARY')
+//
+// Equality, inequality and IN conditions against a string are rewritten, whether built from
+// structs, maps or a plain "column = ?" string; LIKE and more complex SQL are left as written.
+// The NLSSORT wrapping keeps plain indexes on the column from being used.
+//
+//goland:noinspection GoUnusedExportedFunction
+func CaseSensitive(db *gorm.DB) *gorm.DB {
+	return db.Set(caseSensitiveKey, true)
+}
+
+// isCaseSensitive reports whether the statement runs under the CaseSensitive scope
+func isCaseSensitive(stmt *gorm.Statement) bool {
+	v, ok := stmt.Settings.Load(caseSensitiveKey)
+	if !ok {
+		return false
+	}
+	b, _ := v.(bool)
+	return b
+}
+
+// binaryComparison matches a single `column = ?` or `column <> ?` condition
+var binaryComparison = regexp.MustCompile(`^\s*([\w."$#]+)\s*(=|<>|!=)\s*\?\s*$`)
+
+// binaryInList matches a single `column IN ?` condition
+var binaryInList = regexp.MustCompile(`(?i)^\s*([\w."$#]+)\s+IN\s*(?:\?|\(\s*\?\s*\))\s*$`)
+
+// binaryConditions rewrites the string comparisons of exprs into NLSSORT(..., 'NLS_SORT=BINARY')
+// comparisons, see CaseSensitive.
+func binaryConditions(exprs []clause.Expression) []clause.Expression {
+	rewritten := make([]clause.Expression, len(exprs))
+	for i, expr := range exprs {
+		rewritten[i] = binaryCondition(expr)
+	}
+	return rewritten
+}
+
+func binaryCondition(expr clause.Expression) clause.Expression {
+	switch e := expr.(type) {
+	case clause.Eq:
+		if isStringValue(e.Value) {
+			return binaryCompare(asColumn(e.Column), "=", e.Value)
+		}
+	case clause.Neq:
+		if isStringValue(e.Value) {
+			return binaryCompare(asColumn(e.Column), "<>", e.Value)
+		}
+	case clause.IN:
+		// longer lists are split by the WHERE rewrite of IN conditions first, see rewriteINClause
+		if len(e.Values) == 0 || len(e.Values) > 1000 {
+			return e
+		}
+		for _, v := range e.Values {
+			if !isStringValue(v) {
+				return e
+			}
+		}
+		sql := nlsSortBinary + " IN ("
+		for i := range e.Values {
+			if i > 0 {
+				sql += ","
+			}
+			sql += nlsSortBinary
+		}
+		return clause.Expr{SQL: sql + ")", Vars: append([]any{asColumn(e.Column)}, e.Values...)}
+	case clause.Expr:
+		if len(e.Vars) != 1 {
+			return e
+		}
+		if m := binaryInList.FindStringSubmatch(e.SQL); m != nil {
+			if values, ok := flattenSlice(e.Vars[0]); ok {
+				in := binaryCondition(clause.IN{Column: clause.Expr{SQL: m[1]}, Values: values})
+				if _, ok = in.(clause.Expr); ok {
+					return in
+				}
+			}
+			return e
+		}
+		if !isStringValue(e.Vars[0]) {
+			return e
+		}
+		if m := binaryComparison.FindStringSubmatch(e.SQL); m != nil {
+			op := m[2]
+			if op == "!=" {
+				op = "<>"
+			}
+			return clause.Expr{
+				SQL:  "NLSSORT(" + m[1] + ",'NLS_SORT=BINARY') " + op + " " + nlsSortBinary,
+				Vars: e.Vars,
+			}
+		}
+	case clause.AndConditions:
+		return clause.AndConditions{Exprs: binaryConditions(e.Exprs)}
+	case clause.OrConditions:
+		return clause.OrConditions{Exprs: binaryConditions(e.Exprs)}
+	case clause.NotConditions:
+		return clause.NotConditions{Exprs: binaryConditions(e.Exprs)}
+	}
+	return expr
+}
+
+const nlsSortBinary = "NLSSORT(?,'NLS_SORT=BINARY')"
+
+func binaryCompare(column any, op string, value any) clause.Expression {
+	return clause.Expr{SQL: nlsSortBinary + " " + op + " " + nlsSortBinary, Vars: []any{column, value}}
+}
+
+// asColumn returns the column of a condition for use as a bind variable; a plain string would
+// otherwise be bound as a value.
+func asColumn(column any) any {
+	if name, ok := column.(string); ok {
+		return clause.Column{Name: name}
+	}
+	return column
+}
+
+func isStringValue(value any) bool {
+	rv, _, _ := reflectValueDereference(value)
+	return rv.IsValid() && rv.Kind() == reflect.String
+}
@@ -0,0 +1,252 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cmmoran/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Collection kinds accepted by the `oracle_collection_kind` tag setting.
+const (
+	CollectionVarray      = "VARRAY"
+	CollectionNestedTable = "TABLE"
+)
+
+const defaultCollectionLimit = 1000
+
+// collectionType describes the Oracle collection type backing a slice field.
+//
+//	Tags   []string `gorm:"type:TAG_LIST;oracle_collection"`                                        // VARRAY(1000) OF VARCHAR2(4000)
+//	Scores []int64  `gorm:"type:SCORE_LIST;oracle_collection;oracle_collection_limit:10"`           // VARRAY(10) OF NUMBER
+//	Phones []string `gorm:"size:20;type:PHONE_TAB;oracle_collection;oracle_collection_kind:table"` // TABLE OF VARCHAR2(20)
+//
+// The collection is named by the field's `type` tag, which gorm requires for slice fields anyway.
+// Collection type names are plain Oracle identifiers and are always emitted in upper case.
+type collectionType struct {
+	Name  string
+	Kind  string
+	Limit int
+	// Elem is the element SQL type, e.g. VARCHAR2(4000)
+	Elem string
+	// ElemBase is the element type name go-ora uses to register the collection, e.g. VARCHAR2
+	ElemBase string
+}
+
+// parseCollectionType returns the collection type declared on field via `oracle_collection`.
+func parseCollectionType(field *schema.Field) (collectionType, bool) {
+	if field == nil {
+		return collectionType{}, false
+	}
+	if _, ok := field.TagSettings["ORACLE_COLLECTION"]; !ok {
+		return collectionType{}, false
+	}
+	name := strings.TrimSpace(field.TagSettings["TYPE"])
+	if name == "" {
+		return collectionType{}, false
+	}
+
+	ft := field.FieldType
+	for ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+	if ft.Kind() != reflect.Slice || ft.Elem().Kind() == reflect.Uint8 {
+		return collectionType{}, false
+	}
+
+	ct := collectionType{
+		Name:  strings.ToUpper(name),
+		Kind:  CollectionVarray,
+		Limit: defaultCollectionLimit,
+	}
+	if kind := strings.ToUpper(strings.TrimSpace(field.TagSettings["ORACLE_COLLECTION_KIND"])); kind == CollectionNestedTable {
+		ct.Kind = CollectionNestedTable
+	}
+	if limit, err := strconv.Atoi(strings.TrimSpace(field.TagSettings["ORACLE_COLLECTION_LIMIT"])); err == nil && limit > 0 {
+		ct.Limit = limit
+	}
+
+	elem := ft.Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	switch {
+	case elem == tyTime:
+		ct.Elem, ct.ElemBase = "TIMESTAMP", "TIMESTAMP"
+	case elem.Kind() == reflect.String:
+		size := field.Size
+		if size <= 0 || size > 4000 {
+			size = 4000
+		}
+		ct.Elem, ct.ElemBase = fmt.Sprintf("VARCHAR2(%d)", size), "VARCHAR2"
+	case elem.Kind() == reflect.Bool:
+		ct.Elem, ct.ElemBase = "NUMBER(1)", "NUMBER"
+	case isNumeric(elem.Kind()):
+		ct.Elem, ct.ElemBase = "NUMBER", "NUMBER"
+	default:
+		return collectionType{}, false
+	}
+
+	return ct, true
+}
+
+// createSQL returns the CREATE TYPE statement declaring the collection.
+func (ct collectionType) createSQL() string {
+	if ct.Kind == CollectionNestedTable {
+		return fmt.Sprintf("CREATE TYPE %s AS TABLE OF %s", ct.Name, ct.Elem)
+	}
+	return fmt.Sprintf("CREATE TYPE %s AS VARRAY(%d) OF %s", ct.Name, ct.Limit, ct.Elem)
+}
+
+// nestedTableStorageName names the storage table of a nested table column: NT_<TABLE>_<COLUMN>.
+func nestedTableStorageName(ns *NamingStrategy, table, column string) string {
+	return ns.genToken("NT", table, column)
+}
+
+// ensureCollectionType creates the collection type unless it already exists, then registers it with go-ora.
+// An owner-qualified type, e.g. `type:HR.TAG_LIST`, is looked up in that schema.
+func (m Migrator) ensureCollectionType(ct collectionType) error {
+	query, vars := `SELECT 1 FROM USER_TYPES WHERE TYPE_NAME = :name AND ROWNUM = 1`, []any{sql.Named("name", ct.Name)}
+	if owner, name, ok := strings.Cut(ct.Name, "."); ok {
+		query = `SELECT 1 FROM ALL_TYPES WHERE OWNER = :owner AND TYPE_NAME = :name AND ROWNUM = 1`
+		vars = []any{sql.Named("owner", owner), sql.Named("name", name)}
+	}
+	var exists int
+	if err := m.DB.Raw(query, vars...).Scan(&exists).Error; err != nil {
+		return err
+	}
+	if exists != 1 {
+		if err := m.DB.Exec(ct.createSQL()).Error; err != nil {
+			return err
+		}
+	}
+	return registerCollectionType(m.DB, ct)
+}
+
+func registerCollectionType(db *gorm.DB, ct collectionType) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if _, ok := sqlDB.Driver().(*go_ora.OracleDriver); !ok {
+		return nil
+	}
+	if owner, name, ok := strings.Cut(ct.Name, "."); ok {
+		return go_ora.RegisterTypeWithOwner(sqlDB, owner, ct.ElemBase, name, nil)
+	}
+	return go_ora.RegisterType(sqlDB, ct.ElemBase, ct.Name, nil)
+}
+
+// RegisterCollectionTypes registers the collection types declared by the models' `oracle_collection`
+// fields with the go-ora driver so they can be bound and scanned. AutoMigrate registers them
+// automatically; call this once at startup when the schema is managed elsewhere.
+//
+//goland:noinspection GoUnusedExportedFunction
+func RegisterCollectionTypes(db *gorm.DB, models ...interface{}) error {
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if ct, ok := parseCollectionType(field); ok {
+				if err := registerCollectionType(db, ct); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// collectionValue wraps a slice bound to a collection column in a go-ora Object.
+func collectionValue(ct collectionType, v any) any {
+	return go_ora.Object{Name: ct.Name, Value: v}
+}
+
+// collectionColumn pairs a nested table column with its storage table name.
+type collectionColumn struct {
+	column  string
+	storage string
+}
+
+// In-list collection types, see Config.InListArrayThreshold. Both are VARRAY(32767) types every
+// Oracle database provides.
+const (
+	inListTypeOwner   = "SYS"
+	inListNumberType  = "ODCINUMBERLIST"
+	inListVarcharType = "ODCIVARCHAR2LIST"
+	inListMaxElements = 32767
+)
+
+// RegisterInListTypes registers the collection types long IN conditions are bound as, see
+// Config.InListArrayThreshold, with the go-ora driver. Initialize registers them when the
+// threshold is set; call this when the threshold is set afterwards.
+//
+//goland:noinspection GoUnusedExportedFunction
+func RegisterInListTypes(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return registerInListTypes(sqlDB)
+}
+
+func registerInListTypes(sqlDB *sql.DB) error {
+	if _, ok := sqlDB.Driver().(*go_ora.OracleDriver); !ok {
+		return nil
+	}
+	if err := go_ora.RegisterTypeWithOwner(sqlDB, inListTypeOwner, "NUMBER", inListNumberType, nil); err != nil {
+		return err
+	}
+	return go_ora.RegisterTypeWithOwner(sqlDB, inListTypeOwner, "VARCHAR2", inListVarcharType, nil)
+}
+
+// inListValue wraps values in a go-ora Object of the in-list collection type holding them. It
+// reports false unless the values are all strings, all integers or all floats; other types, e.g.
+// UUIDs stored as RAW, keep their own bind conversions.
+func inListValue(values []any) (any, bool) {
+	if len(values) == 0 {
+		return nil, false
+	}
+	var (
+		strs   []string
+		ints   []int64
+		floats []float64
+	)
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		switch kind := rv.Kind(); {
+		case kind == reflect.String && ints == nil && floats == nil:
+			strs = append(strs, rv.String())
+		case kind >= reflect.Int && kind <= reflect.Int64 && strs == nil && floats == nil:
+			ints = append(ints, rv.Int())
+		case kind >= reflect.Uint && kind <= reflect.Uintptr && strs == nil && floats == nil:
+			if rv.Uint() > math.MaxInt64 {
+				return nil, false
+			}
+			ints = append(ints, int64(rv.Uint()))
+		case (kind == reflect.Float32 || kind == reflect.Float64) && strs == nil && ints == nil:
+			floats = append(floats, rv.Float())
+		default:
+			return nil, false
+		}
+	}
+	switch {
+	case strs != nil:
+		return go_ora.Object{Owner: inListTypeOwner, Name: inListVarcharType, Value: strs}, true
+	case ints != nil:
+		return go_ora.Object{Owner: inListTypeOwner, Name: inListNumberType, Value: ints}, true
+	default:
+		return go_ora.Object{Owner: inListTypeOwner, Name: inListNumberType, Value: floats}, true
+	}
+}
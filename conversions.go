@@ -2,9 +2,11 @@ package oracle
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,10 +18,17 @@ import (
 )
 
 var (
-	tyTime   = reflect.TypeFor[time.Time]()
-	ty16Byte = reflect.TypeFor[[16]byte]()
+	tyTime     = reflect.TypeFor[time.Time]()
+	ty16Byte   = reflect.TypeFor[[16]byte]()
+	tyDuration = reflect.TypeFor[time.Duration]()
+	tyVector   = reflect.TypeFor[[]float32]()
 )
 
+// convertToLiteral converts val, compared with or written to the field f, or to the fields f of a
+// slice of values, to the value the column stores: times are converted to the field's time type and
+// precision, and uuid strings compared with a RAW(16) column bound through HEXTORAW. A driver.Valuer
+// is replaced by its driver value, converted the same way, except for uuids and other 16-byte
+// values which are bound as RAW(16) whatever their Value; an error of Value is added to stmt.
 func convertToLiteral(stmt *gorm.Statement, val any, rv reflect.Value, f ...*schema.Field) any {
 	var ret any
 	rval, _, indirections := reflectValueDereference(val)
@@ -37,12 +46,31 @@ func convertToLiteral(stmt *gorm.Statement, val any, rv reflect.Value, f ...*sch
 		return ret.([]any)
 	case len(f) == 1:
 		field := f[0]
+		if valuer, ok := v.(driver.Valuer); ok && !isSixteenByteType(rval.Type()) {
+			// a Valuer already produces its driver value, still converted below when it is a time or
+			// a uuid string compared with a RAW(16) column
+			dv, err := valuer.Value()
+			if err != nil {
+				if stmt != nil {
+					_ = stmt.AddError(err)
+				}
+				return val
+			}
+			if dv == nil {
+				return nil
+			}
+			val, v, rval, indirections = dv, dv, reflect.ValueOf(dv), 0
+		}
+		if rval.Kind() == reflect.String && isSixteenByteType(field.FieldType) {
+			// a canonical or bare-hex uuid string compared against a RAW(16) column
+			if _, ok := asRaw16(rval); ok {
+				return castRaw16(v)
+			}
+			return val
+		}
 		switch rval.Type() {
 		case tyTime:
-			loc := stmt.DB.Dialector.(*Dialector).sessionLocation
-			if loc == nil {
-				loc = time.Local
-			}
+			loc := sessionLocation(stmt)
 			prec := field.Precision
 			if prec <= 0 || prec > 9 {
 				prec = 6
@@ -52,14 +80,18 @@ func convertToLiteral(stmt *gorm.Statement, val any, rv reflect.Value, f ...*sch
 			if !ok {
 				vt = time.Time{}
 			}
-			switch strings.ToLower(string(field.DataType)) {
+			dataType := field.DataType
+			if dataType == schema.Time {
+				dataType = defaultTimeType(stmt)
+			}
+			switch strings.ToLower(string(dataType)) {
 			case "date":
 				dr := reflect.ValueOf(converters.ToDate(vt, converters.WithLocation(loc)))
 				for i := 0; i < indirections; i++ {
 					dr, _ = reflectValueReference(dr.Interface(), true)
 				}
-				if err := field.Set(stmt.Context, rv, dr.Interface()); err != nil {
-					return dr.Interface()
+				if rv.IsValid() && reflect.Indirect(rv).Kind() == reflect.Struct {
+					_ = field.Set(stmt.Context, rv, dr.Interface())
 				}
 				return dr.Interface()
 			case "timestamp":
@@ -67,8 +99,8 @@ func convertToLiteral(stmt *gorm.Statement, val any, rv reflect.Value, f ...*sch
 				for i := 0; i < indirections; i++ {
 					dr, _ = reflectValueReference(dr.Interface(), true)
 				}
-				if err := field.Set(stmt.Context, rv, dr.Interface()); err != nil {
-					return dr.Interface()
+				if rv.IsValid() && reflect.Indirect(rv).Kind() == reflect.Struct {
+					_ = field.Set(stmt.Context, rv, dr.Interface())
 				}
 				return dr.Interface()
 			case "timestamp with time zone":
@@ -77,8 +109,8 @@ func convertToLiteral(stmt *gorm.Statement, val any, rv reflect.Value, f ...*sch
 				for i := 0; i < indirections; i++ {
 					dr, _ = reflectValueReference(dr.Interface(), true)
 				}
-				if err := field.Set(stmt.Context, rv, dr.Interface()); err != nil {
-					return dr.Interface()
+				if rv.IsValid() && reflect.Indirect(rv).Kind() == reflect.Struct {
+					_ = field.Set(stmt.Context, rv, dr.Interface())
 				}
 				return dr.Interface()
 			case "timestamp with local time zone":
@@ -86,21 +118,191 @@ func convertToLiteral(stmt *gorm.Statement, val any, rv reflect.Value, f ...*sch
 				for i := 0; i < indirections; i++ {
 					dr, _ = reflectValueReference(dr.Interface(), true)
 				}
-				if err := field.Set(stmt.Context, rv, dr.Interface()); err != nil {
-					return dr.Interface()
+				if rv.IsValid() && reflect.Indirect(rv).Kind() == reflect.Struct {
+					_ = field.Set(stmt.Context, rv, dr.Interface())
 				}
 				return dr.Interface()
 
 			}
 		case ty16Byte:
-			return v.([]byte)[:]
+			b := v.([16]byte)
+			return b[:]
+		}
+	}
+
+	return val
+}
+
+// defaultTimeType returns the data type of time fields without a type tag in the dialector of stmt,
+// see Config.DefaultTimeType
+func defaultTimeType(stmt *gorm.Statement) schema.DataType {
+	if stmt != nil && stmt.DB != nil {
+		if v, _ := reflectDereference(stmt.DB.Dialector); v != nil {
+			if d, ok := v.(Dialector); ok {
+				return d.defaultTimeType()
+			}
 		}
 	}
+	return Dialector{}.defaultTimeType()
+}
+
+// isDateField reports whether field is declared as DATE, by its type tag or Config.DefaultTimeType
+func isDateField(stmt *gorm.Statement, field *schema.Field) bool {
+	dataType := field.DataType
+	if dataType == schema.Time {
+		dataType = defaultTimeType(stmt)
+	}
+	return strings.EqualFold(string(dataType), "date")
+}
 
+// convertToBind wraps a value written to a column whose Oracle type needs a constructor:
+// collections are bound as go-ora objects, XMLTYPE documents through XMLTYPE(?), durations
+// through TO_DSINTERVAL(?), see convertToInterval, embeddings through TO_VECTOR(?), see
+// convertToVector, and the infinities and NaN of BINARY_FLOAT and BINARY_DOUBLE columns through
+// TO_BINARY_FLOAT(?) and TO_BINARY_DOUBLE(?), see convertToBinaryFloat. A nil
+// uuid/ulid (or nil pointer to one) is bound as a NULL RAW rather than an untyped NULL; it stays a
+// single bind variable so the rows of a batch insert keep sharing one statement. Times written to
+// a field with a `precision` tag are rounded to that many fractional digits, as the column stores them,
+// and those written to a DATE column are truncated to the second.
+func convertToBind(stmt *gorm.Statement, field *schema.Field, val any) any {
+	if field == nil {
+		return val
+	}
+	if isEmptyString(val) && emptyStringAsNull(stmt) != nil {
+		return convertEmptyString(stmt, field, val)
+	}
+	if isDateField(stmt, field) {
+		switch t := val.(type) {
+		case time.Time:
+			return t.Truncate(time.Second)
+		case *time.Time:
+			if t != nil {
+				return t.Truncate(time.Second)
+			}
+		}
+	}
+	if field.Precision > 0 && field.Precision < 9 {
+		switch t := val.(type) {
+		case time.Time:
+			return trimFracTo(t, field.Precision)
+		case *time.Time:
+			if t != nil {
+				return trimFracTo(*t, field.Precision)
+			}
+		}
+	}
+	if isSixteenByteType(field.FieldType) {
+		if v, _ := reflectDereference(val); v == nil {
+			return []byte(nil)
+		}
+		return val
+	}
+	if isIntervalField(field) {
+		if v, _ := reflectDereference(val); v == nil {
+			return castNullExpr("INTERVAL DAY TO SECOND")
+		}
+		return convertToInterval(field, val)
+	}
+	if isBinaryFloatField(stmt, field) {
+		return convertToBinaryFloat(stmt, field, val)
+	}
+	if isVectorField(field) {
+		return convertToVector(stmt, field, val)
+	}
+	ct, isCollection := parseCollectionType(field)
+	if !isCollection && !isXMLField(field) {
+		return val
+	}
+	rval, isPtr, _ := reflectValueDereference(val)
+	if !rval.IsValid() || (isPtr && rval.Kind() == reflect.Ptr) {
+		if isCollection {
+			return val
+		}
+		return castNullExpr("XMLTYPE")
+	}
+	switch {
+	case isCollection && rval.Kind() == reflect.Slice:
+		return collectionValue(ct, rval.Interface())
+	case !isCollection && rval.Kind() == reflect.String:
+		return xmlValue(rval.String())
+	}
 	return val
 }
 
-func castValue(val any, dataType string, prec int, notnull bool) any {
+// emptyStringAsNull returns Config.EmptyStringAsNull of the dialector of stmt, nil when unset
+func emptyStringAsNull(stmt *gorm.Statement) *bool {
+	if stmt == nil || stmt.DB == nil {
+		return nil
+	}
+	v, _ := reflectDereference(stmt.DB.Dialector)
+	if d, ok := v.(Dialector); ok && d.Config != nil {
+		return d.EmptyStringAsNull
+	}
+	return nil
+}
+
+// isEmptyString reports whether val is, or points to, an empty string; a driver.Valuer is left to
+// its own conversion
+func isEmptyString(val any) bool {
+	v, _ := reflectDereference(val)
+	if v == nil {
+		return false
+	}
+	if _, ok := v.(driver.Valuer); ok {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.String && rv.Len() == 0
+}
+
+// convertEmptyString applies Config.EmptyStringAsNull to a value written to field: an empty string
+// is bound as NULL when it is true, and rejected for a NOT NULL column when it is false, as Oracle
+// would store it as NULL anyway; when unset the value is left alone.
+func convertEmptyString(stmt *gorm.Statement, field *schema.Field, val any) any {
+	asNull := emptyStringAsNull(stmt)
+	if asNull == nil || field == nil || !isEmptyString(val) {
+		return val
+	}
+	if *asNull {
+		return (*string)(nil)
+	}
+	if field.NotNull || field.PrimaryKey {
+		_ = stmt.AddError(fmt.Errorf("oracle: empty string for NOT NULL column %q, Oracle stores '' as NULL", field.DBName))
+	}
+	return val
+}
+
+// emptyStringCondition reports whether a condition comparing field with val for equality is
+// rewritten to IS NULL by Config.EmptyStringAsNull; when it is false the comparison, which would
+// match no rows, is rejected.
+func emptyStringCondition(stmt *gorm.Statement, field *schema.Field, val any) bool {
+	asNull := emptyStringAsNull(stmt)
+	if asNull == nil || !isEmptyString(val) {
+		return false
+	}
+	if !*asNull {
+		_ = stmt.AddError(fmt.Errorf("oracle: comparing %q with an empty string matches no rows, Oracle stores '' as NULL", field.DBName))
+	}
+	return *asNull
+}
+
+// isNullRaw16 reports whether val is a nil value compared with the uuid or other 16-byte field,
+// e.g. a nil *uuid.UUID, a condition built as IS NULL
+func isNullRaw16(field *schema.Field, val any) bool {
+	if !isSixteenByteType(field.FieldType) {
+		return false
+	}
+	v, _ := reflectDereference(val)
+	return v == nil
+}
+
+// castValue casts a value merged into a column of dataType by MERGE, see MergeCreate, so the USING
+// rows are typed; times are cast in the zone of the session running stmt, see sessionLocation,
+// and a driver.Valuer is cast by its driver value, an error of Value added to stmt.
+func castValue(stmt *gorm.Statement, val any, dataType string, prec int, notnull bool) any {
+	if val != nil && isSixteenByteType(reflect.TypeOf(val)) {
+		return castRaw16(val)
+	}
 	v, wasPtr := reflectDereference(val)
 	if v == nil && wasPtr {
 		return castNullExpr(dataType)
@@ -108,6 +310,12 @@ func castValue(val any, dataType string, prec int, notnull bool) any {
 	if v == nil {
 		return nil
 	}
+	if inner, valid, ok := sqlNullValue(v); ok {
+		if !valid {
+			return castNullExpr(dataType)
+		}
+		return castValue(stmt, inner, dataType, prec, notnull)
+	}
 
 	switch x := v.(type) {
 	case bool:
@@ -141,36 +349,122 @@ func castValue(val any, dataType string, prec int, notnull bool) any {
 		return sql.NullTime{}
 
 	case time.Time:
-		return castTime(x, dataType, prec)
+		var loc *time.Location
+		if stmt != nil && stmt.DB != nil {
+			loc = sessionLocation(stmt)
+		}
+		return castTime(x, dataType, prec, loc)
 
 	default:
-		if reflect.TypeOf(x).ConvertibleTo(ty16Byte) {
-			return castRaw16(x)
+		if valuer, ok := x.(driver.Valuer); ok {
+			dv, err := valuer.Value()
+			if err != nil {
+				if stmt != nil {
+					_ = stmt.AddError(err)
+				}
+				return x
+			}
+			if dv == nil {
+				return castNullExpr(dataType)
+			}
+			return castValue(stmt, dv, dataType, prec, notnull)
 		}
 		return x
 	}
 }
 
+// castSelectVar converts a value bound by a computed select, which has no column to take its type
+// from: times are bound as TIMESTAMP(9) WITH TIME ZONE, keeping their zone and nanoseconds,
+// uuids and other 16-byte values as RAW(16) and booleans as 1 or 0.
+func castSelectVar(v any) any {
+	switch x := v.(type) {
+	case time.Time:
+		return castTime(x, "TIMESTAMP WITH TIME ZONE", 9, nil)
+	case *time.Time:
+		if x == nil {
+			return castNullExpr("TIMESTAMP WITH TIME ZONE")
+		}
+		return castTime(*x, "TIMESTAMP WITH TIME ZONE", 9, nil)
+	case bool, *bool:
+		return castValue(nil, x, "NUMBER(1)", 0, false)
+	}
+	if t := reflect.TypeOf(v); t != nil {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.ConvertibleTo(ty16Byte) {
+			return castRaw16(v)
+		}
+	}
+	return v
+}
+
+// sqlNullValue unwraps the sql.Null* types, including sql.Null[T], into their inner value and
+// validity; ok is false for any other value.
+func sqlNullValue(v any) (inner any, valid bool, ok bool) {
+	switch x := v.(type) {
+	case sql.NullString:
+		return x.String, x.Valid, true
+	case sql.NullInt64:
+		return x.Int64, x.Valid, true
+	case sql.NullInt32:
+		return x.Int32, x.Valid, true
+	case sql.NullInt16:
+		return x.Int16, x.Valid, true
+	case sql.NullByte:
+		return x.Byte, x.Valid, true
+	case sql.NullFloat64:
+		return x.Float64, x.Valid, true
+	case sql.NullBool:
+		return x.Bool, x.Valid, true
+	case sql.NullTime:
+		return x.Time, x.Valid, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Struct || rv.Type().PkgPath() != "database/sql" || !strings.HasPrefix(rv.Type().Name(), "Null[") {
+		return nil, false, false
+	}
+	return rv.FieldByName("V").Interface(), rv.FieldByName("Valid").Bool(), true
+}
+
+// castNullExpr returns a NULL typed as t, for the single-word types below and the multi-word ones,
+// see IsTypePhrase; nil for any other type.
 func castNullExpr(t string) any {
 	if t == "" {
 		return nil
 	}
 	t = strings.ToUpper(t)
+	if IsTypePhrase(t) {
+		return clause.Expr{SQL: fmt.Sprintf("CAST(NULL AS %s)", t)}
+	}
 	switch t {
-	case "RAW(16)", "RAW(32)", "BLOB", "LONG RAW", "CHAR(1)", "VARCHAR2", "CLOB", "NCLOB",
-		"NUMBER", "NUMBER(1)", "BINARY_FLOAT", "BINARY_DOUBLE", "FLOAT", "DATE", "TIMESTAMP",
-		"TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE", "INTERVAL YEAR TO MONTH",
-		"INTERVAL DAY TO SECOND", "XMLTYPE", "JSON":
+	case "RAW(16)", "RAW(32)", "BLOB", "CHAR(1)", "VARCHAR2", "CLOB", "NCLOB",
+		"NUMBER", "NUMBER(1)", "INTEGER", "SMALLINT", "BOOLEAN", "BINARY_FLOAT", "BINARY_DOUBLE", "FLOAT", "DATE", "TIMESTAMP",
+		"XMLTYPE", "JSON":
 		return clause.Expr{SQL: fmt.Sprintf("CAST(NULL AS %s)", t)}
 	default:
-		if strings.HasPrefix(t, "VARCHAR2(") {
+		if strings.HasPrefix(t, "VARCHAR2(") || strings.HasPrefix(t, "NUMBER(") {
 			return clause.Expr{SQL: fmt.Sprintf("CAST(NULL AS %s)", t)}
 		}
 		return nil
 	}
 }
 
-func castTime(t time.Time, typ string, prec int) any {
+// castTime casts t to the time type typ. A DATE, TIMESTAMP or TIMESTAMP WITH LOCAL TIME ZONE keeps
+// the wall clock of t in loc, the zone of the session, when loc is set; a TIMESTAMP WITH TIME
+// ZONE keeps the zone of t.
+func castTime(t time.Time, typ string, prec int, loc *time.Location) any {
+	// TIMESTAMP(3) WITH TIME ZONE, as DataTypeOf declares a time field with a precision tag
+	if tm := timestampPrecisionRe.FindStringSubmatch(typ); tm != nil {
+		typ = "TIMESTAMP" + typ[len(tm[0]):]
+		if prec <= 0 {
+			prec, _ = strconv.Atoi(tm[1])
+		}
+	}
+	if loc != nil && typ != "TIMESTAMP WITH TIME ZONE" {
+		t = t.In(loc)
+	}
 	switch typ {
 	case "DATE":
 		return clause.Expr{
@@ -209,10 +503,12 @@ func castTime(t time.Time, typ string, prec int) any {
 	}
 }
 
+// castRaw16 binds a uuid or other 16-byte value through HEXTORAW; a nil one binds as a single
+// NULL RAW variable, as convertToBind writes it.
 func castRaw16(v any) any {
 	b, ok := asRaw16(reflect.ValueOf(v))
-	if !ok {
-		return clause.Expr{SQL: "CAST(NULL AS RAW(16))"}
+	if !ok || b == nil {
+		return []byte(nil)
 	}
 	return clause.Expr{
 		SQL:  "HEXTORAW(?)",
@@ -220,6 +516,27 @@ func castRaw16(v any) any {
 	}
 }
 
+// RawToUUID formats the RAW(16) column as canonical lower-case UUID text, for projections in
+// reports and ad-hoc queries:
+//
+//	db.Model(&User{}).Select("?", oracle.RawToUUID("id")).Scan(&ids)
+//	// SELECT REGEXP_REPLACE(LOWER(RAWTOHEX("id")), ...) FROM "users"
+//
+//goland:noinspection GoUnusedExportedFunction
+func RawToUUID(column string) clause.Expr {
+	return clause.Expr{
+		SQL:  `REGEXP_REPLACE(LOWER(RAWTOHEX(?)), '^(.{8})(.{4})(.{4})(.{4})(.{12})$', '\1-\2-\3-\4-\5')`,
+		Vars: []any{clause.Column{Name: column}},
+	}
+}
+
+// UUIDToRaw converts UUID text, with or without hyphens, into a RAW(16) value: HEXTORAW(REPLACE(?, '-'))
+//
+//goland:noinspection GoUnusedExportedFunction
+func UUIDToRaw(value string) clause.Expr {
+	return clause.Expr{SQL: "HEXTORAW(REPLACE(?, '-'))", Vars: []any{value}}
+}
+
 func trimFracTo(t time.Time, p int) time.Time {
 	if p < 0 || p > 9 {
 		return t
@@ -293,3 +610,290 @@ func fromHex(r byte) (byte, bool) {
 	}
 	return 0, false
 }
+
+// isBinaryFloatField reports whether field is stored as BINARY_FLOAT or BINARY_DOUBLE, by its type
+// tag or Config.UseBinaryFloatTypes
+func isBinaryFloatField(stmt *gorm.Statement, field *schema.Field) bool {
+	if field == nil {
+		return false
+	}
+	switch strings.ToLower(string(field.DataType)) {
+	case "binary_float", "binary_double":
+		return true
+	case string(schema.Float):
+		if field.Precision > 0 || stmt == nil || stmt.DB == nil {
+			return false
+		}
+		v, _ := reflectDereference(stmt.DB.Dialector)
+		d, ok := v.(Dialector)
+		return ok && d.Config != nil && d.UseBinaryFloatTypes
+	}
+	return false
+}
+
+// convertToBinaryFloat binds the infinities and NaN compared with or written to a binary float
+// field, see isBinaryFloatField, through TO_BINARY_FLOAT(?) or TO_BINARY_DOUBLE(?): go-ora binds
+// floats as NUMBER, which holds neither. Other values are returned as is.
+func convertToBinaryFloat(stmt *gorm.Statement, field *schema.Field, val any) any {
+	if !isBinaryFloatField(stmt, field) {
+		return val
+	}
+	rval, _, _ := reflectValueDereference(val)
+	if !rval.IsValid() || (rval.Kind() != reflect.Float32 && rval.Kind() != reflect.Float64) {
+		return val
+	}
+	var literal string
+	switch f := rval.Float(); {
+	case math.IsNaN(f):
+		literal = "NaN"
+	case math.IsInf(f, 1):
+		literal = "INF"
+	case math.IsInf(f, -1):
+		literal = "-INF"
+	default:
+		return val
+	}
+	if strings.EqualFold(string(field.DataType), "binary_float") || (field.DataType == schema.Float && field.Size == 32) {
+		return clause.Expr{SQL: "TO_BINARY_FLOAT(?)", Vars: []any{literal}}
+	}
+	return clause.Expr{SQL: "TO_BINARY_DOUBLE(?)", Vars: []any{literal}}
+}
+
+// isIntervalField reports whether field holds a number of nanoseconds stored as INTERVAL DAY TO
+// SECOND: a time.Duration, or an int64 tagged `type:interval day to second`.
+func isIntervalField(field *schema.Field) bool {
+	if field == nil {
+		return false
+	}
+	if field.IndirectFieldType == tyDuration && (field.DataType == schema.Int || isIntervalType(string(field.DataType))) {
+		return true
+	}
+	return field.IndirectFieldType.Kind() == reflect.Int64 && isIntervalType(string(field.DataType))
+}
+
+func isIntervalType(dataType string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(dataType)), "INTERVAL DAY")
+}
+
+// convertToInterval binds a duration compared with or written to an interval field, see
+// isIntervalField, as an INTERVAL DAY TO SECOND; other values are returned as is.
+func convertToInterval(field *schema.Field, val any) any {
+	if !isIntervalField(field) {
+		return val
+	}
+	rval, _, _ := reflectValueDereference(val)
+	if !rval.IsValid() || rval.Kind() != reflect.Int64 {
+		return val
+	}
+	return clause.Expr{SQL: "TO_DSINTERVAL(?)", Vars: []any{formatIntervalDS(time.Duration(rval.Int()))}}
+}
+
+// formatIntervalDS formats d as an INTERVAL DAY TO SECOND literal, `+1 02:03:04.500000000`
+func formatIntervalDS(d time.Duration) string {
+	sign := "+"
+	u := uint64(d)
+	if d < 0 {
+		sign = "-"
+		u = uint64(-(d + 1)) + 1
+	}
+	const day = uint64(24 * time.Hour)
+	days, rem := u/day, u%day
+	hours, rem := rem/uint64(time.Hour), rem%uint64(time.Hour)
+	minutes, rem := rem/uint64(time.Minute), rem%uint64(time.Minute)
+	seconds, nanos := rem/uint64(time.Second), rem%uint64(time.Second)
+	return fmt.Sprintf("%s%d %02d:%02d:%02d.%09d", sign, days, hours, minutes, seconds, nanos)
+}
+
+// parseIntervalDS parses an INTERVAL DAY TO SECOND as go-ora reads it, `+01 02:03:04.500000`
+func parseIntervalDS(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	dayPart, clock, ok := strings.Cut(strings.TrimLeft(s, "+-"), " ")
+	if !ok {
+		return 0, fmt.Errorf("oracle: invalid INTERVAL DAY TO SECOND %q", s)
+	}
+	var days, hours, minutes int64
+	var seconds float64
+	if _, err := fmt.Sscanf(dayPart, "%d", &days); err != nil {
+		return 0, fmt.Errorf("oracle: invalid INTERVAL DAY TO SECOND %q: %w", s, err)
+	}
+	if _, err := fmt.Sscanf(clock, "%d:%d:%f", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("oracle: invalid INTERVAL DAY TO SECOND %q: %w", s, err)
+	}
+	whole := math.Floor(seconds)
+	d := time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(whole)*time.Second + time.Duration(math.Round((seconds-whole)*1e9))
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// scannedInterval converts the value read from an interval field, see isIntervalField: a number of
+// nanoseconds, see readExpression, or the INTERVAL DAY TO SECOND text go-ora reads otherwise.
+func scannedInterval(field *schema.Field, val any) (any, error) {
+	v, _ := reflectDereference(val)
+	var d time.Duration
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		d = time.Duration(x)
+	case float64:
+		d = time.Duration(math.Round(x))
+	case string:
+		var err error
+		if d, err = parseIntervalDS(x); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("oracle: cannot scan %T into %s", v, field.Name)
+	}
+	return reflect.ValueOf(d).Convert(field.IndirectFieldType).Interface(), nil
+}
+
+// Vector is a VECTOR embedding, written and read as its text literal, [1.5,-2,0.25]:
+//
+//	Embedding oracle.Vector                                 // VECTOR
+//	Embedding []float32 `gorm:"type:vector(1536,FLOAT32)"` // VECTOR(1536,FLOAT32)
+//
+// The native VECTOR type needs Oracle 23ai; on earlier releases vector fields are stored as their
+// literal in a CLOB. As a bind variable a Vector is its literal, e.g. for TO_VECTOR(?).
+type Vector []float32
+
+// GormDataType gorm common data type
+func (Vector) GormDataType() string {
+	return "vector"
+}
+
+// Value return the vector literal, implement driver.Valuer interface
+func (v Vector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return formatVector(v), nil
+}
+
+// Scan the vector literal, implements sql.Scanner interface
+func (v *Vector) Scan(val interface{}) error {
+	var literal string
+	switch x := val.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		literal = x
+	case []byte:
+		literal = string(x)
+	case go_ora.Clob:
+		literal = x.String
+	default:
+		return fmt.Errorf("oracle: cannot scan %T into Vector", val)
+	}
+	parsed, err := parseVector(literal)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// formatVector formats v as a vector literal, [1.5,-2,0.25]
+func formatVector(v []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// parseVector parses a vector literal as formatted by formatVector or read from a VECTOR column
+func parseVector(literal string) ([]float32, error) {
+	s := strings.TrimSpace(literal)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("oracle: invalid vector literal %q", literal)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return []float32{}, nil
+	}
+	parts := strings.Split(s, ",")
+	v := make([]float32, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil, fmt.Errorf("oracle: invalid vector literal %q: %w", literal, err)
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
+// isVectorField reports whether field holds an embedding stored as VECTOR: a Vector, or a []float32
+// tagged `type:vector(...)`.
+func isVectorField(field *schema.Field) bool {
+	if field == nil || field.IndirectFieldType.Kind() != reflect.Slice || !field.IndirectFieldType.ConvertibleTo(tyVector) {
+		return false
+	}
+	dataType := strings.ToLower(strings.TrimSpace(string(field.DataType)))
+	return dataType == "vector" || strings.HasPrefix(dataType, "vector(")
+}
+
+// nativeVectors reports whether the dialector of stmt has the VECTOR type, Oracle 23ai and later
+func nativeVectors(stmt *gorm.Statement) bool {
+	if stmt == nil || stmt.DB == nil {
+		return false
+	}
+	v, _ := reflectDereference(stmt.DB.Dialector)
+	d, ok := v.(Dialector)
+	return ok && d.nativeVectors()
+}
+
+// convertToVector binds an embedding written to a vector field, see isVectorField, as its literal:
+// through TO_VECTOR(?) into a VECTOR column and as is into the CLOB holding it before Oracle 23ai. A
+// nil slice is bound as NULL; other values are returned as is.
+func convertToVector(stmt *gorm.Statement, field *schema.Field, val any) any {
+	if !isVectorField(field) {
+		return val
+	}
+	rval, _, _ := reflectValueDereference(val)
+	if !rval.IsValid() || (rval.Kind() == reflect.Slice && rval.IsNil()) {
+		return nil
+	}
+	var literal any
+	switch {
+	case rval.Kind() == reflect.String:
+		// the literal returned by Vector.Value
+		literal = rval.String()
+	case rval.Type().ConvertibleTo(tyVector):
+		literal = formatVector(rval.Convert(tyVector).Interface().([]float32))
+	default:
+		return val
+	}
+	if len(literal.(string)) > 2000 {
+		literal = go_ora.Clob{String: literal.(string), Valid: true}
+	}
+	if nativeVectors(stmt) {
+		return clause.Expr{SQL: "TO_VECTOR(?)", Vars: []any{literal}}
+	}
+	return literal
+}
+
+// scannedVector converts the value read from a vector field, see isVectorField, its literal as read
+// through FROM_VECTOR or from the CLOB holding it, to the type of the field.
+func scannedVector(field *schema.Field, val any) (any, error) {
+	v, _ := reflectDereference(val)
+	var vec Vector
+	if err := vec.Scan(v); err != nil {
+		return nil, fmt.Errorf("oracle: cannot scan into %s: %w", field.Name, err)
+	}
+	if vec == nil {
+		return nil, nil
+	}
+	return reflect.ValueOf([]float32(vec)).Convert(field.IndirectFieldType).Interface(), nil
+}
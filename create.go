@@ -1,6 +1,9 @@
 package oracle
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"strings"
@@ -9,14 +12,23 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils"
 )
 
+// defaultMergeBatchSize is the number of rows per MERGE statement unless Config.MergeBatchSize is set
+const defaultMergeBatchSize = 500
+
 func Create(db *gorm.DB) {
 	if db.Error != nil || db.Statement == nil {
 		return
 	}
 
 	stmt := db.Statement
+	if query, ok := stmt.Dest.(*gorm.DB); ok {
+		CreateFromQuery(db, query)
+		return
+	}
+
 	stmtSchema := stmt.Schema
 	if stmtSchema != nil && !stmt.Unscoped {
 		for _, c := range stmtSchema.CreateClauses {
@@ -28,6 +40,8 @@ func Create(db *gorm.DB) {
 		var (
 			createValues            = ConvertToCreateValues(stmt)
 			onConflict, hasConflict = stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict)
+			arrayVars               []interface{}
+			insertClauses           = []string{"INSERT", "VALUES"}
 		)
 
 		if hasConflict {
@@ -35,6 +49,14 @@ func Create(db *gorm.DB) {
 				_ = db.AddError(fmt.Errorf("oracle: OnConflict.TargetWhere is unsupported in MERGE path due to semantic ambiguity"))
 				return
 			}
+			if onConflict.OnConstraint != "" && len(onConflict.Columns) == 0 {
+				columns, err := constraintColumns(db, onConflict.OnConstraint)
+				if err != nil {
+					_ = db.AddError(err)
+					return
+				}
+				onConflict.Columns = columns
+			}
 
 			conflictDBNames := getMergeMatchDBNames(stmtSchema, onConflict)
 			if len(conflictDBNames) == 0 {
@@ -55,37 +77,77 @@ func Create(db *gorm.DB) {
 		}
 
 		if hasConflict {
+			if batchSize := mergeBatchSize(db); !db.DryRun && batchSize > 0 && len(createValues.Values) > batchSize {
+				mergeInBatches(db, onConflict, createValues, batchSize)
+				return
+			}
 			MergeCreate(db, onConflict, createValues)
 		} else {
 			stmt.AddClauseIfNotExists(clause.Insert{})
 			stmt.AddClause(clause.Values{Columns: createValues.Columns, Values: [][]interface{}{createValues.Values[0]}})
 			if returning := ReturningFieldsWithDefaultDBValue(stmtSchema, &createValues); len(returning.Names) > 0 {
 				stmt.AddClause(returning)
-				stmt.Build("INSERT", "VALUES", "RETURNING")
+				insertClauses = append(insertClauses, "RETURNING")
+				stmt.Build(insertClauses...)
+				writeLogErrors(stmt)
 			} else {
-				stmt.Build("INSERT", "VALUES")
+				stmt.Build(insertClauses...)
+				writeLogErrors(stmt)
+				arrayVars, _ = arrayBindVars(stmt.Vars, createValues)
 			}
 		}
 
 		if !db.DryRun && db.Error == nil {
 			if hasConflict {
+				// Oracle reports a single count for MERGE: the rows inserted plus the rows updated.
+				// Matched rows filtered out by OnConflict.Where are not counted.
 				result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 				if db.AddError(err) == nil {
 					db.RowsAffected, _ = result.RowsAffected()
-					// TODO: get merged returning
+					refreshMerged(db, onConflict, 0, len(createValues.Values))
+
+					if stmt.Result != nil {
+						stmt.Result.Result = result
+						stmt.Result.RowsAffected = db.RowsAffected
+					}
+				}
+			} else if arrayVars != nil {
+				// every row in a single execution, through array DML
+				result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), arrayVars...)
+				if db.AddError(err) == nil {
+					db.RowsAffected, _ = result.RowsAffected()
 				}
 			} else {
+				exec := stmt.ConnPool.ExecContext
+				shared := rowsBindAsOneVar(createValues)
+				if shared && len(createValues.Values) > 1 {
+					// parse once, then bind and execute each row of the batch
+					if prepared, err := stmt.ConnPool.PrepareContext(stmt.Context, stmt.SQL.String()); err == nil {
+						defer func() { _ = prepared.Close() }()
+						exec = func(ctx context.Context, _ string, args ...any) (sql.Result, error) {
+							return prepared.ExecContext(ctx, args...)
+						}
+					}
+				}
 				for idx, values := range createValues.Values {
-					for i, val := range values {
-						stmt.Vars[i] = val
+					switch {
+					case idx == 0:
+						// the statement was built for the first row
+					case shared:
+						for i, val := range values {
+							stmt.Vars[i] = val
+						}
+					default:
+						buildInsertRow(stmt, createValues, idx, insertClauses...)
 					}
 
-					result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+					result, err := exec(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 					if db.AddError(err) == nil {
 						rowsAffected, _ := result.RowsAffected()
 						db.RowsAffected += rowsAffected
 
-						if stmtSchema != nil && len(stmtSchema.FieldsWithDefaultDBValue) > 0 {
+						// a row rejected into the error logging table, see LogErrors, returns nothing
+						if rowsAffected > 0 && stmtSchema != nil && len(stmtSchema.FieldsWithDefaultDBValue) > 0 {
 							getDefaultValues(db, idx)
 						}
 					}
@@ -95,14 +157,175 @@ func Create(db *gorm.DB) {
 	}
 }
 
+// arrayBindVars turns vars, the bind variables of an INSERT built for the first row of values, into
+// arrays holding the value of every row, which go-ora binds as one array DML execution. Variables
+// past the columns, such as the tag of LogErrors, repeat for every row. ok is false for a single
+// row and when a value is bound through SQL of its own, like XMLTYPE(?), as the rows would then
+// not share the statement; Create then rebuilds the statement for each row, see buildInsertRow.
+func arrayBindVars(vars []interface{}, values clause.Values) (args []interface{}, ok bool) {
+	rows, columns := len(values.Values), len(values.Columns)
+	if rows < 2 || len(vars) < columns {
+		return nil, false
+	}
+	args = make([]interface{}, len(vars))
+	for i := range vars {
+		args[i] = make([]interface{}, rows)
+	}
+	for r, row := range values.Values {
+		if len(row) != columns {
+			return nil, false
+		}
+		for i, value := range row {
+			if !bindsAsOneVar(value) {
+				return nil, false
+			}
+			args[i].([]interface{})[r] = value
+		}
+		for i := columns; i < len(vars); i++ {
+			args[i].([]interface{})[r] = vars[i]
+		}
+	}
+	return args, true
+}
+
+// rowsBindAsOneVar reports whether every value of values binds as a single variable, so that the
+// INSERT built for the first row runs every other row by swapping its variables in place.
+func rowsBindAsOneVar(values clause.Values) bool {
+	for _, row := range values.Values {
+		for _, value := range row {
+			if !bindsAsOneVar(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// buildInsertRow rebuilds the INSERT of stmt for row idx of values alone, for rows holding a value
+// bound through SQL of its own, like XMLTYPE(?) or CAST(NULL AS XMLTYPE), whose statement and
+// variables differ from those of the first row. RETURNING binds into the fields of that row.
+func buildInsertRow(stmt *gorm.Statement, values clause.Values, idx int, clauses ...string) {
+	rv := stmt.ReflectValue
+	defer func() { stmt.ReflectValue = rv }()
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		stmt.ReflectValue = reflect.Indirect(rv.Index(idx))
+	}
+
+	stmt.SQL.Reset()
+	stmt.Vars = nil
+	stmt.AddClause(clause.Values{Columns: values.Columns, Values: [][]interface{}{values.Values[idx][:len(values.Columns)]}})
+	stmt.Build(clauses...)
+	writeLogErrors(stmt)
+}
+
+// bindsAsOneVar reports whether gorm binds value as a single variable, see gorm.Statement.AddVar;
+// expressions, subqueries and slices other than []byte write SQL of their own.
+func bindsAsOneVar(value interface{}) bool {
+	switch value.(type) {
+	case clause.Expression, gorm.Valuer, *gorm.DB:
+		return false
+	case driver.Valuer, []byte:
+		return true
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		return rv.Type().Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
+
+// CreateFromQuery builds an INSERT INTO ... SELECT statement copying the rows of query:
+//
+//	db.Table("archive").Create(db.Table("users").Select("name", "age").Where("age > ?", 60))
+//	// INSERT INTO "ARCHIVE" ("NAME","AGE") SELECT "NAME","AGE" FROM "USERS" WHERE age > 60
+//
+// The target columns are taken from Select on the insert statement, falling back to the columns
+// selected by query; without either the column list is omitted and query must return every column.
+func CreateFromQuery(db *gorm.DB, query *gorm.DB) {
+	stmt := db.Statement
+	// stmt.Schema was parsed from the *gorm.DB itself unless a model was given
+	stmtSchema := stmt.Schema
+	if stmt.Model == stmt.Dest {
+		stmtSchema = nil
+	}
+
+	names := stmt.Selects
+	if len(names) == 0 {
+		names = query.Statement.Selects
+	}
+	columns := insertColumns(stmtSchema, names)
+
+	stmt.AddClauseIfNotExists(clause.Insert{})
+	stmt.Build("INSERT")
+	_ = stmt.WriteByte(' ')
+	if len(columns) > 0 {
+		_ = stmt.WriteByte('(')
+		for idx, column := range columns {
+			if idx > 0 {
+				_ = stmt.WriteByte(',')
+			}
+			stmt.WriteQuoted(column)
+		}
+		_, _ = stmt.WriteString(") ")
+	}
+	stmt.AddVar(stmt, query)
+	writeLogErrors(stmt)
+
+	if !db.DryRun && db.Error == nil {
+		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		if db.AddError(err) == nil {
+			db.RowsAffected, _ = result.RowsAffected()
+		}
+	}
+}
+
+// insertColumns maps selected names to the insert column list; it returns nil when any of them is
+// an expression rather than a plain (optionally qualified) column name.
+func insertColumns(sch *schema.Schema, selects []string) []clause.Column {
+	var columns []clause.Column
+	for _, sel := range selects {
+		for _, name := range strings.Split(sel, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || strings.ContainsAny(name, "*@") || strings.IndexFunc(name, utils.IsInvalidDBNameChar) >= 0 {
+				return nil
+			}
+			name = name[strings.LastIndexByte(name, '.')+1:]
+			if sch != nil {
+				if field := sch.LookUpField(name); field != nil && field.DBName != "" {
+					name = field.DBName
+				}
+			}
+			columns = append(columns, clause.Column{Name: name})
+		}
+	}
+	return columns
+}
+
+// MergeCreate builds a MERGE INTO ... USING (SELECT ... FROM DUAL UNION ALL ...) statement for
+// clause.OnConflict upserts; from Oracle 23 on the source rows are selected without FROM DUAL.
+//
+// When executed, db.RowsAffected is the total number of rows merged, i.e. inserted rows plus
+// updated rows, as reported by Oracle; the split between the two is not available. Server-filled
+// fields are read back afterward rather than through RETURNING, see refreshMerged, so under
+// DryRun, and through db.ToSQL, the statement is the MERGE executed, bind placeholders and all.
+// onConflict is left as given, building the same upsert again yields the same statement.
+//
+// With onConflict.DoNothing only the WHEN NOT MATCHED THEN INSERT branch is written: rows matching
+// an existing one are skipped and db.RowsAffected counts the inserted rows alone.
 func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values) {
-	dummyTable := getDummyTable(db)
+	dummyFrom := getDummyFrom(db)
+	if onConflict.DoNothing {
+		values.Values = distinctMergeRows(db.Statement.Schema, onConflict, values)
+	}
 	var prioritizedPrimaryField *schema.Field
 	if db.Statement.Schema != nil {
 		prioritizedPrimaryField = db.Statement.Schema.PrioritizedPrimaryField
 	}
 
-	_, _ = db.Statement.WriteString("MERGE INTO ")
+	_, _ = db.Statement.WriteString("MERGE ")
+	if hint := dmlHint(db.Statement, "MERGE"); hint != "" {
+		_, _ = db.Statement.WriteString("/*+ " + hint + " */ ")
+	}
+	_, _ = db.Statement.WriteString("INTO ")
 	db.Statement.WriteQuoted(db.Statement.Table)
 	_, _ = db.Statement.WriteString(" USING (")
 
@@ -111,6 +334,8 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 		precision int
 		notnull   bool
 	})
+	// a single space stands in for an empty string in a NOT NULL column unless EmptyStringAsNull is set
+	spaceForEmpty := emptyStringAsNull(db.Statement) == nil
 	for idx, value := range values.Values {
 		if idx > 0 {
 			_, _ = db.Statement.WriteString(" UNION ALL ")
@@ -136,7 +361,7 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 					if f := db.Statement.Schema.LookUpField(column.Name); f != nil {
 						dataType = db.Statement.DataTypeOf(f)
 						precision = f.Precision
-						notnull = f.NotNull
+						notnull = f.NotNull && spaceForEmpty
 						fcache[column.Name] = struct {
 							dataType  string
 							precision int
@@ -145,12 +370,11 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 					}
 				}
 			}
-			db.Statement.AddVar(db.Statement, castValue(v, dataType, precision, notnull))
+			db.Statement.AddVar(db.Statement, castValue(db.Statement, v, dataType, precision, notnull))
 			_, _ = db.Statement.WriteString(" AS ")
 			db.Statement.WriteQuoted(column.Name)
 		}
-		_, _ = db.Statement.WriteString(" FROM ")
-		_, _ = db.Statement.WriteString(dummyTable)
+		_, _ = db.Statement.WriteString(dummyFrom)
 	}
 
 	_, _ = db.Statement.WriteString(`) `)
@@ -167,7 +391,9 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 	where.Build(db.Statement)
 	_ = db.Statement.WriteByte(')')
 
-	if len(onConflict.DoUpdates) > 0 {
+	if !onConflict.DoNothing && len(onConflict.DoUpdates) > 0 {
+		// the values are cast below, leave those of the caller's clause alone
+		onConflict.DoUpdates = append(clause.Set(nil), onConflict.DoUpdates...)
 		_, _ = db.Statement.WriteString(" WHEN MATCHED THEN UPDATE SET ")
 		for idx := range onConflict.DoUpdates {
 			var (
@@ -184,7 +410,7 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 					if f := db.Statement.Schema.LookUpField(onConflict.DoUpdates[idx].Column.Name); f != nil {
 						dataType = db.Statement.DataTypeOf(f)
 						precision = f.Precision
-						notnull = f.NotNull
+						notnull = f.NotNull && spaceForEmpty
 						fcache[onConflict.DoUpdates[idx].Column.Name] = struct {
 							dataType  string
 							precision int
@@ -193,7 +419,7 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 					}
 				}
 			}
-			onConflict.DoUpdates[idx].Value = castValue(onConflict.DoUpdates[idx].Value, dataType, precision, notnull)
+			onConflict.DoUpdates[idx].Value = castValue(db.Statement, onConflict.DoUpdates[idx].Value, dataType, precision, notnull)
 		}
 		onConflict.DoUpdates.Build(db.Statement)
 		if len(onConflict.Where.Exprs) > 0 {
@@ -231,17 +457,230 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 		}
 	}
 	_, _ = db.Statement.WriteString(")")
+	writeLogErrors(db.Statement)
 }
 
-func getDummyTable(db *gorm.DB) (dummyTable string) {
-	v, _ := reflectDereference(db.Dialector)
-	switch d := v.(type) {
-	case Dialector:
-		dummyTable = d.DummyTableName()
+// distinctMergeRows drops the rows repeating the conflict key of an earlier row of values. A MERGE
+// inserts every source row left unmatched, so with DoNothing a key repeated within one statement
+// would still violate the constraint the conflict target stands for. Rows with a NULL key never
+// match and are all kept.
+func distinctMergeRows(sch *schema.Schema, onConflict clause.OnConflict, values clause.Values) [][]interface{} {
+	if len(values.Values) < 2 {
+		return values.Values
+	}
+	dbNames := getMergeMatchDBNames(sch, onConflict)
+	keyIdx := make([]int, 0, len(dbNames))
+	for _, dbName := range dbNames {
+		for i, column := range values.Columns {
+			if strings.EqualFold(column.Name, dbName) {
+				keyIdx = append(keyIdx, i)
+				break
+			}
+		}
+	}
+	if len(keyIdx) == 0 || len(keyIdx) != len(dbNames) {
+		return values.Values
+	}
+
+	seen := make(map[string]bool, len(values.Values))
+	rows := make([][]interface{}, 0, len(values.Values))
+	for _, row := range values.Values {
+		var key strings.Builder
+		for _, i := range keyIdx {
+			v, _ := reflectDereference(row[i])
+			if v == nil {
+				key.Reset()
+				break
+			}
+			_, _ = fmt.Fprintf(&key, "%#v\x00", v)
+		}
+		if key.Len() > 0 {
+			if seen[key.String()] {
+				continue
+			}
+			seen[key.String()] = true
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// mergeInBatches runs one MERGE per batchSize rows of values, keeping statements and their bind
+// lists bounded; db.RowsAffected is the total over all batches.
+func mergeInBatches(db *gorm.DB, onConflict clause.OnConflict, values clause.Values, batchSize int) {
+	stmt := db.Statement
+	for start := 0; start < len(values.Values) && db.Error == nil; start += batchSize {
+		end := min(start+batchSize, len(values.Values))
+		stmt.SQL.Reset()
+		stmt.Vars = nil
+		MergeCreate(db, onConflict, clause.Values{Columns: values.Columns, Values: values.Values[start:end]})
+
+		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		if db.AddError(err) == nil {
+			rowsAffected, _ := result.RowsAffected()
+			db.RowsAffected += rowsAffected
+			refreshMerged(db, onConflict, start, end)
+			if stmt.Result != nil {
+				stmt.Result.Result = result
+				stmt.Result.RowsAffected = db.RowsAffected
+			}
+		}
+	}
+}
+
+// refreshMerged reads back the server-filled fields, FieldsWithDefaultDBValue such as identity keys,
+// of the rows start to end of the merged values, selecting them by the columns the MERGE matched on.
+// Oracle has no RETURNING for MERGE before 23, and even then none returning the rows of a multi-row
+// MERGE to a client, so the rows are looked up once the MERGE is done.
+func refreshMerged(db *gorm.DB, onConflict clause.OnConflict, start, end int) {
+	stmt := db.Statement
+	if stmt.Schema == nil || db.DryRun || db.Error != nil {
+		return
+	}
+	fields := make([]*schema.Field, 0, len(stmt.Schema.FieldsWithDefaultDBValue))
+	for _, f := range stmt.Schema.FieldsWithDefaultDBValue {
+		if isReturnableField(f) {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	matchDBNames := getMergeMatchDBNames(stmt.Schema, onConflict)
+	matchFields := make([]*schema.Field, 0, len(matchDBNames))
+	for _, dbName := range matchDBNames {
+		f := stmt.Schema.LookUpField(dbName)
+		if f == nil {
+			return
+		}
+		matchFields = append(matchFields, f)
+	}
+
+	rv := reflect.Indirect(stmt.ReflectValue)
+	var rows []reflect.Value
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := start; i < end && i < rv.Len(); i++ {
+			rows = append(rows, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		rows = append(rows, rv)
 	default:
-		dummyTable = "DUAL"
+		return
+	}
+
+	key := func(row reflect.Value) string {
+		var b strings.Builder
+		for _, f := range matchFields {
+			fmt.Fprintf(&b, "%v\x00", reflect.Indirect(f.ReflectValueOf(stmt.Context, row)))
+		}
+		return b.String()
+	}
+	keys := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		if row.Kind() != reflect.Struct {
+			return
+		}
+		values := make([]interface{}, 0, len(matchFields))
+		for _, f := range matchFields {
+			v, _ := f.ValueOf(stmt.Context, row)
+			values = append(values, v)
+		}
+		keys = append(keys, values)
+	}
+
+	selects := make([]string, 0, len(matchFields)+len(fields))
+	for _, f := range append(append([]*schema.Field(nil), matchFields...), fields...) {
+		selects = append(selects, f.DBName)
+	}
+	column, queryValues := schema.ToQueryValues(stmt.Table, matchDBNames, keys)
+	merged := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Table(stmt.Table).Select(selects).
+		Where(clause.IN{Column: column, Values: queryValues}).Find(merged.Interface()).Error
+	if db.AddError(err) != nil {
+		return
+	}
+
+	byKey := make(map[string]reflect.Value, merged.Elem().Len())
+	for i := 0; i < merged.Elem().Len(); i++ {
+		row := merged.Elem().Index(i)
+		byKey[key(row)] = row
+	}
+	for _, row := range rows {
+		found, ok := byKey[key(row)]
+		if !ok {
+			continue
+		}
+		for _, f := range fields {
+			v, _ := f.ValueOf(stmt.Context, found)
+			if db.AddError(f.Set(stmt.Context, row, v)) != nil {
+				return
+			}
+		}
+	}
+}
+
+func mergeBatchSize(db *gorm.DB) int {
+	v, _ := reflectDereference(db.Dialector)
+	if d, ok := v.(Dialector); ok && d.Config != nil && d.MergeBatchSize != 0 {
+		return d.MergeBatchSize
+	}
+	return defaultMergeBatchSize
+}
+
+func getDummyFrom(db *gorm.DB) string {
+	v, _ := reflectDereference(db.Dialector)
+	if d, ok := v.(Dialector); ok {
+		return d.dummyFrom()
+	}
+	return " FROM DUAL"
+}
+
+// constraintColumns returns the columns of the named unique constraint (or unique index) of the
+// statement's table, for OnConflict.OnConstraint.
+func constraintColumns(db *gorm.DB, name string) ([]clause.Column, error) {
+	ns := getNS(db, db.Dialector)
+	owner, tab, hasOwner := ns.dictQualifiedParts(db.Statement.Table)
+	cname := ns.dictCasePart(name)
+
+	var queries []string
+	var args []interface{}
+	if hasOwner {
+		queries = []string{
+			`SELECT COLUMN_NAME FROM ALL_CONS_COLUMNS
+			  WHERE OWNER = :owner AND TABLE_NAME = :tab AND CONSTRAINT_NAME = :c ORDER BY POSITION`,
+			`SELECT COLUMN_NAME FROM ALL_IND_COLUMNS
+			  WHERE TABLE_OWNER = :owner AND TABLE_NAME = :tab AND INDEX_NAME = :c ORDER BY COLUMN_POSITION`,
+		}
+		args = []interface{}{sql.Named("owner", owner), sql.Named("tab", tab), sql.Named("c", cname)}
+	} else {
+		queries = []string{
+			`SELECT COLUMN_NAME FROM USER_CONS_COLUMNS
+			  WHERE TABLE_NAME = :tab AND CONSTRAINT_NAME = :c ORDER BY POSITION`,
+			`SELECT COLUMN_NAME FROM USER_IND_COLUMNS
+			  WHERE TABLE_NAME = :tab AND INDEX_NAME = :c ORDER BY COLUMN_POSITION`,
+		}
+		args = []interface{}{sql.Named("tab", tab), sql.Named("c", cname)}
+	}
+
+	var cols []string
+	for _, q := range queries {
+		if err := db.Session(&gorm.Session{NewDB: true}).Raw(q, args...).Scan(&cols).Error; err != nil {
+			return nil, err
+		}
+		if len(cols) > 0 {
+			break
+		}
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("oracle: OnConflict.OnConstraint %q not found on table %s", name, db.Statement.Table)
+	}
+
+	columns := make([]clause.Column, 0, len(cols))
+	for _, col := range cols {
+		columns = append(columns, clause.Column{Name: col})
 	}
-	return
+	return columns, nil
 }
 
 func getMergeMatchDBNames(stmtSchema *schema.Schema, onConflict clause.OnConflict) []string {
@@ -1,14 +1,20 @@
 package oracle
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
+	"math"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 func TestMergeCreate(t *testing.T) {
@@ -254,6 +260,225 @@ func TestMergeCreateOnConflictColumnsExecution(t *testing.T) {
 	assert.Equal(t, "Beta", got.Name, "expected matched-update WHERE to prevent update")
 }
 
+type TestTableSQLNull struct {
+	ID      uint64         `gorm:"primaryKey;autoIncrement:false"`
+	String  sql.NullString `gorm:"size:20"`
+	Int64   sql.NullInt64
+	Int32   sql.NullInt32
+	Int16   sql.NullInt16
+	Byte    sql.NullByte
+	Float64 sql.NullFloat64
+	Bool    sql.NullBool
+	Time    sql.NullTime
+	Generic sql.Null[string] `gorm:"size:20"`
+}
+
+func (TestTableSQLNull) TableName() string {
+	return "test_sql_null"
+}
+
+func TestMergeCreateSQLNullTypes(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	_ = db.Migrator().DropTable(&TestTableSQLNull{})
+	require.NoError(t, db.AutoMigrate(&TestTableSQLNull{}), "expecting no error")
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := []TestTableSQLNull{
+		{
+			ID:      1,
+			String:  sql.NullString{String: "a", Valid: true},
+			Int64:   sql.NullInt64{Int64: math.MaxInt32 + 1, Valid: true},
+			Int32:   sql.NullInt32{Int32: -32, Valid: true},
+			Int16:   sql.NullInt16{Int16: 16, Valid: true},
+			Byte:    sql.NullByte{Byte: 8, Valid: true},
+			Float64: sql.NullFloat64{Float64: 1.5, Valid: true},
+			Bool:    sql.NullBool{Bool: true, Valid: true},
+			Time:    sql.NullTime{Time: ts, Valid: true},
+			Generic: sql.Null[string]{V: "g", Valid: true},
+		},
+		{ID: 2},
+	}
+	for range 2 {
+		// the first pass inserts through MERGE, the second updates the matched rows
+		require.NoError(t, db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&rows).Error, "expecting no error")
+	}
+
+	var got []TestTableSQLNull
+	require.NoError(t, db.Order("id").Find(&got).Error, "expecting no error")
+	require.Len(t, got, 2)
+	require.True(t, rows[0].Time.Time.Equal(got[0].Time.Time), "expecting %v, got %v", rows[0].Time.Time, got[0].Time.Time)
+	got[0].Time.Time = rows[0].Time.Time
+	require.Equal(t, rows, got)
+}
+
+type TestTableMergeShape struct {
+	ID   uint64 `gorm:"primaryKey;autoIncrement"`
+	Code string `gorm:"size:10;unique"`
+	Name string `gorm:"size:20"`
+}
+
+func (TestTableMergeShape) TableName() string {
+	return "test_merge_shape"
+}
+
+func TestMergeCreateToSQL(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	rows := []TestTableMergeShape{{Code: "A", Name: "a"}, {Code: "B", Name: "b"}}
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.Assignments(map[string]any{"name": "z"}),
+	}
+	toSQL := func() string {
+		return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Clauses(onConflict).Create(&rows)
+		})
+	}
+
+	merge := toSQL()
+	require.Equal(t, merge, toSQL(), "expecting the same statement when built again")
+	require.Equal(t, clause.Set{{Column: clause.Column{Name: "name"}, Value: "z"}}, onConflict.DoUpdates, "expecting the clause to be left as given")
+	require.True(t, strings.HasPrefix(merge, "MERGE INTO "), merge)
+	require.Equal(t, 1, strings.Count(merge, " UNION ALL SELECT "), merge)
+	for _, part := range []string{" USING (SELECT ", ") EXCLUDED ON (", " WHEN MATCHED THEN UPDATE SET ", " WHEN NOT MATCHED THEN INSERT ("} {
+		require.Contains(t, merge, part)
+	}
+	require.NotContains(t, merge, "RETURNING")
+
+	stmt := db.Session(&gorm.Session{DryRun: true}).Clauses(onConflict).Create(&rows).Statement
+	for _, bind := range []string{":1 ", ":2 ", ":3 ", ":4 ", ":5 "} {
+		require.Contains(t, stmt.SQL.String(), bind)
+	}
+	require.NotContains(t, stmt.SQL.String(), "'A'")
+	require.Equal(t, []any{"A", "a", "B", "b", "z"}, stmt.Vars)
+	require.Zero(t, rows[0].ID, "expecting nothing read back under DryRun")
+}
+
+type TestTableTenantCode struct {
+	ID     uint64 `gorm:"primaryKey;autoIncrement"`
+	Tenant string `gorm:"size:20;uniqueIndex:uk_tenant_code"`
+	Code   string `gorm:"size:20;uniqueIndex:uk_tenant_code"`
+	Name   string `gorm:"size:50"`
+}
+
+func (TestTableTenantCode) TableName() string {
+	return "test_tenant_code"
+}
+
+func TestMergeCreateCompositeConflictColumns(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	_ = db.Migrator().DropTable(&TestTableTenantCode{})
+	require.NoError(t, db.AutoMigrate(&TestTableTenantCode{}), "expecting no error")
+
+	// the business key, by field and by column name, rather than the primary key
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "Tenant"}, {Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Clauses(onConflict).Create(&TestTableTenantCode{Tenant: "t1", Code: "a"}).Statement
+	require.Regexp(t, `ON \(\S+\.TENANT = EXCLUDED\.TENANT AND \S+\.CODE = EXCLUDED\.CODE\)`, stmt.SQL.String())
+
+	rows := []TestTableTenantCode{{Tenant: "t1", Code: "a", Name: "one"}, {Tenant: "t2", Code: "a", Name: "two"}}
+	require.NoError(t, db.Clauses(onConflict).Create(&rows).Error, "expecting no error inserting")
+	rows = []TestTableTenantCode{{Tenant: "t1", Code: "a", Name: "uno"}, {Tenant: "t1", Code: "b", Name: "three"}}
+	res := db.Clauses(onConflict).Create(&rows)
+	require.NoError(t, res.Error, "expecting matched rows to update rather than violate uk_tenant_code")
+	require.EqualValues(t, 2, res.RowsAffected)
+
+	var got []TestTableTenantCode
+	require.NoError(t, db.Order("tenant, code").Find(&got).Error)
+	require.Len(t, got, 3)
+	assert.Equal(t, []string{"uno", "three", "two"}, []string{got[0].Name, got[1].Name, got[2].Name})
+}
+
+func TestMergeCreateDoNothing(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	_ = db.Migrator().DropTable(&TestTableTenantCode{})
+	require.NoError(t, db.AutoMigrate(&TestTableTenantCode{}), "expecting no error")
+
+	// DoUpdates given alongside DoNothing are not written
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant"}, {Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+		DoNothing: true,
+	}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Clauses(onConflict).Create(&TestTableTenantCode{Tenant: "t1", Code: "a"}).Statement
+	require.NotContains(t, stmt.SQL.String(), "WHEN MATCHED")
+	require.Contains(t, stmt.SQL.String(), "WHEN NOT MATCHED THEN INSERT")
+
+	require.NoError(t, db.Create(&TestTableTenantCode{Tenant: "t1", Code: "a", Name: "one"}).Error, "expecting no error inserting")
+	rows := []TestTableTenantCode{
+		{Tenant: "t1", Code: "a", Name: "uno"},
+		{Tenant: "t1", Code: "b", Name: "two"},
+		{Tenant: "t2", Code: "a", Name: "three"},
+		{Tenant: "t2", Code: "a", Name: "tres"},
+	}
+	res := db.Clauses(onConflict).Create(&rows)
+	require.NoError(t, res.Error, "expecting conflicting rows to be skipped rather than violate uk_tenant_code")
+	require.EqualValues(t, 2, res.RowsAffected)
+
+	var got []TestTableTenantCode
+	require.NoError(t, db.Order("tenant, code").Find(&got).Error)
+	require.Len(t, got, 3)
+	assert.Equal(t, []string{"one", "two", "three"}, []string{got[0].Name, got[1].Name, got[2].Name})
+}
+
+func Test_distinctMergeRows(t *testing.T) {
+	sch, err := schema.Parse(&TestTableTenantCode{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	code := "a"
+	values := clause.Values{
+		Columns: []clause.Column{{Name: "tenant"}, {Name: "code"}, {Name: "name"}},
+		Values: [][]interface{}{
+			{"t1", "a", "one"},
+			{"t1", &code, "uno"},
+			{"t1", "b", "two"},
+			{nil, "a", "three"},
+			{nil, "a", "tres"},
+		},
+	}
+	onConflict := clause.OnConflict{Columns: []clause.Column{{Name: "tenant"}, {Name: "code"}}, DoNothing: true}
+	got := distinctMergeRows(sch, onConflict, values)
+	require.Len(t, got, 4)
+	assert.Equal(t, []interface{}{"one", "two", "three", "tres"}, []interface{}{got[0][2], got[1][2], got[2][2], got[3][2]})
+
+	// without the key among the columns the rows are kept as given
+	onConflict.Columns = []clause.Column{{Name: "id"}}
+	require.Len(t, distinctMergeRows(sch, onConflict, values), 5)
+}
+
 type testModelOra03146TTC struct {
 	Id          int64     `gorm:"primaryKey;autoIncrement:false;type:uint;size:20;default:0;comment:id" json:"SL_ID"`
 	ApiName     string    `gorm:"type:VARCHAR2;size:100;default:null;comment:Interface Name" json:"SL_API_NAME"`
@@ -367,3 +592,580 @@ func TestCreateInBatches(t *testing.T) {
 		t.Logf("result: %s", dataJsonBytes)
 	})
 }
+
+func TestMergeCreateRowsAffectedMixedBatch(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := TestTableUserUnique{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+
+	require.NoError(t, db.Create(&TestTableUserUnique{UID: "U1", Name: "Alpha", Enabled: true}).Error, "expecting no error inserting base row")
+
+	batch := []TestTableUserUnique{
+		{UID: "U1", Name: "Beta", Enabled: true},
+		{UID: "U2", Name: "Gamma", Enabled: true},
+		{UID: "U3", Name: "Delta", Enabled: true},
+	}
+	res := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "uid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(&batch)
+	require.NoError(t, res.Error, "expecting no error on mixed upsert")
+	assert.EqualValues(t, 3, res.RowsAffected, "expected one updated row plus two inserted rows")
+
+	var count int64
+	require.NoError(t, db.Model(&TestTableUserUnique{}).Count(&count).Error, "expecting no error counting rows")
+	assert.EqualValues(t, 3, count, "expected the existing row to be updated rather than duplicated")
+}
+
+func TestCreateFromQuery(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	source, target := TestTableUserUnique{}, testNoDefaultDBValues{}
+	_ = db.Migrator().DropTable(source, target)
+	require.NoError(t, db.Migrator().AutoMigrate(source, target), "expecting no error")
+
+	users := []TestTableUserUnique{
+		{UID: "U1", Name: "Alpha", Enabled: true},
+		{UID: "U2", Name: "Beta", Enabled: true},
+		{UID: "U3", Name: "Gamma", Enabled: false},
+	}
+	require.NoError(t, db.Create(&users).Error, "expecting no error inserting source rows")
+
+	enabled := db.Model(&TestTableUserUnique{}).Select("uid", "name").Where(map[string]any{"enabled": true})
+
+	dryRun := db.Session(&gorm.Session{DryRun: true}).Table(target.TableName()).Create(enabled)
+	require.NoError(t, dryRun.Error, "expecting no error building INSERT ... SELECT")
+	sql := strings.ToUpper(dryRun.Statement.SQL.String())
+	assert.True(t, strings.HasPrefix(sql, `INSERT INTO "TEST_NO_DEFAULT_DB_VALUES" ("UID","NAME") SELECT`), "unexpected SQL: %s", sql)
+
+	res := db.Table(target.TableName()).Create(enabled)
+	require.NoError(t, res.Error, "expecting no error copying rows")
+	assert.EqualValues(t, 2, res.RowsAffected, "expected the enabled rows to be copied")
+
+	res = db.Model(&testNoDefaultDBValues{}).Select("uid", "name").Create(db.Model(&TestTableUserUnique{}).Select("uid", "name").Where(map[string]any{"uid": "U3"}))
+	require.NoError(t, res.Error, "expecting no error copying rows through a model")
+	assert.EqualValues(t, 1, res.RowsAffected, "expected the selected row to be copied")
+
+	var copied []testNoDefaultDBValues
+	require.NoError(t, db.Order("uid").Find(&copied).Error, "expecting no error reading copied rows")
+	require.Len(t, copied, 3)
+	assert.Equal(t, "Alpha", copied[0].Name)
+	assert.Equal(t, "Beta", copied[1].Name)
+	assert.Equal(t, "Gamma", copied[2].Name)
+}
+
+func TestCreateFromQueryAppend(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	source, target := TestTableUserUnique{}, testNoDefaultDBValues{}
+	_ = db.Migrator().DropTable(source, target)
+	require.NoError(t, db.Migrator().AutoMigrate(source, target), "expecting no error")
+	require.NoError(t, db.Create(&[]TestTableUserUnique{
+		{UID: "U1", Name: "Alpha", Enabled: true},
+		{UID: "U2", Name: "Beta", Enabled: true},
+	}).Error, "expecting no error inserting source rows")
+
+	all := db.Model(&TestTableUserUnique{}).Select("uid", "name")
+
+	dryRun := db.Session(&gorm.Session{DryRun: true}).Clauses(Append()).Table(target.TableName()).Create(all)
+	require.NoError(t, dryRun.Error, "expecting no error building INSERT /*+ APPEND */ ... SELECT")
+	sql := strings.ToUpper(dryRun.Statement.SQL.String())
+	assert.True(t, strings.HasPrefix(sql, `INSERT /*+ APPEND */ INTO "TEST_NO_DEFAULT_DB_VALUES" ("UID","NAME") SELECT`), "expecting the hint right after INSERT: %s", sql)
+
+	tx := db.Begin()
+	require.NoError(t, tx.Error)
+	res := tx.Clauses(Append()).Table(target.TableName()).Create(all)
+	if !assert.NoError(t, res.Error, "expecting no error loading rows direct-path") {
+		tx.Rollback()
+		return
+	}
+	assert.EqualValues(t, 2, res.RowsAffected)
+	require.NoError(t, tx.Commit().Error, "expecting the direct-path insert to commit")
+
+	var copied []testNoDefaultDBValues
+	require.NoError(t, db.Order("uid").Find(&copied).Error, "expecting the committed rows to be readable")
+	require.Len(t, copied, 2)
+	assert.Equal(t, "Alpha", copied[0].Name)
+	assert.Equal(t, "Beta", copied[1].Name)
+}
+
+func TestMergeCreateOnConstraint(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := TestTableUserUnique{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+	require.NoError(t, db.Exec(`ALTER TABLE "TEST_USER_UNIQUE" ADD CONSTRAINT UK_TEST_USER_ACCOUNT UNIQUE ("ACCOUNT")`).Error)
+
+	require.NoError(t, db.Create(&TestTableUserUnique{UID: "U1", Name: "Alpha", Account: "alpha", Enabled: true}).Error)
+
+	upsert := func(rows ...TestTableUserUnique) *gorm.DB {
+		return db.Clauses(clause.OnConflict{
+			OnConstraint: "uk_test_user_account",
+			DoUpdates:    clause.AssignmentColumns([]string{"name"}),
+		}).Create(&rows)
+	}
+	res := upsert(
+		TestTableUserUnique{UID: "U2", Name: "Beta", Account: "alpha", Enabled: true},
+		TestTableUserUnique{UID: "U3", Name: "Gamma", Account: "gamma", Enabled: true},
+	)
+	require.NoError(t, res.Error, "expecting no error upserting on the named constraint")
+	assert.EqualValues(t, 2, res.RowsAffected, "expected one updated row plus one inserted row")
+
+	var users []TestTableUserUnique
+	require.NoError(t, db.Order("account").Find(&users).Error)
+	require.Len(t, users, 2)
+	assert.Equal(t, "U1", users[0].UID, "expected the row matched by account to keep its uid")
+	assert.Equal(t, "Beta", users[0].Name)
+	assert.Equal(t, "Gamma", users[1].Name)
+
+	res = db.Clauses(clause.OnConflict{OnConstraint: "uk_missing", UpdateAll: true}).Create(&TestTableUserUnique{UID: "U4", Account: "delta"})
+	require.ErrorContains(t, res.Error, `OnConflict.OnConstraint "uk_missing" not found`)
+}
+
+func TestMergeCreateInBatches(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := TestTableUserUnique{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+
+	const total = 5000
+	existing := make([]TestTableUserUnique, 0, total/2)
+	for i := 0; i < total; i += 2 {
+		existing = append(existing, TestTableUserUnique{UID: fmt.Sprintf("U%05d", i), Name: "old", Enabled: true})
+	}
+	require.NoError(t, db.CreateInBatches(&existing, 500).Error, "expecting no error inserting base rows")
+
+	rows := make([]TestTableUserUnique, total)
+	for i := range rows {
+		rows[i] = TestTableUserUnique{UID: fmt.Sprintf("U%05d", i), Name: "new", Enabled: true}
+	}
+	res := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "uid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(&rows)
+	require.NoError(t, res.Error, "expecting no error upserting in batches")
+	assert.EqualValues(t, total, res.RowsAffected, "expected updated plus inserted rows over all batches")
+	assert.Equal(t, defaultMergeBatchSize-1, strings.Count(res.Statement.SQL.String(), " UNION ALL "), "expected the last statement to merge a single batch")
+	assert.LessOrEqual(t, len(res.Statement.Vars), defaultMergeBatchSize*len(res.Statement.Schema.DBNames), "expected binds for a single batch")
+
+	var count, updated int64
+	require.NoError(t, db.Model(&TestTableUserUnique{}).Count(&count).Error)
+	assert.EqualValues(t, total, count, "expected existing rows to be updated rather than duplicated")
+	require.NoError(t, db.Model(&TestTableUserUnique{}).Where("name = ?", "new").Count(&updated).Error)
+	assert.EqualValues(t, total, updated)
+}
+
+type testMergeRefresh struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UID       string    `gorm:"size:50;unique"`
+	Name      string    `gorm:"size:50"`
+	CreatedOn time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func TestMergeCreateRefreshesDefaults(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	model := testMergeRefresh{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+
+	existing := testMergeRefresh{UID: "U1", Name: "old"}
+	require.NoError(t, db.Create(&existing).Error, "expecting no error inserting base row")
+	require.NotZero(t, existing.ID)
+
+	upsert := clause.OnConflict{Columns: []clause.Column{{Name: "uid"}}, DoUpdates: clause.AssignmentColumns([]string{"name"})}
+	rows := []testMergeRefresh{{UID: "U1", Name: "new"}, {UID: "U2", Name: "new"}, {UID: "U3", Name: "new"}}
+	res := db.Clauses(upsert).Create(&rows)
+	require.NoError(t, res.Error, "expecting no error upserting")
+	assert.EqualValues(t, 3, res.RowsAffected, "expected one updated plus two inserted rows")
+	assert.Contains(t, res.Statement.SQL.String(), "MERGE INTO")
+
+	assert.Equal(t, existing.ID, rows[0].ID, "expected the matched row's key")
+	assert.NotZero(t, rows[1].ID, "expected the inserted row's identity")
+	assert.NotZero(t, rows[2].ID, "expected the inserted row's identity")
+	assert.NotEqual(t, rows[1].ID, rows[2].ID)
+	for _, row := range rows {
+		assert.False(t, row.CreatedOn.IsZero(), "expected the server default of %s", row.UID)
+	}
+
+	var stored []testMergeRefresh
+	require.NoError(t, db.Order("uid").Find(&stored).Error)
+	require.Len(t, stored, 3)
+	for i := range stored {
+		assert.Equal(t, stored[i].ID, rows[i].ID)
+	}
+
+	// batches are refreshed one by one
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(size int) { cfg.MergeBatchSize = size }(cfg.MergeBatchSize)
+	cfg.MergeBatchSize = 2
+	more := []testMergeRefresh{{UID: "U3", Name: "newer"}, {UID: "U4", Name: "newer"}, {UID: "U5", Name: "newer"}}
+	require.NoError(t, db.Clauses(upsert).Create(&more).Error, "expecting no error upserting in batches")
+	assert.Equal(t, rows[2].ID, more[0].ID)
+	assert.NotZero(t, more[1].ID)
+	assert.NotZero(t, more[2].ID)
+}
+
+func TestCreatePreparedRows(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := TestTableUserUnique{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+
+	const total = 1000
+	rows := make([]TestTableUserUnique, total)
+	for i := range rows {
+		rows[i] = TestTableUserUnique{UID: fmt.Sprintf("U%04d", i), Name: fmt.Sprintf("name %d", i), UserType: i % 7, Enabled: i%2 == 0}
+	}
+	res := db.Create(&rows)
+	require.NoError(t, res.Error, "expecting no error")
+	assert.EqualValues(t, total, res.RowsAffected)
+
+	ids := make(map[uint64]bool, total)
+	for _, row := range rows {
+		require.NotZero(t, row.ID, "expecting the RETURNING id of every row")
+		ids[row.ID] = true
+	}
+	assert.Len(t, ids, total, "expecting distinct ids")
+
+	var stored []TestTableUserUnique
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	require.Len(t, stored, total)
+	for i, row := range stored {
+		assert.Equal(t, rows[i].ID, row.ID)
+		assert.Equal(t, rows[i].UID, row.UID)
+		assert.Equal(t, rows[i].Name, row.Name)
+		assert.Equal(t, rows[i].UserType, row.UserType)
+		assert.Equal(t, rows[i].Enabled, row.Enabled)
+	}
+}
+
+func TestCreateRowsBoundThroughSQL(t *testing.T) {
+	assert.True(t, rowsBindAsOneVar(clause.Values{Values: [][]interface{}{{1, "a"}, {2, nil}}}))
+	assert.False(t, rowsBindAsOneVar(clause.Values{Values: [][]interface{}{{1, "a"}, {2, clause.Expr{SQL: "CAST(NULL AS XMLTYPE)"}}}}))
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	_ = db.Migrator().DropTable(&TestTableXML{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableXML{}), "expecting no error")
+
+	// XMLTYPE(?) and the variable-less CAST(NULL AS XMLTYPE) give the rows statements of their own
+	rows := []TestTableXML{
+		{Name: "first", Doc: XML(`<a>1</a>`)},
+		{Name: "null"},
+		{Name: "third", Doc: XML(`<a>3</a>`)},
+	}
+	res := db.Create(&rows)
+	require.NoError(t, res.Error, "expecting no error")
+	assert.EqualValues(t, 3, res.RowsAffected)
+	for _, row := range rows {
+		require.NotZero(t, row.ID, "expecting the RETURNING id of every row")
+	}
+	assert.NotEqual(t, rows[0].ID, rows[2].ID)
+
+	var stored []TestTableXML
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	require.Len(t, stored, 3)
+	for i, row := range stored {
+		assert.Equal(t, rows[i].ID, row.ID)
+		assert.Equal(t, rows[i].Name, row.Name, "expecting every row bound with its own values")
+		assert.Equal(t, rows[i].Doc, row.Doc)
+	}
+}
+
+func BenchmarkCreateRows(b *testing.B) {
+	db := dbNamingCase
+	if db == nil {
+		b.Skip("db is nil!")
+	}
+
+	model := TestTableUserUnique{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(b, db.Migrator().AutoMigrate(model), "expecting no error")
+
+	const batch = 100
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		rows := make([]TestTableUserUnique, batch)
+		for i := range rows {
+			rows[i] = TestTableUserUnique{UID: fmt.Sprintf("B%d-%d", n, i), Name: "bench", Enabled: true}
+		}
+		if err := db.Create(&rows).Error; err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type testArrayBindRow struct {
+	ID    uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name  string `gorm:"size:50"`
+	Qty   int
+	Price float64
+	At    *time.Time
+	Flag  bool
+}
+
+func Test_arrayBindVars(t *testing.T) {
+	values := clause.Values{
+		Columns: []clause.Column{{Name: "id"}, {Name: "name"}},
+		Values:  [][]interface{}{{1, "a"}, {2, nil}, {3, []byte("c")}},
+	}
+	args, ok := arrayBindVars([]interface{}{1, "a", "tag"}, values)
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{
+		[]interface{}{1, 2, 3},
+		[]interface{}{"a", nil, []byte("c")},
+		[]interface{}{"tag", "tag", "tag"},
+	}, args)
+
+	_, ok = arrayBindVars([]interface{}{1, "a"}, clause.Values{Columns: values.Columns, Values: values.Values[:1]})
+	assert.False(t, ok, "expecting a single row bound as is")
+
+	values.Values[1][1] = castNullExpr("XMLTYPE")
+	_, ok = arrayBindVars([]interface{}{1, "a"}, values)
+	assert.False(t, ok, "expecting rows with SQL of their own bound row by row")
+
+	values.Values[1][1] = []interface{}{"x", "y"}
+	_, ok = arrayBindVars([]interface{}{1, "a"}, values)
+	assert.False(t, ok, "expecting rows with SQL of their own bound row by row")
+}
+
+func TestCreateArrayBind(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := testArrayBindRow{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+
+	const total = 5000
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	rows := make([]testArrayBindRow, total)
+	for i := range rows {
+		rows[i] = testArrayBindRow{ID: uint64(i + 1), Name: fmt.Sprintf("name %d", i), Qty: i % 13, Price: float64(i) / 4, Flag: i%2 == 0}
+		if i%3 == 0 {
+			when := at.Add(time.Duration(i) * time.Minute)
+			rows[i].At = &when
+		}
+	}
+
+	res := db.Create(&rows)
+	require.NoError(t, res.Error, "expecting no error")
+	assert.EqualValues(t, total, res.RowsAffected)
+
+	var stored []testArrayBindRow
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	require.Len(t, stored, total)
+	for i, row := range stored {
+		assert.Equal(t, rows[i].ID, row.ID)
+		assert.Equal(t, rows[i].Name, row.Name)
+		assert.Equal(t, rows[i].Qty, row.Qty)
+		assert.Equal(t, rows[i].Price, row.Price)
+		assert.Equal(t, rows[i].Flag, row.Flag)
+		if rows[i].At == nil {
+			assert.Nil(t, row.At)
+		} else if assert.NotNil(t, row.At) {
+			assert.True(t, rows[i].At.Equal(*row.At), "row %d: %v vs %v", i, rows[i].At, row.At)
+		}
+	}
+}
+
+func BenchmarkCreateArrayBind(b *testing.B) {
+	db := dbNamingCase
+	if db == nil {
+		b.Skip("db is nil!")
+	}
+
+	model := testArrayBindRow{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(b, db.Migrator().AutoMigrate(model), "expecting no error")
+
+	const batch = 5000
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		rows := make([]testArrayBindRow, batch)
+		for i := range rows {
+			rows[i] = testArrayBindRow{ID: uint64(n*batch + i + 1), Name: "bench", Qty: i, Price: 1.5, Flag: true}
+		}
+		if err := db.Create(&rows).Error; err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type testMergeErrorLog struct {
+	ID   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name string `gorm:"size:50"`
+	Qty  int    `gorm:"check:chk_merge_error_log_qty,qty >= 0"`
+}
+
+func TestMergeCreateLogErrors(t *testing.T) {
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: Dialector{Config: &Config{namingStrategy: &NamingStrategy{}}}}}, Clauses: map[string]clause.Clause{}}
+	stmt.AddClause(LogErrors{Table: "err_log", Tag: "batch-1"})
+	stmt.Clauses["LOG ERRORS"].Expression.Build(stmt)
+	assert.Equal(t, "LOG ERRORS INTO ERR_LOG (:1) REJECT LIMIT UNLIMITED", stmt.SQL.String())
+	assert.Equal(t, []interface{}{"batch-1"}, stmt.Vars)
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	const errorTable = "ERR$_TEST_MERGE_ERROR_LOG"
+	model := testMergeErrorLog{}
+	_ = db.Migrator().DropTable(errorTable)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+	require.NoError(t, CreateErrorLog(db, model, errorTable), "expecting the error logging table created")
+	require.True(t, db.Migrator().HasTable(errorTable))
+
+	require.NoError(t, db.Create(&testMergeErrorLog{ID: 1, Name: "old", Qty: 1}).Error)
+
+	upsert := clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoUpdates: clause.AssignmentColumns([]string{"name", "qty"})}
+	rows := []testMergeErrorLog{{ID: 1, Name: "new", Qty: 2}, {ID: 2, Name: "bad", Qty: -1}, {ID: 3, Name: "good", Qty: 3}}
+	res := db.Clauses(upsert, LogErrors{Table: errorTable, Tag: "batch-1"}).Create(&rows)
+	require.NoError(t, res.Error, "expecting the bad row logged rather than failing the MERGE")
+	assert.EqualValues(t, 2, res.RowsAffected, "expected one updated plus one inserted row")
+	assert.Contains(t, res.Statement.SQL.String(), "LOG ERRORS INTO")
+
+	var stored []testMergeErrorLog
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	assert.Equal(t, []testMergeErrorLog{{ID: 1, Name: "new", Qty: 2}, {ID: 3, Name: "good", Qty: 3}}, stored)
+
+	logged, err := ErrorLog(db, errorTable, "batch-1")
+	require.NoError(t, err)
+	require.Len(t, logged, 1)
+	assert.Equal(t, 2290, logged[0].Code, "expecting the check constraint violation")
+	assert.Equal(t, "I", logged[0].Operation)
+	assert.Equal(t, "batch-1", logged[0].Tag)
+	assert.Contains(t, logged[0].Message, "CHK_MERGE_ERROR_LOG_QTY")
+	assert.Equal(t, "2", logged[0].Columns["ID"])
+	assert.Equal(t, "-1", logged[0].Columns["QTY"])
+
+	logged, err = ErrorLog(db, errorTable, "batch-2")
+	require.NoError(t, err)
+	assert.Empty(t, logged)
+}
+
+func TestCreateLogErrors(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	const errorTable = "ERR$_TEST_MERGE_ERROR_LOG"
+	model := testMergeErrorLog{}
+	_ = db.Migrator().DropTable(errorTable)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model), "expecting no error")
+	require.NoError(t, CreateErrorLog(db, model, errorTable), "expecting the error logging table created")
+
+	require.NoError(t, db.Create(&testMergeErrorLog{ID: 1, Name: "old", Qty: 1}).Error)
+
+	rows := []testMergeErrorLog{{ID: 1, Name: "dup", Qty: 2}, {ID: 2, Name: "bad", Qty: -1}, {ID: 3, Name: "good", Qty: 3}, {ID: 4, Name: "fine", Qty: 4}}
+	res := db.Clauses(LogErrors{Table: errorTable, Tag: "load-1"}).Create(&rows)
+	require.NoError(t, res.Error, "expecting the bad rows logged rather than failing the batch")
+	assert.EqualValues(t, 2, res.RowsAffected, "expected the two valid rows inserted")
+	assert.Contains(t, res.Statement.SQL.String(), "INSERT INTO")
+	assert.Contains(t, res.Statement.SQL.String(), "LOG ERRORS INTO")
+
+	var stored []testMergeErrorLog
+	require.NoError(t, db.Order("id").Find(&stored).Error)
+	assert.Equal(t, []testMergeErrorLog{{ID: 1, Name: "old", Qty: 1}, {ID: 3, Name: "good", Qty: 3}, {ID: 4, Name: "fine", Qty: 4}}, stored)
+
+	logged, err := ErrorLog(db, errorTable, "load-1")
+	require.NoError(t, err)
+	require.Len(t, logged, 2)
+	codes := []int{logged[0].Code, logged[1].Code}
+	assert.ElementsMatch(t, []int{1, 2290}, codes, "expecting the unique and check constraint violations")
+	for _, entry := range logged {
+		assert.Equal(t, "I", entry.Operation)
+	}
+
+	// INSERT ... SELECT logs the rows of the query it cannot write as well
+	res = db.Model(&testMergeErrorLog{}).Clauses(LogErrors{Table: errorTable, Tag: "load-2"}).
+		Create(db.Model(&testMergeErrorLog{}).Select("id + 10", "name", "qty - 2"))
+	require.NoError(t, res.Error)
+	assert.EqualValues(t, 2, res.RowsAffected, "expected the rows with qty >= 2 copied")
+	logged, err = ErrorLog(db, errorTable, "load-2")
+	require.NoError(t, err)
+	assert.Len(t, logged, 1)
+}
@@ -2,6 +2,7 @@ package oracle
 
 import (
 	"reflect"
+	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -19,6 +20,11 @@ func Delete(db *gorm.DB) {
 		}
 	}
 
+	if db.Statement.SQL.Len() == 0 && !db.DryRun && isReturningSlice(db.Statement) {
+		deleteEachReturning(db)
+		return
+	}
+
 	if db.Statement.SQL.Len() == 0 {
 		db.Statement.SQL.Grow(100)
 		db.Statement.AddClauseIfNotExists(clause.Delete{})
@@ -41,6 +47,10 @@ func Delete(db *gorm.DB) {
 			}
 		}
 
+		if hasJoins(db.Statement) {
+			deleteJoinedRows(db)
+		}
+
 		db.Statement.AddClauseIfNotExists(clause.From{})
 
 		db.Statement.Build(db.Statement.BuildClauses...)
@@ -61,3 +71,117 @@ func Delete(db *gorm.DB) {
 		}
 	}
 }
+
+// isReturningSlice reports whether stmt deletes a slice of more than one model with RETURNING,
+// which binds its OUT parameters into a single row, see Returning.Build
+func isReturningSlice(stmt *gorm.Statement) bool {
+	if stmt.Schema == nil || len(stmt.Schema.PrimaryFields) == 0 {
+		return false
+	}
+	if _, ok := stmt.Clauses[clause.Returning{}.Name()]; !ok {
+		return false
+	}
+	rv := reflect.Indirect(stmt.ReflectValue)
+	return (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() > 1
+}
+
+// deleteEachReturning deletes the models of a slice one statement per element, as Create inserts
+// the rows needing RETURNING, so that the RETURNING INTO of each statement scatters the deleted
+// row back into its element:
+//
+//	db.Clauses(clause.Returning{}).Delete(&users)
+//	// DELETE FROM "USERS" WHERE "USERS"."ID" = 1 RETURNING ... INTO ..., then for "ID" = 2, ...
+//
+// The conditions of the statement apply to every element. Elements without a primary key are
+// skipped, as they are when the slice is deleted in one statement, and RowsAffected is the total.
+func deleteEachReturning(db *gorm.DB) {
+	stmt := db.Statement
+	rv := reflect.Indirect(stmt.ReflectValue)
+	for i := 0; i < rv.Len() && db.Error == nil; i++ {
+		elem := rv.Index(i)
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct || !elem.CanAddr() {
+			continue
+		}
+		hasKey := true
+		for _, field := range stmt.Schema.PrimaryFields {
+			if _, isZero := field.ValueOf(stmt.Context, elem); isZero {
+				hasKey = false
+				break
+			}
+		}
+		if !hasKey {
+			continue
+		}
+
+		// a context makes the session clone the statement
+		tx := db.Session(&gorm.Session{Context: stmt.Context})
+		if stmt.Model == stmt.Dest {
+			tx.Statement.Model = elem.Addr().Interface()
+		}
+		tx.Statement.BuildClauses = stmt.BuildClauses
+		tx.Statement.Dest = elem.Addr().Interface()
+		tx.Statement.ReflectValue = elem
+		Delete(tx)
+		if db.AddError(tx.Error) == nil {
+			db.RowsAffected += tx.RowsAffected
+		}
+		// the statement of the first element is the one logged, as for the rows of Create
+		if stmt.SQL.Len() == 0 {
+			_, _ = stmt.SQL.WriteString(tx.Statement.SQL.String())
+			stmt.Vars = tx.Statement.Vars
+		}
+	}
+
+	if stmt.Result != nil {
+		stmt.Result.RowsAffected = db.RowsAffected
+	}
+}
+
+// deleteJoinedRows rewrites a delete with joins, which Oracle's DELETE does not accept, into a
+// delete of the rows selected by the joined query:
+//
+//	db.Joins("JOIN orders ON orders.user_id = users.id").Where("orders.state = ?", "void").Delete(&User{})
+//	// DELETE FROM "USERS" WHERE ROWID IN (SELECT "USERS".ROWID FROM "USERS" JOIN orders ON ... WHERE orders.state = 'void')
+//
+// Without conditions the statement is left alone, so a delete limited by its joins alone still
+// needs AllowGlobalUpdate.
+func deleteJoinedRows(db *gorm.DB) {
+	stmt := db.Statement
+	where, hasWhere := stmt.Clauses["WHERE"]
+	if !hasWhere {
+		return
+	}
+
+	query := db.Session(&gorm.Session{NewDB: true})
+	if stmt.Model != nil {
+		query = query.Model(stmt.Model)
+	}
+	query = query.Table(stmt.Table)
+	query.Statement.TableExpr = stmt.TableExpr
+	// relation joins select the columns of the joined table unless omitted
+	query.Statement.Joins = append(stmt.Joins[:0:0], stmt.Joins...)
+	for i := range query.Statement.Joins {
+		query.Statement.Joins[i].Selects = nil
+		query.Statement.Joins[i].Omits = []string{"*"}
+	}
+	if from, ok := stmt.Clauses["FROM"]; ok {
+		query.Statement.Clauses["FROM"] = from
+	}
+	query.Statement.Clauses["WHERE"] = where
+
+	var rowID strings.Builder
+	stmt.QuoteTo(&rowID, stmt.Table)
+	query = query.Select(rowID.String() + ".ROWID")
+
+	stmt.Joins = nil
+	delete(stmt.Clauses, "FROM")
+	stmt.Clauses["WHERE"] = clause.Clause{Name: "WHERE", Expression: clause.Where{Exprs: []clause.Expression{
+		clause.Expr{SQL: "ROWID IN (?)", Vars: []any{query}},
+	}}}
+}
@@ -0,0 +1,122 @@
+package oracle
+
+import (
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Parallel runs the statement with degree parallel execution servers, or the degree Oracle picks
+// when degree is zero. A query is hinted right after SELECT; an INSERT, UPDATE, DELETE or MERGE also
+// enables parallel DML for the statement, as PARALLEL alone only parallelizes its query part:
+//
+//	db.Clauses(oracle.Parallel(8)).Where("status = ?", "stale").Delete(&Job{})
+//	// DELETE /*+ ENABLE_PARALLEL_DML PARALLEL(8) */ FROM "JOBS" WHERE status = 'stale'
+//
+// A table modified by parallel DML cannot be read or modified again in the same transaction before
+// it commits (ORA-12838).
+//
+//goland:noinspection GoUnusedExportedFunction
+func Parallel(degree int) clause.Expression {
+	return parallel{Degree: degree}
+}
+
+type parallel struct {
+	Degree int
+}
+
+func (parallel) Name() string {
+	return "PARALLEL"
+}
+
+func (p parallel) Build(builder clause.Builder) {
+	_, _ = builder.WriteString("/*+ ")
+	_, _ = builder.WriteString(p.hint())
+	_, _ = builder.WriteString(" */")
+}
+
+func (p parallel) MergeClause(c *clause.Clause) {
+	c.Expression = p
+}
+
+func (p parallel) hint() string {
+	if p.Degree > 0 {
+		return "PARALLEL(" + strconv.Itoa(p.Degree) + ")"
+	}
+	return "PARALLEL"
+}
+
+// Append inserts the rows of an INSERT ... SELECT above the high-water mark of the table, a
+// direct-path insert bypassing the buffer cache:
+//
+//	db.Clauses(oracle.Append()).Table("archive").Create(db.Table("users").Where("age > ?", 60))
+//	// INSERT /*+ APPEND */ INTO "ARCHIVE" SELECT * FROM "USERS" WHERE age > 60
+//
+// The insert locks the table exclusively until the transaction ends, and the transaction cannot read
+// or modify the table again before it commits (ORA-12838): run it in a transaction of its own. Oracle
+// falls back to a conventional insert on tables with enabled triggers or foreign keys, and ignores the
+// hint on INSERT ... VALUES; UPDATE, DELETE and MERGE are not hinted.
+//
+//goland:noinspection GoUnusedExportedFunction
+func Append() clause.Expression {
+	return appendHint{}
+}
+
+type appendHint struct{}
+
+func (appendHint) Name() string {
+	return "APPEND"
+}
+
+func (appendHint) Build(builder clause.Builder) {
+	_, _ = builder.WriteString("/*+ APPEND */")
+}
+
+func (a appendHint) MergeClause(c *clause.Clause) {
+	c.Expression = a
+}
+
+// dmlHint returns the hints written right after keyword, the INSERT, UPDATE, DELETE or MERGE starting
+// the statement: APPEND for an INSERT with an Append clause, and those of a Parallel clause, with
+// parallel DML enabled.
+func dmlHint(stmt *gorm.Statement, keyword string) string {
+	var hints []string
+	if _, ok := stmt.Clauses["APPEND"].Expression.(appendHint); ok && keyword == "INSERT" {
+		hints = append(hints, "APPEND")
+	}
+	if p, ok := stmt.Clauses["PARALLEL"].Expression.(parallel); ok {
+		hints = append(hints, "ENABLE_PARALLEL_DML", p.hint())
+	}
+	return strings.Join(hints, " ")
+}
+
+// RewriteDML builds the INSERT, UPDATE or DELETE clause, writing the hints of the statement for DML,
+// see dmlHint, right after its keyword.
+func (d Dialector) RewriteDML(c clause.Clause, builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+	start := stmt.SQL.Len()
+	c.Build(builder)
+	built := stmt.SQL.String()
+	for _, keyword := range []string{"INSERT", "UPDATE", "DELETE"} {
+		if strings.HasPrefix(built[start:], keyword) {
+			hint := dmlHint(stmt, keyword)
+			if hint == "" {
+				return
+			}
+			at := start + len(keyword)
+			stmt.SQL.Reset()
+			stmt.SQL.WriteString(built[:at])
+			stmt.SQL.WriteString(" /*+ ")
+			stmt.SQL.WriteString(hint)
+			stmt.SQL.WriteString(" */")
+			stmt.SQL.WriteString(built[at:])
+			return
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/cmmoran/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Cipher encrypts the fields tagged `encrypt` as they are written and decrypts them as they are
+// read, see Config.Cipher:
+//
+//	type User struct {
+//		ID  uint64
+//		SSN string `gorm:"encrypt;size:256"` // RAW(256) holding the ciphertext
+//	}
+//
+// The fields hold a string or []byte, or a pointer to either; the column is RAW(size) for a size
+// of at most 2000 bytes and BLOB otherwise, sized for the ciphertext. Conditions on an encrypted
+// field are bound as written, so they only match with a deterministic Cipher given the ciphertext.
+type Cipher interface {
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// WrappedBytes is the ciphertext of a field tagged `encrypt`, bound to and scanned from its RAW or
+// BLOB column as is.
+type WrappedBytes []byte
+
+func (w WrappedBytes) Value() (driver.Value, error) {
+	if w == nil {
+		return nil, nil
+	}
+	return []byte(w), nil
+}
+
+func (w *WrappedBytes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*w = nil
+	case []byte:
+		*w = append(WrappedBytes(nil), v...)
+	case go_ora.Blob:
+		*w = append(WrappedBytes(nil), v.Data...)
+	default:
+		return fmt.Errorf("oracle: cannot scan %T into WrappedBytes", value)
+	}
+	return nil
+}
+
+// isEncryptedField reports whether field is tagged `encrypt`
+func isEncryptedField(field *schema.Field) bool {
+	if field == nil {
+		return false
+	}
+	_, ok := field.TagSettings["ENCRYPT"]
+	return ok
+}
+
+// encryptedDataType returns the column type holding the ciphertext of field
+func encryptedDataType(field *schema.Field) string {
+	if field.Size > 0 && field.Size <= 2000 {
+		return fmt.Sprintf("RAW(%d)", field.Size)
+	}
+	return "BLOB"
+}
+
+// configuredCipher returns Config.Cipher of the dialector of db
+func configuredCipher(db *gorm.DB) Cipher {
+	v, _ := reflectDereference(db.Dialector)
+	if d, ok := v.(Dialector); ok && d.Config != nil {
+		return d.Cipher
+	}
+	return nil
+}
+
+// encryptValue returns the ciphertext of val, the value written to field, when field is tagged
+// `encrypt`; a nil value stays NULL and expressions are left as they are.
+func encryptValue(stmt *gorm.Statement, field *schema.Field, val interface{}) interface{} {
+	if !isEncryptedField(field) {
+		return val
+	}
+	switch val.(type) {
+	case WrappedBytes, clause.Expression:
+		return val
+	}
+
+	rv, _, _ := reflectValueDereference(val)
+	var plaintext []byte
+	switch {
+	case !rv.IsValid() || rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface:
+		// nil, or a nil pointer
+		return WrappedBytes(nil)
+	case rv.Kind() == reflect.String:
+		plaintext = []byte(rv.String())
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		if rv.IsNil() {
+			return WrappedBytes(nil)
+		}
+		plaintext = rv.Bytes()
+	default:
+		_ = stmt.AddError(fmt.Errorf("oracle: encrypted field %s must hold a string or []byte, got %T", field.Name, val))
+		return WrappedBytes(nil)
+	}
+
+	cipher := configuredCipher(stmt.DB)
+	if cipher == nil {
+		_ = stmt.AddError(fmt.Errorf("oracle: field %s is tagged encrypt but Config.Cipher is not set", field.Name))
+		return WrappedBytes(nil)
+	}
+	ciphertext, err := cipher.Encrypt(stmt.Context, plaintext)
+	if err != nil {
+		_ = stmt.AddError(fmt.Errorf("oracle: encrypting %s: %w", field.Name, err))
+		return WrappedBytes(nil)
+	}
+	return WrappedBytes(ciphertext)
+}
+
+// decryptValue returns the plaintext of ciphertext, read from field, as the string or []byte the
+// field holds; NULL is returned as nil.
+func decryptValue(stmt *gorm.Statement, field *schema.Field, ciphertext WrappedBytes) (interface{}, error) {
+	if ciphertext == nil {
+		return nil, nil
+	}
+	cipher := configuredCipher(stmt.DB)
+	if cipher == nil {
+		return nil, fmt.Errorf("oracle: field %s is tagged encrypt but Config.Cipher is not set", field.Name)
+	}
+	plaintext, err := cipher.Decrypt(stmt.Context, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: decrypting %s: %w", field.Name, err)
+	}
+
+	fieldType := field.FieldType
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.String {
+		return string(plaintext), nil
+	}
+	return plaintext, nil
+}
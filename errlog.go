@@ -0,0 +1,133 @@
+package oracle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const createErrorLogSQL = "BEGIN DBMS_ERRLOG.CREATE_ERROR_LOG(dml_table_name => ?, err_log_table_name => ?, skip_unsupported => TRUE); END;"
+
+// LogErrors makes Create log the rows it fails to write into an error logging table, see
+// CreateErrorLog, and carry on with the others, for plain inserts and MERGE upserts alike:
+//
+//	db.Clauses(oracle.LogErrors{Table: "ERR$_USERS", Tag: "import-42"}).Create(&users)
+//	// INSERT INTO "USERS" ... LOG ERRORS INTO "ERR$_USERS" ('import-42') REJECT LIMIT UNLIMITED
+//	db.Clauses(clause.OnConflict{UpdateAll: true}, oracle.LogErrors{Table: "ERR$_USERS", Tag: "import-42"}).Create(&users)
+//	// MERGE INTO "USERS" ... LOG ERRORS INTO "ERR$_USERS" ('import-42') REJECT LIMIT UNLIMITED
+//
+// RowsAffected counts the rows written; ErrorLog reads the rejected ones, whose server-filled fields
+// are left as they were. Oracle does not log unique constraint violations of a MERGE, those still
+// fail the statement.
+type LogErrors struct {
+	// Table is the error logging table
+	Table string
+	// Tag is stored with each logged row, to tell the rows of an upsert apart
+	Tag string
+	// RejectLimit is the number of rows logged before the MERGE fails, unlimited when 0
+	RejectLimit int
+}
+
+func (LogErrors) Name() string {
+	return "LOG ERRORS"
+}
+
+func (l LogErrors) Build(builder clause.Builder) {
+	_, _ = builder.WriteString("LOG ERRORS INTO ")
+	builder.WriteQuoted(clause.Table{Name: l.Table})
+	if l.Tag != "" {
+		_, _ = builder.WriteString(" (")
+		builder.AddVar(builder, l.Tag)
+		_ = builder.WriteByte(')')
+	}
+	_, _ = builder.WriteString(" REJECT LIMIT ")
+	if l.RejectLimit > 0 {
+		_, _ = builder.WriteString(strconv.Itoa(l.RejectLimit))
+	} else {
+		_, _ = builder.WriteString("UNLIMITED")
+	}
+}
+
+func (l LogErrors) MergeClause(c *clause.Clause) {
+	c.Expression = l
+}
+
+// writeLogErrors appends the LogErrors clause of stmt, if any, to the statement being built
+func writeLogErrors(stmt *gorm.Statement) {
+	if logErrors, ok := stmt.Clauses["LOG ERRORS"].Expression.(LogErrors); ok {
+		_ = stmt.WriteByte(' ')
+		logErrors.Build(stmt)
+	}
+}
+
+// CreateErrorLog creates the error logging table of the table of model through
+// DBMS_ERRLOG.CREATE_ERROR_LOG, for LogErrors: the ORA_ERR_* columns followed by a VARCHAR2(4000)
+// column per column of the table.
+//
+//goland:noinspection GoUnusedExportedFunction
+func CreateErrorLog(db *gorm.DB, model interface{}, table string) error {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	return db.Exec(createErrorLogSQL, stmt.Quote(stmt.Table), stmt.Quote(table)).Error
+}
+
+// ErrorLogEntry is a row rejected by a MERGE, see LogErrors
+type ErrorLogEntry struct {
+	// Code is the ORA error code, 2290 for a check constraint violation
+	Code int
+	// Message is the ORA error message
+	Message string
+	// Operation is I for an insert and U for an update
+	Operation string
+	Tag       string
+	// Columns holds the values of the rejected row as text, by column name
+	Columns map[string]string
+}
+
+// ErrorLog reads the rows logged into the error logging table under tag, all of them when tag is
+// empty, see LogErrors.
+//
+//goland:noinspection GoUnusedExportedFunction
+func ErrorLog(db *gorm.DB, table, tag string) ([]ErrorLogEntry, error) {
+	tx := db.Table(table)
+	if tag != "" {
+		tx = tx.Where("ORA_ERR_TAG$ = ?", tag)
+	}
+	var rows []map[string]interface{}
+	if err := tx.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]ErrorLogEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := ErrorLogEntry{Columns: make(map[string]string, len(row))}
+		for name, value := range row {
+			text := ""
+			if value != nil {
+				text = fmt.Sprint(value)
+			}
+			switch strings.ToUpper(name) {
+			case "ORA_ERR_NUMBER$":
+				entry.Code, _ = strconv.Atoi(text)
+			case "ORA_ERR_MESG$":
+				entry.Message = text
+			case "ORA_ERR_OPTYP$":
+				entry.Operation = text
+			case "ORA_ERR_TAG$":
+				entry.Tag = text
+			case "ORA_ERR_ROWID$":
+			default:
+				if value != nil {
+					entry.Columns[name] = text
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,133 @@
+package oracle
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JSONPath is a JSON_VALUE or JSON_EXISTS expression over a JSON (or CLOB IS JSON) column,
+// usable anywhere gorm accepts a clause.Expression:
+//
+//	db.Where(oracle.JSONExists("doc", "$.address.city"))
+//	db.Where("? = ?", oracle.JSONValue("doc", "$.address.city"), "Paris")
+//	db.Select("?", oracle.JSONValue("doc", "$.age").Returning("NUMBER").As("age")).Scan(&rows)
+//
+// SQL/JSON paths cannot be bound, so the path is written as a string literal.
+type JSONPath struct {
+	Function  string
+	Column    string
+	Path      string
+	returning string
+	alias     string
+}
+
+// JSONValue selects the scalar at path in column: JSON_VALUE("col", '$.a.b')
+func JSONValue(column, path string) JSONPath {
+	return JSONPath{Function: "JSON_VALUE", Column: column, Path: path}
+}
+
+// JSONExists tests whether path matches anything in column: JSON_EXISTS("col", '$.a.b')
+func JSONExists(column, path string) JSONPath {
+	return JSONPath{Function: "JSON_EXISTS", Column: column, Path: path}
+}
+
+// Returning sets the SQL type JSON_VALUE converts the scalar to, e.g. NUMBER; it defaults to VARCHAR2(4000)
+func (j JSONPath) Returning(typ string) JSONPath {
+	j.returning = typ
+	return j
+}
+
+// As names the selected value so it can be scanned into the matching field
+func (j JSONPath) As(alias string) JSONPath {
+	j.alias = alias
+	return j
+}
+
+// Build implements clause.Expression
+func (j JSONPath) Build(builder clause.Builder) {
+	_, _ = builder.WriteString(j.Function)
+	_ = builder.WriteByte('(')
+	builder.WriteQuoted(clause.Column{Name: j.Column})
+	_, _ = builder.WriteString(", ")
+	_, _ = builder.WriteString(sqlLiteral(j.Path))
+	if j.returning != "" && j.Function == "JSON_VALUE" {
+		_, _ = builder.WriteString(" RETURNING ")
+		_, _ = builder.WriteString(j.returning)
+	}
+	_ = builder.WriteByte(')')
+	if j.alias != "" {
+		_, _ = builder.WriteString(" AS ")
+		builder.WriteQuoted(j.alias)
+	}
+}
+
+// sqlLiteral quotes s as an SQL string literal, for arguments Oracle does not accept as bind variables.
+func sqlLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// JSONColumn is a column projected by JSONTable.
+type JSONColumn struct {
+	// Name is the projected column name, matched against the destination's columns
+	Name string
+	// Type is the SQL type of the column, defaults to VARCHAR2(4000)
+	Type string
+	// Path is the SQL/JSON path of the value relative to each row, defaults to $.<Name>
+	Path string
+}
+
+// jsonTableAlias names the JSON_TABLE row source joined by JSONTable.
+const jsonTableAlias = "JT"
+
+// JSONTable relationalizes the JSON document stored in column of db's model into one row per
+// item matched by path, projecting columns so the rows can be scanned into a struct slice:
+//
+//	var items []Item
+//	err := oracle.JSONTable(db.Model(&Order{}), "doc", "$.items[*]",
+//		oracle.JSONColumn{Name: "sku", Type: "VARCHAR2(20)"},
+//		oracle.JSONColumn{Name: "qty", Type: "NUMBER", Path: "$.quantity"},
+//	).Where("id = ?", 1).Scan(&items).Error
+//
+// generates
+//
+//	SELECT "JT"."SKU","JT"."QTY" FROM "ORDERS" , JSON_TABLE("ORDERS"."DOC", '$.items[*]'
+//	  COLUMNS ("SKU" VARCHAR2(20) PATH '$.sku', "QTY" NUMBER PATH '$.quantity')) "JT" WHERE ...
+func JSONTable(db *gorm.DB, column, path string, columns ...JSONColumn) *gorm.DB {
+	var (
+		sql     strings.Builder
+		vars    = []any{clause.Column{Table: clause.CurrentTable, Name: column}}
+		selects = make([]clause.Column, 0, len(columns))
+	)
+	sql.WriteString(", JSON_TABLE(?, ")
+	sql.WriteString(sqlLiteral(path))
+	sql.WriteString(" COLUMNS (")
+	for idx, col := range columns {
+		if idx > 0 {
+			sql.WriteString(", ")
+		}
+		typ, colPath := col.Type, col.Path
+		if typ == "" {
+			typ = "VARCHAR2(4000)"
+		}
+		if colPath == "" {
+			colPath = "$." + col.Name
+		}
+		sql.WriteString("? ")
+		sql.WriteString(typ)
+		sql.WriteString(" PATH ")
+		sql.WriteString(sqlLiteral(colPath))
+		vars = append(vars, clause.Column{Name: col.Name})
+		selects = append(selects, clause.Column{Table: jsonTableAlias, Name: col.Name})
+	}
+	sql.WriteString(")) ?")
+	vars = append(vars, clause.Table{Name: jsonTableAlias})
+
+	tx := db.Clauses(clause.Select{Columns: selects}).Joins(sql.String(), vars...)
+	if len(columns) == 0 {
+		_ = tx.AddError(fmt.Errorf("oracle: JSONTable requires at least one column"))
+	}
+	return tx
+}
@@ -0,0 +1,124 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// ORAError is the error handed to the wrapped logger by ErrorCodeLogger for a statement failing
+// with an Oracle error; Code is the numeric part of its ORA-NNNNN code.
+type ORAError struct {
+	Code int
+	Err  error
+}
+
+func (e *ORAError) Error() string {
+	return fmt.Sprintf("[ora_code=ORA-%05d] %v", e.Code, e.Err)
+}
+
+func (e *ORAError) Unwrap() error {
+	return e.Err
+}
+
+// LogValue implements slog.LogValuer, logging the code as its own attribute
+func (e *ORAError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ora_code", fmt.Sprintf("ORA-%05d", e.Code)),
+		slog.String("message", e.Err.Error()),
+	)
+}
+
+// ErrorCodeLogger wraps a gorm logger, tagging the error of every statement failing with an
+// Oracle error with its ORA-NNNNN code:
+//
+//	db, err := gorm.Open(oracle.New(cfg), &gorm.Config{Logger: oracle.NewErrorCodeLogger(logger.Default)})
+//
+// The wrapped logger receives an *ORAError, which still unwraps to the driver error.
+type ErrorCodeLogger struct {
+	logger.Interface
+	// OnError, when set, is called with the code of every failed statement, e.g. to record it as
+	// a structured field or a metric
+	OnError func(ctx context.Context, code int, sql string, err error)
+}
+
+// NewErrorCodeLogger returns an ErrorCodeLogger wrapping l
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewErrorCodeLogger(l logger.Interface) *ErrorCodeLogger {
+	return &ErrorCodeLogger{Interface: l}
+}
+
+// LogMode implements logger.Interface, keeping the wrapper around the returned logger
+func (l *ErrorCodeLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &ErrorCodeLogger{Interface: l.Interface.LogMode(level), OnError: l.OnError}
+}
+
+// Trace implements logger.Interface
+func (l *ErrorCodeLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if code := oracleErrorCode(err); code != 0 {
+		if l.OnError != nil {
+			sql, _ := fc()
+			l.OnError(ctx, code, sql, err)
+		}
+		err = &ORAError{Code: code, Err: err}
+	}
+	l.Interface.Trace(ctx, begin, fc, err)
+}
+
+// ParamsFilter implements gorm.ParamsFilter when the wrapped logger does
+func (l *ErrorCodeLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	if f, ok := l.Interface.(interface {
+		ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any)
+	}); ok {
+		return f.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, params
+}
+
+// slowDDLLogger wraps the logger of the migrator when Config.SlowDDLThreshold is set, warning about
+// the DDL statements running longer than threshold. Other statements, e.g. the dictionary
+// queries, are passed through as they are.
+type slowDDLLogger struct {
+	logger.Interface
+	threshold time.Duration
+}
+
+// LogMode implements logger.Interface, keeping the wrapper around the returned logger
+func (l *slowDDLLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &slowDDLLogger{Interface: l.Interface.LogMode(level), threshold: l.threshold}
+}
+
+// Trace implements logger.Interface
+func (l *slowDDLLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if elapsed := time.Since(begin); elapsed > l.threshold {
+		if sql, _ := fc(); isDDL(sql) {
+			l.Interface.Warn(ctx, "oracle: slow DDL took %s (threshold %s): %s", elapsed, l.threshold, sql)
+		}
+	}
+	l.Interface.Trace(ctx, begin, fc, err)
+}
+
+// ParamsFilter implements gorm.ParamsFilter when the wrapped logger does
+func (l *slowDDLLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	if f, ok := l.Interface.(interface {
+		ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any)
+	}); ok {
+		return f.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, params
+}
+
+// isDDL reports whether sql is a DDL statement, judging by its first keyword
+func isDDL(sql string) bool {
+	keyword, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	switch strings.ToUpper(keyword) {
+	case "CREATE", "ALTER", "DROP", "TRUNCATE", "RENAME", "COMMENT", "PURGE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MoveTable relocates the table of value to tablespace and rebuilds the indexes the move left
+// unusable:
+//
+//	db.Migrator().(oracle.Migrator).MoveTable(&User{}, "USERS_DATA")
+//	// ALTER TABLE USERS MOVE TABLESPACE "USERS_DATA"
+//	// ALTER INDEX "IDX_USERS_NAME" REBUILD, for every index of USERS with STATUS UNUSABLE
+//
+// MOVE changes the ROWID of every row, which makes Oracle mark each index of the table UNUSABLE
+// until it is rebuilt; the indexes keep their own tablespace. An unquoted tablespace is upper
+// cased as the dictionary stores it. LOB segments are not moved, and the partitions of a
+// partitioned index are left to the caller.
+func (m Migrator) MoveTable(value interface{}, tablespace string) error {
+	if strings.TrimSpace(tablespace) == "" {
+		return fmt.Errorf("oracle: MoveTable requires a tablespace")
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if err := m.DB.Exec("ALTER TABLE ? MOVE TABLESPACE ?", m.CurrentTable(stmt), tablespaceIdentifier(tablespace)).Error; err != nil {
+			return err
+		}
+		return m.rebuildUnusableIndexes(stmt)
+	})
+}
+
+// RebuildIndexes rebuilds the indexes of the table of value that Oracle marked UNUSABLE, e.g.
+// after a direct-path load, a MOVE or ALTER INDEX ... UNUSABLE:
+//
+//	db.Migrator().(oracle.Migrator).RebuildIndexes(&User{})
+//	// ALTER INDEX "IDX_USERS_NAME" REBUILD, for every index of USERS with STATUS UNUSABLE
+//
+// Usable indexes are left alone, as are the partitions of a partitioned index.
+func (m Migrator) RebuildIndexes(value interface{}) error {
+	return m.RunWithValue(value, m.rebuildUnusableIndexes)
+}
+
+// rebuildUnusableIndexes rebuilds the indexes of the statement's table with STATUS UNUSABLE
+func (m Migrator) rebuildUnusableIndexes(stmt *gorm.Statement) error {
+	ns := getNS(m.DB, m.Dialector)
+	owner, tab, hasOwner := ns.dictQualifiedParts(stmt.Table)
+
+	var indexes []struct {
+		Owner     string
+		IndexName string
+	}
+	var err error
+	if hasOwner {
+		err = m.DB.Raw(
+			`SELECT OWNER, INDEX_NAME FROM ALL_INDEXES
+			  WHERE TABLE_OWNER = :owner AND TABLE_NAME = :tab AND STATUS = 'UNUSABLE'`,
+			sql.Named("owner", owner), sql.Named("tab", tab),
+		).Scan(&indexes).Error
+	} else {
+		err = m.DB.Raw(
+			`SELECT INDEX_NAME FROM USER_INDEXES WHERE TABLE_NAME = :tab AND STATUS = 'UNUSABLE'`,
+			sql.Named("tab", tab),
+		).Scan(&indexes).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, idx := range indexes {
+		name := dictIdentifier(idx.IndexName)
+		if idx.Owner != "" {
+			name = dictIdentifier(idx.Owner, idx.IndexName)
+		}
+		if err = m.DB.Exec("ALTER INDEX ? REBUILD", name).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GatherStats gathers the optimizer statistics of the table of value, and of its indexes, through
+// DBMS_STATS.GATHER_TABLE_STATS, e.g. right after a migration or a bulk load:
+//
+//	db.Migrator().(oracle.Migrator).GatherStats(&User{})
+//	// BEGIN DBMS_STATS.GATHER_TABLE_STATS(ownname => SYS_CONTEXT('USERENV','CURRENT_SCHEMA'), tabname => '"USERS"', cascade => TRUE); END;
+//
+// The names are handed over quoted, in dictionary case, so that mixed-case tables are found.
+func (m Migrator) GatherStats(value interface{}) error {
+	ns := getNS(m.DB, m.Dialector)
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		owner, tab, hasOwner := ns.dictQualifiedParts(stmt.Table)
+		if hasOwner {
+			return m.DB.Exec(
+				"BEGIN DBMS_STATS.GATHER_TABLE_STATS(ownname => ?, tabname => ?, cascade => TRUE); END;",
+				dictIdentifier(owner).Name, dictIdentifier(tab).Name,
+			).Error
+		}
+		return m.DB.Exec(
+			"BEGIN DBMS_STATS.GATHER_TABLE_STATS(ownname => SYS_CONTEXT('USERENV','CURRENT_SCHEMA'), tabname => ?, cascade => TRUE); END;",
+			dictIdentifier(tab).Name,
+		).Error
+	})
+}
+
+// tablespaceIdentifier is the quoted dictionary name of tablespace: the inner name when it is
+// quoted, else the name upper cased
+func tablespaceIdentifier(tablespace string) clause.Column {
+	tablespace = strings.TrimSpace(tablespace)
+	if inner, ok := IsExplicitQuoted(tablespace); ok {
+		return dictIdentifier(inner)
+	}
+	return dictIdentifier(strings.ToUpper(tablespace))
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"hash/fnv"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/iancoleman/strcase"
@@ -31,6 +33,13 @@ func (m Migrator) AutoMigrate(dst ...interface{}) error {
 	if err := m.Migrator.AutoMigrate(dst...); err != nil {
 		return err
 	}
+	if cfg := m.config(); cfg != nil && cfg.IndexForeignKeys && !m.DB.IgnoreRelationshipsWhenMigrating {
+		for _, value := range dst {
+			if err := m.createForeignKeyIndexes(value); err != nil {
+				return err
+			}
+		}
+	}
 	// set table comment
 	if tableComments, ok := m.DB.Get("gorm:table_comments"); ok {
 		for i := 0; i < len(dst); i++ {
@@ -55,6 +64,81 @@ func (m Migrator) AutoMigrate(dst ...interface{}) error {
 	return nil
 }
 
+// config returns the dialector configuration, if the migrator runs on this package's dialector.
+func (m Migrator) config() *Config {
+	switch d := m.Dialector.(type) {
+	case Dialector:
+		return d.Config
+	case *Dialector:
+		return d.Config
+	}
+	return nil
+}
+
+// createForeignKeyIndexes creates IDX_<table>_<fk columns> for each relationship whose foreign key
+// lives in value's table, unless the primary key or a declared index already leads with those columns.
+func (m Migrator) createForeignKeyIndexes(value interface{}) error {
+	ns := getNS(m.DB, m.Dialector)
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			if rel.Field.IgnoreMigration {
+				continue
+			}
+			c := rel.ParseConstraint()
+			if c == nil || c.Schema != stmt.Schema || len(c.ForeignKeys) == 0 {
+				continue
+			}
+
+			cols := make([]string, 0, len(c.ForeignKeys))
+			columns := make([]interface{}, 0, len(c.ForeignKeys))
+			for _, fk := range c.ForeignKeys {
+				cols = append(cols, fk.DBName)
+				columns = append(columns, clause.Column{Name: fk.DBName})
+			}
+			if hasLeadingColumns(stmt.Schema.PrimaryFieldDBNames, cols) {
+				continue
+			}
+			covered := false
+			for _, idx := range stmt.Schema.ParseIndexes() {
+				names := make([]string, 0, len(idx.Fields))
+				for _, opt := range idx.Fields {
+					names = append(names, opt.DBName)
+				}
+				if covered = len(idx.Where) == 0 && hasLeadingColumns(names, cols); covered {
+					break
+				}
+			}
+			if covered {
+				continue
+			}
+
+			name := ns.IndexName(stmt.Table, strings.Join(cols, "_"))
+			if m.HasIndex(value, name) {
+				continue
+			}
+			if err := m.DB.Exec("CREATE INDEX ? ON ? ?",
+				indexNameColumn(ns, name), m.CurrentTable(stmt), columns,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// hasLeadingColumns reports whether names starts with cols, in any order.
+func hasLeadingColumns(names, cols []string) bool {
+	if len(names) < len(cols) {
+		return false
+	}
+	for _, col := range cols {
+		if !slices.Contains(names[:len(cols)], col) {
+			return false
+		}
+	}
+	return true
+}
+
 // FullDataTypeOf returns field's db full data type
 func (m Migrator) FullDataTypeOf(field *schema.Field) (expr clause.Expr) {
 	expr.SQL = m.DataTypeOf(field)
@@ -93,16 +177,20 @@ func (m Migrator) GetTypeAliases(databaseTypeName string) (types []string) {
 		types = append(types, "clob", "nclob", "longvarchar", "ocicloblocator")
 	case "char", "nchar", "varchar", "varchar2", "nvarchar2":
 		types = append(types, "char", "nchar", "varchar", "varchar2", "nvarchar2")
-	case "number", "integer", "smallint":
-		types = append(types, "number", "integer", "smallint")
-	case "decimal", "numeric", "ibfloat", "ibdouble":
-		types = append(types, "decimal", "numeric", "ibfloat", "ibdouble")
+	case "number", "integer", "smallint", "decimal", "numeric", "float":
+		types = append(types, "number", "integer", "smallint", "decimal", "numeric", "float")
+	case "ibfloat", "ibdouble", "binary_float", "binary_double":
+		types = append(types, "ibfloat", "ibdouble", "binary_float", "binary_double")
 	case "timestampdty", "timestamp", "date":
 		types = append(types, "timestampdty", "timestamp", "date")
 	case "timestamptz_dty", "timestamp with time zone":
 		types = append(types, "timestamptz_dty", "timestamp with time zone")
 	case "timestampltz_dty", "timestampeltz", "timestamp with local time zone":
 		types = append(types, "timestampltz_dty", "timestampeltz", "timestamp with local time zone")
+	case "xmltype", "sys.xmltype", "ocixmltype":
+		types = append(types, "xmltype", "sys.xmltype", "ocixmltype")
+	case "boolean":
+		types = append(types, "boolean")
 	default:
 		return
 	}
@@ -125,6 +213,7 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 			sqlBuf := "CREATE TABLE ? ("
 			binds := []interface{}{m.CurrentTable(stmt)}
 			hasPrimaryKeyInDataType := false
+			var nestedTables []collectionColumn
 
 			// columns
 			for _, dbName := range stmt.Schema.DBNames {
@@ -132,6 +221,14 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				if f.IgnoreMigration {
 					continue
 				}
+				if ct, ok := parseCollectionType(f); ok {
+					if err = m.ensureCollectionType(ct); err != nil {
+						return err
+					}
+					if ct.Kind == CollectionNestedTable {
+						nestedTables = append(nestedTables, collectionColumn{column: dbName, storage: nestedTableStorageName(ns, stmt.Table, dbName)})
+					}
+				}
 				sqlBuf += "? ?"
 				if strings.Contains(strings.ToUpper(m.DataTypeOf(f)), "PRIMARY KEY") {
 					hasPrimaryKeyInDataType = true
@@ -155,7 +252,7 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				}
 				sqlBuf += "),"
 				pkName := ns.UniqueName(stmt.Table, strings.Join(pkColNames, "_"))
-				binds = append(binds, clause.Column{Name: pkName, Raw: true})
+				binds = append(binds, indexNameColumn(ns, pkName))
 				binds = append(binds, pkCols...)
 			}
 
@@ -166,10 +263,11 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 						continue
 					}
 					if c := rel.ParseConstraint(); c != nil && c.Schema == stmt.Schema {
-						// Oracle: no ON UPDATE
-						c.OnUpdate = ""                  // primary fix
-						sqlFrag, vars := c.Build()       // build SQL + binds
-						sqlFrag = stripOnUpdate(sqlFrag) // guard: remove any residual "ON UPDATE ..."
+						// Oracle: no ON UPDATE, ON DELETE only CASCADE / SET NULL
+						c.OnUpdate = ""                                 // primary fix
+						c.OnDelete = normalizeOnDelete(c.OnDelete)      // RESTRICT / NO ACTION are Oracle's default
+						sqlFrag, vars := c.Build()                      // build SQL + binds
+						sqlFrag = stripOnDelete(stripOnUpdate(sqlFrag)) // guard: remove any residual unsupported action
 						sqlBuf += sqlFrag + ","
 						binds = append(binds, vars...)
 					}
@@ -180,13 +278,13 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				sqlBuf += "CONSTRAINT ? UNIQUE (?),"
 				binds = append(
 					binds,
-					clause.Column{Name: uni.Name, Raw: true},
+					indexNameColumn(ns, uni.Name),
 					clause.Column{Name: uni.Field.DBName},
 				)
 			}
 			for _, chk := range stmt.Schema.ParseCheckConstraints() {
 				sqlBuf += "CONSTRAINT ? CHECK (?),"
-				binds = append(binds, clause.Column{Name: chk.Name, Raw: true}, clause.Expr{SQL: chk.Constraint})
+				binds = append(binds, indexNameColumn(ns, chk.Name), clause.Expr{SQL: chk.Constraint})
 			}
 
 			// collect indexes for post-create CreateIndex
@@ -196,12 +294,25 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 
 			sqlBuf = strings.TrimSuffix(sqlBuf, ",") + ")"
 
+			// nested table columns need a storage table
+			for _, nt := range nestedTables {
+				sqlBuf += " NESTED TABLE ? STORE AS ?"
+				binds = append(binds, clause.Column{Name: nt.column}, clause.Column{Name: nt.storage, Raw: true})
+			}
+
 			// no MySQL-style table options
 
 			if err = tx.Exec(sqlBuf, binds...).Error; err != nil {
 				return err
 			}
 
+			// sequence-fed columns: CREATE SEQUENCE and the BEFORE INSERT trigger assigning it
+			for _, f := range m.sequenceFields(stmt.Schema) {
+				if err = m.ensureSequence(stmt, f); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		}); err != nil {
 			return err
@@ -267,7 +378,10 @@ func (m Migrator) DropTable(values ...interface{}) error {
 			if purge, ok := m.DB.Get("oracle:purge_on_drop"); ok && purge == true {
 				rawSql += " PURGE"
 			}
-			return m.DB.Exec(rawSql, m.CurrentTable(stmt)).Error
+			if err := m.DB.Exec(rawSql, m.CurrentTable(stmt)).Error; err != nil {
+				return err
+			}
+			return m.dropSequences(stmt)
 		}); err != nil {
 			return err
 		}
@@ -309,34 +423,113 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 			Name        string         `gorm:"column:column_name"`
 			DataType    string         `gorm:"column:data_type"`
 			DataLength  sql.NullInt64  `gorm:"column:data_length"`
+			CharLength  sql.NullInt64  `gorm:"column:char_length"` // width in characters for character types
 			Precision   sql.NullInt64  `gorm:"column:data_precision"`
 			Scale       sql.NullInt64  `gorm:"column:data_scale"`
 			Nullable    string         `gorm:"column:nullable"`     // 'Y' or 'N'
 			DataDefault sql.NullString `gorm:"column:data_default"` // raw default text
+			Comment     sql.NullString `gorm:"column:comments"`
 		}
 		var rows []row
 
-		var q string
+		columnsQuery := func(hasOwner bool) string {
+			if hasOwner {
+				return `
+				SELECT c.COLUMN_NAME, c.DATA_TYPE, c.DATA_LENGTH, c.CHAR_LENGTH, c.DATA_PRECISION, c.DATA_SCALE,
+				       c.NULLABLE, c.DATA_DEFAULT, cc.COMMENTS
+				  FROM ALL_TAB_COLUMNS c
+				  LEFT JOIN ALL_COL_COMMENTS cc
+				    ON cc.OWNER = c.OWNER AND cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = c.COLUMN_NAME
+				 WHERE c.OWNER = :owner AND c.TABLE_NAME = :tab
+				 ORDER BY c.COLUMN_ID`
+			}
+			return `
+				SELECT c.COLUMN_NAME, c.DATA_TYPE, c.DATA_LENGTH, c.CHAR_LENGTH, c.DATA_PRECISION, c.DATA_SCALE,
+				       c.NULLABLE, c.DATA_DEFAULT, cc.COMMENTS
+				  FROM USER_TAB_COLUMNS c
+				  LEFT JOIN USER_COL_COMMENTS cc
+				    ON cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = c.COLUMN_NAME
+				 WHERE c.TABLE_NAME = :tab
+				 ORDER BY c.COLUMN_ID`
+		}
+
+		q := columnsQuery(hasOwner)
 		var args []interface{}
 		if hasOwner {
-			q = `
-				SELECT COLUMN_NAME, DATA_TYPE, DATA_LENGTH, DATA_PRECISION, DATA_SCALE, NULLABLE, DATA_DEFAULT
-				  FROM ALL_TAB_COLUMNS
-				 WHERE OWNER = :owner AND TABLE_NAME = :tab
-				 ORDER BY COLUMN_ID`
 			args = []interface{}{sql.Named("owner", owner), sql.Named("tab", tab)}
 		} else {
-			q = `
-				SELECT COLUMN_NAME, DATA_TYPE, DATA_LENGTH, DATA_PRECISION, DATA_SCALE, NULLABLE, DATA_DEFAULT
-				  FROM USER_TAB_COLUMNS
-				 WHERE TABLE_NAME = :tab
-				 ORDER BY COLUMN_ID`
 			args = []interface{}{sql.Named("tab", tab)}
 		}
 
 		if err := m.DB.Raw(q, args...).Scan(&rows).Error; err != nil {
 			return err
 		}
+		if len(rows) == 0 {
+			// a synonym has no columns of its own, describe the table or view it stands for
+			if synOwner, synTab, ok := m.synonymTarget(owner, tab, hasOwner); ok {
+				owner, tab, hasOwner = synOwner, synTab, true
+				q = columnsQuery(hasOwner)
+				args = []interface{}{sql.Named("owner", owner), sql.Named("tab", tab)}
+				if err := m.DB.Raw(q, args...).Scan(&rows).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		type keyRow struct {
+			Name           string `gorm:"column:column_name"`
+			ConstraintType string `gorm:"column:constraint_type"`
+			ColumnCount    int    `gorm:"column:column_count"`
+		}
+		var (
+			identities []string
+			keys       []keyRow
+		)
+		if hasOwner {
+			q = `
+				SELECT COLUMN_NAME FROM ALL_TAB_IDENTITY_COLS
+				 WHERE OWNER = :owner AND TABLE_NAME = :tab`
+		} else {
+			q = `
+				SELECT COLUMN_NAME FROM USER_TAB_IDENTITY_COLS
+				 WHERE TABLE_NAME = :tab`
+		}
+		if err := m.DB.Raw(q, args...).Scan(&identities).Error; err != nil {
+			return err
+		}
+		if hasOwner {
+			q = `
+				SELECT cc.COLUMN_NAME, k.CONSTRAINT_TYPE,
+				       (SELECT COUNT(*) FROM ALL_CONS_COLUMNS x
+				         WHERE x.OWNER = k.OWNER AND x.CONSTRAINT_NAME = k.CONSTRAINT_NAME) AS COLUMN_COUNT
+				  FROM ALL_CONSTRAINTS k
+				  JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = k.OWNER AND cc.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+				 WHERE k.OWNER = :owner AND k.TABLE_NAME = :tab AND k.CONSTRAINT_TYPE IN ('P', 'U')`
+		} else {
+			q = `
+				SELECT cc.COLUMN_NAME, k.CONSTRAINT_TYPE,
+				       (SELECT COUNT(*) FROM USER_CONS_COLUMNS x
+				         WHERE x.CONSTRAINT_NAME = k.CONSTRAINT_NAME) AS COLUMN_COUNT
+				  FROM USER_CONSTRAINTS k
+				  JOIN USER_CONS_COLUMNS cc ON cc.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+				 WHERE k.TABLE_NAME = :tab AND k.CONSTRAINT_TYPE IN ('P', 'U')`
+		}
+		if err := m.DB.Raw(q, args...).Scan(&keys).Error; err != nil {
+			return err
+		}
+
+		primaryKeys, uniques := map[string]bool{}, map[string]bool{}
+		for _, k := range keys {
+			switch k.ConstraintType {
+			case "P":
+				primaryKeys[k.Name] = true
+			case "U":
+				// like gorm's Unique tag, only single-column constraints make a column unique
+				if k.ColumnCount == 1 {
+					uniques[k.Name] = true
+				}
+			}
+		}
 
 		for _, r := range rows {
 			ct := migrator.ColumnType{}
@@ -349,7 +542,10 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 			if r.Nullable != "" {
 				ct.NullableValue = sql.NullBool{Bool: strings.EqualFold(r.Nullable, "Y"), Valid: true}
 			}
-			if r.DataLength.Valid {
+			switch {
+			case r.CharLength.Valid && r.CharLength.Int64 > 0:
+				ct.LengthValue = r.CharLength
+			case r.DataLength.Valid:
 				ct.LengthValue = r.DataLength
 			}
 			if r.Precision.Valid {
@@ -361,6 +557,13 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 			if r.DataDefault.Valid {
 				ct.DefaultValueValue = sql.NullString{String: strings.TrimSpace(r.DataDefault.String), Valid: true}
 			}
+			if r.Comment.Valid {
+				ct.CommentValue = r.Comment
+			}
+			ct.ColumnTypeValue = sql.NullString{String: dictColumnType(r.DataType, ct.LengthValue, r.Precision, r.Scale), Valid: true}
+			ct.PrimaryKeyValue = sql.NullBool{Bool: primaryKeys[r.Name], Valid: true}
+			ct.UniqueValue = sql.NullBool{Bool: uniques[r.Name], Valid: true}
+			ct.AutoIncrementValue = sql.NullBool{Bool: slices.Contains(identities, r.Name), Valid: true}
 
 			out = append(out, ct)
 		}
@@ -370,6 +573,54 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 	return out, err
 }
 
+// dictColumnType renders the full column type of a dictionary row, e.g. VARCHAR2(100) or NUMBER(10,2)
+func dictColumnType(dataType string, length, precision, scale sql.NullInt64) string {
+	switch strings.ToUpper(dataType) {
+	case "VARCHAR2", "NVARCHAR2", "CHAR", "NCHAR", "RAW", "UROWID":
+		if length.Valid && length.Int64 > 0 {
+			return fmt.Sprintf("%s(%d)", dataType, length.Int64)
+		}
+	case "NUMBER":
+		if precision.Valid {
+			return fmt.Sprintf("%s(%d,%d)", dataType, precision.Int64, scale.Int64)
+		}
+		if scale.Valid && scale.Int64 == 0 {
+			return dataType + "(*,0)"
+		}
+	case "FLOAT":
+		if precision.Valid {
+			return fmt.Sprintf("%s(%d)", dataType, precision.Int64)
+		}
+	}
+	return dataType
+}
+
+// synonymTarget resolves the local synonym owner.name (a private synonym of the current user, else a
+// public one when unqualified) to the owner and name of the object it stands for
+func (m Migrator) synonymTarget(owner, name string, hasOwner bool) (string, string, bool) {
+	var targets []struct {
+		Owner string `gorm:"column:table_owner"`
+		Name  string `gorm:"column:table_name"`
+	}
+	var err error
+	if hasOwner {
+		err = m.DB.Raw(`
+			SELECT TABLE_OWNER, TABLE_NAME FROM ALL_SYNONYMS
+			 WHERE OWNER = :owner AND SYNONYM_NAME = :name AND DB_LINK IS NULL`,
+			sql.Named("owner", owner), sql.Named("name", name)).Scan(&targets).Error
+	} else {
+		err = m.DB.Raw(`
+			SELECT TABLE_OWNER, TABLE_NAME FROM ALL_SYNONYMS
+			 WHERE OWNER IN (USER, 'PUBLIC') AND SYNONYM_NAME = :name AND DB_LINK IS NULL
+			 ORDER BY CASE OWNER WHEN 'PUBLIC' THEN 1 ELSE 0 END`,
+			sql.Named("name", name)).Scan(&targets).Error
+	}
+	if err != nil || len(targets) == 0 {
+		return "", "", false
+	}
+	return targets[0].Owner, targets[0].Name, true
+}
+
 // RenameTable rename table from oldName to newName
 func (m Migrator) RenameTable(oldName, newName interface{}) (err error) {
 	resolveTable := func(name interface{}) (result string, err error) {
@@ -435,6 +686,13 @@ func (m Migrator) AddColumn(value interface{}, field string) error {
 			return nil
 		}
 
+		ct, isCollection := parseCollectionType(sf)
+		if isCollection {
+			if err := m.ensureCollectionType(ct); err != nil {
+				return err
+			}
+		}
+
 		// Build definition for ADD: include identity, skip nullability here.
 		def := m.buildColumnFragment(sf, nil, columnFragOpts{
 			forAlter:        false,
@@ -448,10 +706,21 @@ func (m Migrator) AddColumn(value interface{}, field string) error {
 		add.WriteString(" ADD (")
 		add.WriteString(def)
 		add.WriteByte(')')
+		if isCollection && ct.Kind == CollectionNestedTable {
+			add.WriteString(" NESTED TABLE ")
+			m.DB.Dialector.QuoteTo(&add, sf.DBName)
+			add.WriteString(" STORE AS ")
+			add.WriteString(nestedTableStorageName(getNS(m.DB, m.Dialector), stmt.Table, sf.DBName))
+		}
 
 		if err := m.DB.Exec(add.String()).Error; err != nil {
 			return err
 		}
+		if usesSequence(m.useSequences(), sf) {
+			if err := m.ensureSequence(stmt, sf); err != nil {
+				return err
+			}
+		}
 
 		// Enforce NOT NULL separately if required.
 		if sf.NotNull {
@@ -520,34 +789,21 @@ func (m Migrator) buildColumnFragment(
 	// <datatype>
 	dt := m.DataTypeOf(sf) // IMPORTANT: DataTypeOf, not FullDataTypeOf
 	udt := strings.ToUpper(dt)
+	if i := strings.Index(udt, " GENERATED "); i >= 0 && !opts.includeIdentity && strings.HasSuffix(udt, " AS IDENTITY") {
+		// an identity clause in MODIFY fails with ORA-30673 unless the column already is one;
+		// identity is added and dropped on its own, see AlterColumn
+		dt, udt = dt[:i], udt[:i]
+	}
 	frag.WriteString(dt)
 
 	// [DEFAULT …]
-	switch {
-	case sf.DefaultValueInterface != nil:
-		if s, ok := sf.DefaultValueInterface.(string); ok {
-			frag.WriteString(" DEFAULT '")
-			for _, r := range s {
-				if r == '\'' {
-					frag.WriteString("''")
-				} else {
-					frag.WriteRune(r)
-				}
-			}
-			frag.WriteByte('\'')
-		} else {
-			frag.WriteString(" DEFAULT ")
-			frag.WriteString(toSQLLiteral(sf.DefaultValueInterface))
-		}
-	case sf.HasDefaultValue && strings.TrimSpace(sf.DefaultValue) != "" && sf.DefaultValue != "(-)":
+	if def, ok := columnDefaultSQL(sf); ok {
 		frag.WriteString(" DEFAULT ")
-		frag.WriteString(sf.DefaultValue)
-	default:
+		frag.WriteString(def)
+	} else if opts.forAlter && opts.dropDefault && dictDefault != nil &&
+		dictDefault.Valid && strings.TrimSpace(dictDefault.String) != "" {
 		// only in ALTER: drop an existing default if model has no default
-		if opts.forAlter && opts.dropDefault && dictDefault != nil &&
-			dictDefault.Valid && strings.TrimSpace(dictDefault.String) != "" {
-			frag.WriteString(" DEFAULT NULL")
-		}
+		frag.WriteString(" DEFAULT NULL")
 	}
 
 	// [NULL|NOT NULL] only when requested
@@ -559,7 +815,7 @@ func (m Migrator) buildColumnFragment(
 	}
 
 	// [GENERATED BY DEFAULT AS IDENTITY] only if not already present
-	if opts.includeIdentity && sf.AutoIncrement &&
+	if opts.includeIdentity && sf.AutoIncrement && !usesSequence(m.useSequences(), sf) &&
 		!(strings.Contains(udt, "GENERATED") && strings.Contains(udt, "AS IDENTITY")) {
 		frag.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
 	}
@@ -567,7 +823,135 @@ func (m Migrator) buildColumnFragment(
 	return frag.String()
 }
 
+// columnDefaultSQL returns the DEFAULT expression declared by the model, if any.
+func columnDefaultSQL(sf *schema.Field) (string, bool) {
+	switch {
+	case sf.DefaultValueInterface != nil:
+		if s, ok := sf.DefaultValueInterface.(string); ok {
+			return "'" + strings.ReplaceAll(s, "'", "''") + "'", true
+		}
+		return toSQLLiteral(sf.DefaultValueInterface), true
+	case sf.HasDefaultValue && strings.TrimSpace(sf.DefaultValue) != "" && sf.DefaultValue != "(-)":
+		return sf.DefaultValue, true
+	}
+	return "", false
+}
+
+// dictColumn is the data dictionary definition of an existing column.
+type dictColumn struct {
+	Default    sql.NullString
+	Nullable   string
+	DataType   string
+	Precision  sql.NullInt64
+	Scale      sql.NullInt64
+	CharLength sql.NullInt64
+	CharUsed   sql.NullString
+}
+
+// sameColumnDefault reports whether the dictionary default already matches the model's DEFAULT.
+func sameColumnDefault(sf *schema.Field, dictDefault sql.NullString, dropDefault bool) bool {
+	cur := strings.TrimSpace(dictDefault.String)
+	if strings.EqualFold(cur, "NULL") {
+		cur = ""
+	}
+	if def, ok := columnDefaultSQL(sf); ok {
+		return cur == strings.TrimSpace(def)
+	}
+	return cur == "" || !dropDefault
+}
+
+var columnTypeRe = regexp.MustCompile(`^([A-Z0-9_ ]*?)\s*(?:\(\s*(\d+)\s*(?:,\s*(-?\d+)\s*)?(?:(CHAR|BYTE)\s*)?\))?$`)
+
+// timestampPrecisionRe matches the fractional seconds precision of a TIMESTAMP type, which
+// precedes its time zone qualifier
+var timestampPrecisionRe = regexp.MustCompile(`^TIMESTAMP\s*\(\s*(\d+)\s*\)`)
+
+// sameColumnType reports whether the dictionary definition already satisfies target, a
+// datatype built by DataTypeOf. Types it cannot compare are reported as changed.
+func (m Migrator) sameColumnType(target string, cur dictColumn) bool {
+	t := strings.ToUpper(strings.TrimSpace(target))
+	if i := strings.Index(t, " GENERATED "); i >= 0 {
+		t = t[:i]
+	}
+	// TIMESTAMP(3) WITH TIME ZONE compares as TIMESTAMP WITH TIME ZONE(3); the dictionary reports
+	// the precision in both DATA_TYPE and DATA_SCALE
+	if tm := timestampPrecisionRe.FindStringSubmatch(t); tm != nil {
+		t = "TIMESTAMP" + t[len(tm[0]):] + "(" + tm[1] + ")"
+	}
+	curType := timestampPrecisionRe.ReplaceAllString(strings.ToUpper(cur.DataType), "TIMESTAMP")
+
+	match := columnTypeRe.FindStringSubmatch(t)
+	if match == nil {
+		return false
+	}
+	base := strings.TrimSpace(match[1])
+	if !slices.Contains(m.GetTypeAliases(strings.ToLower(base)), strings.ToLower(curType)) {
+		return false
+	}
+
+	switch base {
+	case "INTEGER", "SMALLINT":
+		// stored as NUMBER(*,0)
+		return !cur.Precision.Valid && cur.Scale.Int64 == 0
+	case "NUMBER", "DECIMAL", "NUMERIC":
+		if match[2] == "" {
+			return !cur.Precision.Valid && !cur.Scale.Valid
+		}
+		precision, _ := strconv.ParseInt(match[2], 10, 64)
+		var scale int64
+		if match[3] != "" {
+			scale, _ = strconv.ParseInt(match[3], 10, 64)
+		}
+		return cur.Precision.Valid && cur.Precision.Int64 == precision && cur.Scale.Int64 == scale
+	case "FLOAT":
+		// FLOAT defaults to binary precision 126; a bare NUMBER already holds any float
+		precision := int64(126)
+		if match[2] != "" {
+			precision, _ = strconv.ParseInt(match[2], 10, 64)
+		}
+		if strings.EqualFold(cur.DataType, "NUMBER") {
+			return !cur.Precision.Valid && !cur.Scale.Valid
+		}
+		return strings.EqualFold(cur.DataType, "FLOAT") && cur.Precision.Int64 == precision
+	case "VARCHAR", "VARCHAR2", "NVARCHAR2", "CHAR", "NCHAR":
+		if base == "VARCHAR" {
+			base = "VARCHAR2"
+		}
+		length := int64(1)
+		if match[2] != "" {
+			length, _ = strconv.ParseInt(match[2], 10, 64)
+		} else if strings.HasSuffix(base, "VARCHAR2") {
+			return false
+		}
+		// without CHAR or BYTE the length semantics follow NLS_LENGTH_SEMANTICS
+		switch match[4] {
+		case "CHAR":
+			if cur.CharUsed.String != "C" {
+				return false
+			}
+		case "BYTE":
+			if cur.CharUsed.String != "B" {
+				return false
+			}
+		}
+		return curType == base && cur.CharLength.Int64 == length
+	case "TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE":
+		precision := int64(6)
+		if match[2] != "" {
+			precision, _ = strconv.ParseInt(match[2], 10, 64)
+		}
+		return curType == base && cur.Scale.Int64 == precision
+	case "DATE", "BOOLEAN", "BLOB", "CLOB", "NCLOB", "BINARY_FLOAT", "BINARY_DOUBLE":
+		return curType == base
+	}
+	return false
+}
+
 // DropColumn ALTER TABLE <table> DROP COLUMN <col>
+//
+// The check constraints referencing the column are dropped first, as one naming another column too
+// fails the drop with ORA-12991, and so are the indexes on the column alone. Oracle removes the
+// comment of the column with it.
 func (m Migrator) DropColumn(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema == nil {
@@ -578,6 +962,82 @@ func (m Migrator) DropColumn(value interface{}, name string) error {
 			return fmt.Errorf("oracle: DropColumn: field %q not found", name)
 		}
 
+		ns := getNS(m.DB, m.Dialector)
+		owner, tab, hasOwner := ns.dictQualifiedParts(stmt.Table)
+		col := ns.dictCasePart(f.DBName)
+
+		var checks []string
+		var indexes []struct {
+			Owner string `gorm:"column:owner"`
+			Name  string `gorm:"column:index_name"`
+		}
+		if hasOwner {
+			if err := m.DB.Raw(`
+				SELECT c.CONSTRAINT_NAME
+				  FROM ALL_CONSTRAINTS c
+				 WHERE c.OWNER = :owner AND c.TABLE_NAME = :tab AND c.CONSTRAINT_TYPE = 'C'
+				   AND EXISTS (SELECT 1 FROM ALL_CONS_COLUMNS cc
+				                WHERE cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+				                  AND cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = :col)`,
+				sql.Named("owner", owner), sql.Named("tab", tab), sql.Named("col", col),
+			).Scan(&checks).Error; err != nil {
+				return err
+			}
+			if err := m.DB.Raw(`
+				SELECT i.OWNER, i.INDEX_NAME
+				  FROM ALL_INDEXES i
+				 WHERE i.TABLE_OWNER = :owner AND i.TABLE_NAME = :tab
+				   AND NOT EXISTS (SELECT 1 FROM ALL_CONSTRAINTS c
+				                    WHERE c.INDEX_OWNER = i.OWNER AND c.INDEX_NAME = i.INDEX_NAME)
+				   AND (SELECT COUNT(*) FROM ALL_IND_COLUMNS ic
+				         WHERE ic.INDEX_OWNER = i.OWNER AND ic.INDEX_NAME = i.INDEX_NAME) = 1
+				   AND EXISTS (SELECT 1 FROM ALL_IND_COLUMNS ic
+				                WHERE ic.INDEX_OWNER = i.OWNER AND ic.INDEX_NAME = i.INDEX_NAME AND ic.COLUMN_NAME = :col)`,
+				sql.Named("owner", owner), sql.Named("tab", tab), sql.Named("col", col),
+			).Scan(&indexes).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := m.DB.Raw(`
+				SELECT c.CONSTRAINT_NAME
+				  FROM USER_CONSTRAINTS c
+				 WHERE c.TABLE_NAME = :tab AND c.CONSTRAINT_TYPE = 'C'
+				   AND EXISTS (SELECT 1 FROM USER_CONS_COLUMNS cc
+				                WHERE cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+				                  AND cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = :col)`,
+				sql.Named("tab", tab), sql.Named("col", col),
+			).Scan(&checks).Error; err != nil {
+				return err
+			}
+			if err := m.DB.Raw(`
+				SELECT i.INDEX_NAME
+				  FROM USER_INDEXES i
+				 WHERE i.TABLE_NAME = :tab
+				   AND NOT EXISTS (SELECT 1 FROM USER_CONSTRAINTS c WHERE c.INDEX_NAME = i.INDEX_NAME)
+				   AND (SELECT COUNT(*) FROM USER_IND_COLUMNS ic WHERE ic.INDEX_NAME = i.INDEX_NAME) = 1
+				   AND EXISTS (SELECT 1 FROM USER_IND_COLUMNS ic
+				                WHERE ic.INDEX_NAME = i.INDEX_NAME AND ic.COLUMN_NAME = :col)`,
+				sql.Named("tab", tab), sql.Named("col", col),
+			).Scan(&indexes).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, check := range checks {
+			if err := m.DB.Exec("ALTER TABLE ? DROP CONSTRAINT ?", m.CurrentTable(stmt), dictIdentifier(check)).Error; err != nil {
+				return err
+			}
+		}
+		for _, index := range indexes {
+			name := dictIdentifier(index.Name)
+			if index.Owner != "" {
+				name = dictIdentifier(index.Owner, index.Name)
+			}
+			if err := m.DB.Exec("DROP INDEX ?", name).Error; err != nil {
+				return err
+			}
+		}
+
 		var rawSql strings.Builder
 		rawSql.WriteString("ALTER TABLE ")
 		m.DB.Dialector.QuoteTo(&rawSql, stmt.Table)
@@ -588,6 +1048,35 @@ func (m Migrator) DropColumn(value interface{}, name string) error {
 	})
 }
 
+// DropColumns drops several columns in one statement: ALTER TABLE <t> DROP (<a>, <b>, …) CASCADE CONSTRAINTS.
+// names are field names or column names; CASCADE CONSTRAINTS also drops the multi-column and
+// referencing constraints that would otherwise make the drop fail.
+func (m Migrator) DropColumns(value interface{}, names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		var rawSql strings.Builder
+		rawSql.WriteString("ALTER TABLE ")
+		m.DB.Dialector.QuoteTo(&rawSql, stmt.Table)
+		rawSql.WriteString(" DROP (")
+		for idx, name := range names {
+			if idx > 0 {
+				rawSql.WriteString(", ")
+			}
+			if stmt.Schema != nil {
+				if f := stmt.Schema.LookUpField(name); f != nil && f.DBName != "" {
+					name = f.DBName
+				}
+			}
+			m.DB.Dialector.QuoteTo(&rawSql, name)
+		}
+		rawSql.WriteString(") CASCADE CONSTRAINTS")
+
+		return m.DB.Exec(rawSql.String()).Error
+	})
+}
+
 // AlterColumn
 //
 // ALTER TABLE <t> MODIFY (<col …>)
@@ -610,17 +1099,16 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 		owner, tab, hasOwner := ns.dictQualifiedParts(stmt.Table)
 		col := ns.dictCasePart(sf.DBName)
 
-		var curDefault sql.NullString
-		var curNullable string
+		var cur dictColumn
 		var hasIdentity int
 
 		if hasOwner {
 			_ = m.DB.Raw(`
-                SELECT c.DATA_DEFAULT, c.NULLABLE
+                SELECT c.DATA_DEFAULT, c.NULLABLE, c.DATA_TYPE, c.DATA_PRECISION, c.DATA_SCALE, c.CHAR_LENGTH, c.CHAR_USED
                   FROM ALL_TAB_COLUMNS c
                  WHERE c.OWNER = :owner AND c.TABLE_NAME = :tab AND c.COLUMN_NAME = :col`,
 				sql.Named("owner", owner), sql.Named("tab", tab), sql.Named("col", col),
-			).Row().Scan(&curDefault, &curNullable)
+			).Row().Scan(&cur.Default, &cur.Nullable, &cur.DataType, &cur.Precision, &cur.Scale, &cur.CharLength, &cur.CharUsed)
 
 			_ = m.DB.Raw(`
                 SELECT 1 FROM ALL_TAB_IDENTITY_COLS
@@ -629,11 +1117,11 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 			).Row().Scan(&hasIdentity)
 		} else {
 			_ = m.DB.Raw(`
-                SELECT c.DATA_DEFAULT, c.NULLABLE
+                SELECT c.DATA_DEFAULT, c.NULLABLE, c.DATA_TYPE, c.DATA_PRECISION, c.DATA_SCALE, c.CHAR_LENGTH, c.CHAR_USED
                   FROM USER_TAB_COLUMNS c
                  WHERE c.TABLE_NAME = :tab AND c.COLUMN_NAME = :col`,
 				sql.Named("tab", tab), sql.Named("col", col),
-			).Row().Scan(&curDefault, &curNullable)
+			).Row().Scan(&cur.Default, &cur.Nullable, &cur.DataType, &cur.Precision, &cur.Scale, &cur.CharLength, &cur.CharUsed)
 
 			_ = m.DB.Raw(`
                 SELECT 1 FROM USER_TAB_IDENTITY_COLS
@@ -644,7 +1132,7 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 
 		// decide nullability delta
 		var na nullAction = NullNoop
-		isCurNullable := strings.EqualFold(curNullable, "Y")
+		isCurNullable := strings.EqualFold(cur.Nullable, "Y")
 		switch {
 		case sf.NotNull && isCurNullable:
 			na = NullSetNotNull
@@ -666,29 +1154,52 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 		targetDT := m.DataTypeOf(sf)
 
 		// If target is LOB/LONG, use rewrite path instead of MODIFY
-		if targetIsLOB(targetDT) {
+		if targetIsLOB(targetDT) && !m.sameColumnType(targetDT, cur) {
 			return m.rewriteColumnToLOB(stmt, sf, targetDT) // see below
 		}
 
-		frag := m.buildColumnFragment(sf, &curDefault, columnFragOpts{
+		// an existing table migrated by a new process still needs its collection types registered
+		if ct, ok := parseCollectionType(sf); ok {
+			if err := m.ensureCollectionType(ct); err != nil {
+				return err
+			}
+		}
+
+		// Collection, XMLTYPE and VECTOR columns cannot be modified in place; only keep the comment in sync
+		if _, ok := parseCollectionType(sf); ok || (isXMLField(sf) && slices.Contains(m.GetTypeAliases("xmltype"), strings.ToLower(cur.DataType))) ||
+			(isVectorField(sf) && strings.EqualFold(cur.DataType, "VECTOR")) {
+			if strings.TrimSpace(sf.Comment) != "" {
+				return m.setColumnComment(stmt.Table, sf.DBName, sf.Comment)
+			}
+			return nil
+		}
+
+		// Nothing to MODIFY when the dictionary already matches the model; Oracle reports
+		// INTEGER/SMALLINT as NUMBER and FLOAT as FLOAT(126), so the type is compared through its aliases.
+		unchanged := na == NullNoop && sameColumnDefault(sf, cur.Default, dropDef) && m.sameColumnType(targetDT, cur)
+
+		frag := m.buildColumnFragment(sf, &cur.Default, columnFragOpts{
 			forAlter:        true,
 			nullability:     na,
 			includeIdentity: false, // identity handled separately below
 			dropDefault:     dropDef,
 		})
 
-		var alter strings.Builder
-		alter.WriteString("ALTER TABLE ")
-		m.DB.Dialector.QuoteTo(&alter, stmt.Table)
-		alter.WriteString(" MODIFY (")
-		alter.WriteString(frag)
-		alter.WriteByte(')')
-		if err := m.DB.Exec(alter.String()).Error; err != nil {
-			return err
+		if !unchanged {
+			var alter strings.Builder
+			alter.WriteString("ALTER TABLE ")
+			m.DB.Dialector.QuoteTo(&alter, stmt.Table)
+			alter.WriteString(" MODIFY (")
+			alter.WriteString(frag)
+			alter.WriteByte(')')
+			if err := m.DB.Exec(alter.String()).Error; err != nil {
+				return err
+			}
 		}
 
-		// identity add/drop separate
-		if sf.AutoIncrement && hasIdentity != 1 {
+		// identity add/drop separate; a sequence-fed column is no identity
+		sequenced := usesSequence(m.useSequences(), sf)
+		if sf.AutoIncrement && !sequenced && hasIdentity != 1 {
 			// ADD IDENTITY
 			var ai strings.Builder
 			ai.WriteString("ALTER TABLE ")
@@ -699,7 +1210,7 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 			if err := m.DB.Exec(ai.String()).Error; err != nil {
 				return err
 			}
-		} else if !sf.AutoIncrement && hasIdentity == 1 {
+		} else if (!sf.AutoIncrement || sequenced) && hasIdentity == 1 {
 			// DROP IDENTITY
 			var di strings.Builder
 			di.WriteString("ALTER TABLE ")
@@ -711,6 +1222,11 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 				return err
 			}
 		}
+		if sequenced {
+			if err := m.ensureSequence(stmt, sf); err != nil {
+				return err
+			}
+		}
 
 		// comment sync
 		if strings.TrimSpace(sf.Comment) != "" {
@@ -1029,13 +1545,18 @@ func (m Migrator) CreateConstraint(value interface{}, name string) error {
 				c.Name = n
 			}
 
-			// 2) Oracle: drop ON UPDATE (unsupported)
+			// 2) Oracle: drop ON UPDATE (unsupported) and ON DELETE actions other than CASCADE / SET NULL
 			c.OnUpdate = ""
+			c.OnDelete = normalizeOnDelete(c.OnDelete)
 			sqlFrag, vars := c.Build()
-			sqlFrag = stripOnUpdate(sqlFrag)
+			sqlFrag = stripOnDelete(stripOnUpdate(sqlFrag))
 
-			// 3) Execute
-			return m.DB.Exec(sqlFrag, vars...).Error
+			// 3) Execute: ALTER TABLE <constrained table> ADD CONSTRAINT ...
+			var table interface{} = m.CurrentTable(stmt)
+			if c.Schema != nil && c.Schema != stmt.Schema {
+				table = clause.Table{Name: c.Schema.Table}
+			}
+			return m.DB.Exec("ALTER TABLE ? ADD "+sqlFrag, append([]interface{}{table}, vars...)...).Error
 		}
 		return nil
 	})
@@ -1045,12 +1566,10 @@ func (m Migrator) CreateConstraint(value interface{}, name string) error {
 func (m Migrator) DropConstraint(value interface{}, name string) error {
 	ns := getNS(m.DB, m.Dialector)
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		dictName := ns.dictCasePart(name)
-
 		return m.DB.Exec(
 			"ALTER TABLE ? DROP CONSTRAINT ?",
 			m.CurrentTable(stmt),
-			clause.Column{Name: dictName, Raw: true},
+			indexNameColumn(ns, name),
 		).Error
 	})
 }
@@ -1098,10 +1617,116 @@ func (m Migrator) HasConstraint(value interface{}, name string) bool {
 	return err == nil && exists == 1
 }
 
+// ConstraintInfo describes a constraint of a table as recorded in the data dictionary.
+type ConstraintInfo struct {
+	Name string
+	// Type is one of PRIMARY KEY, UNIQUE, FOREIGN KEY or CHECK
+	Type    string
+	Columns []string
+	// Condition is the search condition of a CHECK constraint
+	Condition string
+	// ReferencedTable and ReferencedColumns are the target of a FOREIGN KEY, paired with Columns
+	ReferencedTable   string
+	ReferencedColumns []string
+	// DeleteRule is the ON DELETE action of a FOREIGN KEY: CASCADE, SET NULL or NO ACTION
+	DeleteRule string
+}
+
+var constraintTypes = map[string]string{"P": "PRIMARY KEY", "U": "UNIQUE", "R": "FOREIGN KEY", "C": "CHECK"}
+
+// GetConstraints returns the primary key, unique, foreign key and check constraints of value's
+// table from USER_CONSTRAINTS / ALL_CONSTRAINTS. The NOT NULL checks Oracle generates for
+// mandatory columns are left out.
+func (m Migrator) GetConstraints(value interface{}) ([]ConstraintInfo, error) {
+	ns := getNS(m.DB, m.Dialector)
+	var out []ConstraintInfo
+
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		owner, tab, hasOwner := ns.dictQualifiedParts(stmt.Table)
+
+		type row struct {
+			Name             string         `gorm:"column:constraint_name"`
+			Type             string         `gorm:"column:constraint_type"`
+			Generated        string         `gorm:"column:generated"`
+			Condition        sql.NullString `gorm:"column:search_condition_vc"`
+			DeleteRule       sql.NullString `gorm:"column:delete_rule"`
+			Column           sql.NullString `gorm:"column:column_name"`
+			ReferencedTable  sql.NullString `gorm:"column:r_table_name"`
+			ReferencedColumn sql.NullString `gorm:"column:r_column_name"`
+		}
+		var rows []row
+
+		// SEARCH_CONDITION_VC came with 12c; 11g only has the LONG SEARCH_CONDITION
+		condition := "c.SEARCH_CONDITION_VC"
+		if cfg := m.config(); cfg != nil {
+			if dbVer, err := strconv.Atoi(strings.Split(cfg.DBVer, ".")[0]); err == nil && dbVer < 12 {
+				condition = "c.SEARCH_CONDITION AS SEARCH_CONDITION_VC"
+			}
+		}
+
+		var q string
+		var args []interface{}
+		if hasOwner {
+			q = `
+				SELECT c.CONSTRAINT_NAME, c.CONSTRAINT_TYPE, c.GENERATED, ` + condition + `, c.DELETE_RULE,
+				       cc.COLUMN_NAME, r.TABLE_NAME AS R_TABLE_NAME, rc.COLUMN_NAME AS R_COLUMN_NAME
+				  FROM ALL_CONSTRAINTS c
+				  LEFT JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+				  LEFT JOIN ALL_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+				  LEFT JOIN ALL_CONS_COLUMNS rc ON rc.OWNER = c.R_OWNER AND rc.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME AND rc.POSITION = cc.POSITION
+				 WHERE c.OWNER = :owner AND c.TABLE_NAME = :tab AND c.CONSTRAINT_TYPE IN ('P', 'U', 'R', 'C')
+				 ORDER BY c.CONSTRAINT_NAME, cc.POSITION, cc.COLUMN_NAME`
+			args = []interface{}{sql.Named("owner", owner), sql.Named("tab", tab)}
+		} else {
+			q = `
+				SELECT c.CONSTRAINT_NAME, c.CONSTRAINT_TYPE, c.GENERATED, ` + condition + `, c.DELETE_RULE,
+				       cc.COLUMN_NAME, r.TABLE_NAME AS R_TABLE_NAME, rc.COLUMN_NAME AS R_COLUMN_NAME
+				  FROM USER_CONSTRAINTS c
+				  LEFT JOIN USER_CONS_COLUMNS cc ON cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+				  LEFT JOIN ALL_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+				  LEFT JOIN ALL_CONS_COLUMNS rc ON rc.OWNER = c.R_OWNER AND rc.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME AND rc.POSITION = cc.POSITION
+				 WHERE c.TABLE_NAME = :tab AND c.CONSTRAINT_TYPE IN ('P', 'U', 'R', 'C')
+				 ORDER BY c.CONSTRAINT_NAME, cc.POSITION, cc.COLUMN_NAME`
+			args = []interface{}{sql.Named("tab", tab)}
+		}
+
+		if err := m.DB.Raw(q, args...).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, r := range rows {
+			if r.Type == "C" && r.Generated == "GENERATED NAME" && strings.HasSuffix(strings.ToUpper(r.Condition.String), " IS NOT NULL") {
+				continue
+			}
+			if len(out) == 0 || out[len(out)-1].Name != r.Name {
+				out = append(out, ConstraintInfo{
+					Name:            r.Name,
+					Type:            constraintTypes[r.Type],
+					Condition:       r.Condition.String,
+					ReferencedTable: r.ReferencedTable.String,
+				})
+				if r.Type == "R" {
+					out[len(out)-1].DeleteRule = r.DeleteRule.String
+				}
+			}
+			c := &out[len(out)-1]
+			if r.Column.Valid {
+				c.Columns = append(c.Columns, r.Column.String)
+			}
+			if r.ReferencedColumn.Valid {
+				c.ReferencedColumns = append(c.ReferencedColumns, r.ReferencedColumn.String)
+			}
+		}
+		return nil
+	})
+
+	return out, err
+}
+
 func (m Migrator) CreateIndex(value interface{}, name string) error {
 	ns := getNS(m.DB, m.Dialector)
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		if idx := stmt.Schema.LookIndex(name); idx != nil {
+		if idx, _ := lookUpIndex(stmt.Schema, name); idx != nil {
 			domainCfg, err := parseOracleDomainIndexConfig(idx)
 			if err != nil {
 				return err
@@ -1112,7 +1737,7 @@ func (m Migrator) CreateIndex(value interface{}, name string) error {
 
 			if len(idx.Where) == 0 {
 				opts := m.DB.Migrator().(migrator.BuildIndexOptionsInterface).BuildIndexOptions(idx.Fields, stmt)
-				values := []interface{}{clause.Column{Name: ns.dictCasePart(idx.Name), Raw: true}, m.CurrentTable(stmt), opts}
+				values := []interface{}{indexNameColumn(ns, idx.Name), m.CurrentTable(stmt), opts}
 
 				createIndexSQL := buildCreateIndexSQL(idx, domainCfg)
 
@@ -1156,8 +1781,8 @@ func (m Migrator) CreateIndex(value interface{}, name string) error {
 				opt = fmt.Sprintf(" %s", idx.Option)
 			}
 
-			idxName := ns.dictCasePart(idx.Name)
-			stmtTable := m.namingStrategy.dictCasePart(stmt.Table)
+			idxName := indexNameColumn(ns, idx.Name).Name
+			stmtTable := ns.dictQualifiedName(stmt.Table)
 			str := fmt.Sprintf(`%sINDEX %s ON %s (%s) %s%s%s`, create, idxName, stmtTable, strings.Join(exprs, ","), using, comment, opt)
 
 			return m.DB.Exec(str).Error
@@ -1389,12 +2014,9 @@ func (m Migrator) DropIndex(value interface{}, name string) error {
 	ns := getNS(m.DB, m.Dialector)
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		// Normalize via schema (if defined), but still quote through Dialector.
-		if idx := stmt.Schema.LookIndex(name); idx != nil {
-			name = idx.Name
-		}
-		dictName := ns.dictCasePart(name)
+		_, name = lookUpIndex(stmt.Schema, name)
 
-		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: dictName, Raw: true}).Error
+		return m.DB.Exec("DROP INDEX ?", indexNameColumn(ns, name)).Error
 	})
 }
 
@@ -1404,15 +2026,27 @@ func (m Migrator) HasIndex(value interface{}, name string) bool {
 
 	var exists int
 	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		if idx := stmt.Schema.LookIndex(name); idx != nil {
-			name = idx.Name // trust parsed name
-		}
+		_, name = lookUpIndex(stmt.Schema, name) // trust parsed name
 
 		owner, tab, hasOwner := ns.dictQualifiedParts(stmt.Table)
 
 		// dictionary form of the name: unquoted -> UPPER(name), quoted -> exact inner
-		dictName := ns.dictCasePart(name)
-
+		idxOwner, dictName, idxHasOwner := ns.dictQualifiedParts(name)
+
+		if idxHasOwner {
+			// the index may live in another schema than its table
+			tableOwner := "SYS_CONTEXT('USERENV','CURRENT_SCHEMA')"
+			args := []interface{}{sql.Named("owner", idxOwner), sql.Named("idx", dictName), sql.Named("tab", tab)}
+			if hasOwner {
+				tableOwner = ":towner"
+				args = append(args, sql.Named("towner", owner))
+			}
+			return m.DB.Raw(
+				`SELECT 1 FROM ALL_INDEXES
+				  WHERE OWNER = :owner AND INDEX_NAME = :idx AND TABLE_NAME = :tab AND TABLE_OWNER = `+tableOwner+` AND ROWNUM = 1`,
+				args...,
+			).Scan(&exists).Error
+		}
 		if hasOwner {
 			return m.DB.Raw(
 				`SELECT 1 FROM ALL_INDEXES
@@ -1436,8 +2070,10 @@ func (m Migrator) HasIndex(value interface{}, name string) bool {
 func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		// Resolve from schema if present for determinism
-		if idx := stmt.Schema.LookIndex(oldName); idx != nil {
-			oldName = idx.Name
+		_, oldName = lookUpIndex(stmt.Schema, oldName)
+		// the index keeps its owner, RENAME TO takes the bare name
+		if parts := splitQualified(newName); len(parts) > 1 {
+			newName = parts[len(parts)-1]
 		}
 		// Build with placeholders so Dialector.QuoteTo is applied
 		return m.DB.Exec(
@@ -1448,12 +2084,61 @@ func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error
 	})
 }
 
+// lookUpIndex finds the schema index named name, which may be owner-qualified (OWNER.IDX_NAME) to
+// place an index of the schema in another owner's schema, returning it with its resolved name
+func lookUpIndex(sch *schema.Schema, name string) (*schema.Index, string) {
+	if idx := sch.LookIndex(name); idx != nil {
+		return idx, idx.Name
+	}
+	if parts := splitQualified(name); len(parts) == 2 {
+		if idx := sch.LookIndex(parts[1]); idx != nil {
+			qualified := *idx
+			qualified.Name = parts[0] + "." + idx.Name
+			return &qualified, qualified.Name
+		}
+	}
+	return nil, name
+}
+
+// indexNameColumn renders an index or constraint name, possibly owner-qualified, in dictionary case
+func indexNameColumn(ns *NamingStrategy, name string) clause.Column {
+	return clause.Column{Name: ns.dictQualifiedName(name), Raw: true}
+}
+
+// dictIdentifier quotes the parts of a name read from the data dictionary, which holds it in its exact case
+func dictIdentifier(parts ...string) clause.Column {
+	quoted := make([]string, len(parts))
+	for i, p := range parts {
+		quoted[i] = `"` + strings.ReplaceAll(p, `"`, `""`) + `"`
+	}
+	return clause.Column{Name: strings.Join(quoted, "."), Raw: true}
+}
+
 var onUpdateRe = regexp.MustCompile(`(?i)\s+ON\s+UPDATE\s+(NO\s+ACTION|RESTRICT|CASCADE|SET\s+NULL|SET\s+DEFAULT)`)
 
 func stripOnUpdate(s string) string {
 	return onUpdateRe.ReplaceAllString(s, "")
 }
 
+var onDeleteRe = regexp.MustCompile(`(?i)\s+ON\s+DELETE\s+(NO\s+ACTION|RESTRICT|SET\s+DEFAULT)`)
+
+// stripOnDelete removes the ON DELETE actions Oracle rejects; without one Oracle restricts deletes
+// of referenced rows, which is what NO ACTION and RESTRICT ask for.
+func stripOnDelete(s string) string {
+	return onDeleteRe.ReplaceAllString(s, "")
+}
+
+// normalizeOnDelete keeps the ON DELETE actions Oracle supports (CASCADE, SET NULL) and drops the rest.
+func normalizeOnDelete(action string) string {
+	switch strings.Join(strings.Fields(strings.ToUpper(action)), " ") {
+	case "CASCADE":
+		return "CASCADE"
+	case "SET NULL":
+		return "SET NULL"
+	}
+	return ""
+}
+
 // getNS returns the configured oracle NamingStrategy (pointer), regardless of how it was set.
 func getNS(db *gorm.DB, d gorm.Dialector) *NamingStrategy {
 	if od, ok := d.(*Dialector); ok && od.namingStrategy != nil {
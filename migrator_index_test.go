@@ -137,3 +137,69 @@ func mustLookIndex(t *testing.T, model interface{}, name string) *schema.Index {
 	require.NotNil(t, idx)
 	return idx
 }
+
+type testOwnerIndex struct {
+	ID    uint   `gorm:"primaryKey"`
+	Email string `gorm:"size:100;index:idx_owner_index_email"`
+}
+
+func (testOwnerIndex) TableName() string {
+	return "test_owner_index"
+}
+
+func Test_lookUpIndex(t *testing.T) {
+	sch, err := schema.Parse(&testOwnerIndex{}, &sync.Map{}, &NamingStrategy{IdentifierMaxLength: 30})
+	require.NoError(t, err)
+
+	idx, name := lookUpIndex(sch, "idx_owner_index_email")
+	require.NotNil(t, idx)
+	require.Equal(t, "idx_owner_index_email", name)
+
+	idx, name = lookUpIndex(sch, "app.idx_owner_index_email")
+	require.NotNil(t, idx)
+	require.Equal(t, "app.idx_owner_index_email", name)
+	require.Equal(t, "app.idx_owner_index_email", idx.Name)
+	require.Equal(t, "idx_owner_index_email", sch.LookIndex("idx_owner_index_email").Name, "expecting the schema index untouched")
+
+	idx, name = lookUpIndex(sch, "app.idx_missing")
+	require.Nil(t, idx)
+	require.Equal(t, "app.idx_missing", name)
+
+	ns := &NamingStrategy{PreferredCase: ScreamingSnakeCase, IdentifierMaxLength: 30}
+	require.Equal(t, "APP.IDX_OWNER_INDEX_EMAIL", indexNameColumn(ns, "app.idx_owner_index_email").Name)
+}
+
+func TestMigrator_OwnerQualifiedIndex(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	var owner string
+	require.NoError(t, db.Raw("SELECT USER FROM DUAL").Scan(&owner).Error)
+
+	m := db.Migrator()
+	_ = m.DropTable(&testOwnerIndex{})
+	require.NoError(t, m.CreateTable(&testOwnerIndex{}))
+
+	qualified := owner + ".idx_owner_index_email"
+	if m.HasIndex(&testOwnerIndex{}, qualified) {
+		require.NoError(t, m.DropIndex(&testOwnerIndex{}, qualified))
+	}
+	require.NoError(t, m.CreateIndex(&testOwnerIndex{}, qualified))
+	require.True(t, m.HasIndex(&testOwnerIndex{}, qualified), "expecting the index in %s's schema", owner)
+	require.False(t, m.HasIndex(&testOwnerIndex{}, "nobody.idx_owner_index_email"), "expecting no index in another schema")
+
+	var n int
+	require.NoError(t, db.Raw(`SELECT COUNT(*) FROM ALL_INDEXES WHERE OWNER = ? AND INDEX_NAME = 'IDX_OWNER_INDEX_EMAIL'`, strings.ToUpper(owner)).Scan(&n).Error)
+	require.Equal(t, 1, n)
+
+	require.NoError(t, m.RenameIndex(&testOwnerIndex{}, qualified, owner+".idx_owner_index_mail"))
+	require.True(t, m.HasIndex(&testOwnerIndex{}, owner+".idx_owner_index_mail"))
+	require.NoError(t, m.DropIndex(&testOwnerIndex{}, owner+".idx_owner_index_mail"))
+	require.False(t, m.HasIndex(&testOwnerIndex{}, owner+".idx_owner_index_mail"))
+}
@@ -1,13 +1,21 @@
 package oracle
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
 func TestMigrator_AutoMigrate(t *testing.T) {
@@ -246,6 +254,1264 @@ func TestMigrator_TableColumnType(t *testing.T) {
 	}
 }
 
+func TestDataTypeOf_UnboundedSize(t *testing.T) {
+	sch, err := schema.Parse(&testTableColumnTypeModel{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	field := sch.LookUpField("Remark")
+	require.NotNil(t, field)
+	require.Equal(t, -1, field.Size)
+
+	for _, cfg := range []struct {
+		config Config
+		want   string
+	}{
+		{Config{UseClobForTextType: true, DefaultStringSize: 1024}, "CLOB"},
+		{Config{UseClobForTextType: false, DefaultStringSize: 1024}, "VARCHAR2(4000)"},
+		{Config{UseClobForTextType: false, DefaultStringSize: 1024, VarcharSizeIsCharLength: true}, "VARCHAR2(4000)"},
+	} {
+		require.Equal(t, cfg.want, Dialector{Config: &cfg.config}.DataTypeOf(field), "%+v", cfg.config)
+	}
+}
+
+type testDefaultStringSizeModel struct {
+	ID   int64 `gorm:"primaryKey"`
+	Name string
+	Code string `gorm:"size:16"`
+}
+
+func (testDefaultStringSizeModel) TableName() string {
+	return "test_default_string_size"
+}
+
+func TestDataTypeOf_DefaultStringSize(t *testing.T) {
+	sch, err := schema.Parse(&testDefaultStringSizeModel{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	name, code := sch.LookUpField("Name"), sch.LookUpField("Code")
+
+	for _, cfg := range []struct {
+		config     Config
+		name, code string
+	}{
+		{Config{DefaultStringSize: 255}, "VARCHAR2(255)", "VARCHAR2(16)"},
+		{Config{DefaultStringSize: 255, VarcharSizeIsCharLength: true}, "VARCHAR2(255 CHAR)", "VARCHAR2(16 CHAR)"},
+		// 2000 characters may take up to 6000 bytes
+		{Config{DefaultStringSize: 2000, VarcharSizeIsCharLength: true}, "CLOB", "VARCHAR2(16 CHAR)"},
+		{Config{DefaultStringSize: 4000}, "VARCHAR2(4000)", "VARCHAR2(16)"},
+	} {
+		require.Equal(t, cfg.name, Dialector{Config: &cfg.config}.DataTypeOf(name), "%+v", cfg.config)
+		require.Equal(t, cfg.code, Dialector{Config: &cfg.config}.DataTypeOf(code), "%+v", cfg.config)
+	}
+}
+
+func TestMigrator_DefaultStringSize(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	require.EqualValues(t, 1024, db.Dialector.(*Dialector).DefaultStringSize, "expecting the default when unset")
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sized, err := gorm.Open(New(Config{Conn: sqlDB, DefaultStringSize: 255}), &gorm.Config{NamingStrategy: &NamingStrategy{}})
+	require.NoError(t, err)
+	require.EqualValues(t, 255, sized.Dialector.(*Dialector).DefaultStringSize, "expecting the configured size kept")
+
+	model := testDefaultStringSizeModel{}
+	_ = sized.Migrator().DropTable(model)
+	require.NoError(t, sized.Migrator().AutoMigrate(model))
+
+	columnTypes, err := sized.Migrator().ColumnTypes(model)
+	require.NoError(t, err)
+	lengths := map[string]int64{}
+	for _, ct := range columnTypes {
+		if length, ok := ct.Length(); ok {
+			lengths[strings.ToUpper(ct.Name())] = length
+		}
+	}
+	require.EqualValues(t, 255, lengths["NAME"], "expecting the configured default width")
+	require.EqualValues(t, 16, lengths["CODE"])
+}
+
+type testNationalStringModel struct {
+	ID      int64  `gorm:"primaryKey"`
+	Name    string `gorm:"type:nvarchar2;size:100"`
+	Title   string `gorm:"type:nvarchar2(50)"`
+	Summary string `gorm:"type:nvarchar2;size:1500"`
+	Body    string `gorm:"type:nclob"`
+}
+
+func (testNationalStringModel) TableName() string {
+	return "test_national_string"
+}
+
+func TestDataTypeOf_NationalStrings(t *testing.T) {
+	sch, err := schema.Parse(&testNationalStringModel{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+
+	// Long is Summary beyond the 2000 characters of an NVARCHAR2
+	long := *sch.LookUpField("Summary")
+	long.Size = 3000
+	fields := map[string]*schema.Field{"Long": &long}
+	for _, name := range []string{"Name", "Title", "Summary", "Body"} {
+		fields[name] = sch.LookUpField(name)
+	}
+	for _, cfg := range []struct {
+		config Config
+		want   map[string]string
+	}{
+		{Config{DefaultStringSize: 1024}, map[string]string{
+			"Name": "NVARCHAR2(100)", "Title": "nvarchar2(50)", "Summary": "NVARCHAR2(1500)", "Long": "NVARCHAR2(3000)", "Body": "NCLOB",
+		}},
+		{Config{DefaultStringSize: 1024, UseClobForTextType: true}, map[string]string{
+			"Name": "NVARCHAR2(100)", "Summary": "NVARCHAR2(1500)", "Long": "NCLOB", "Body": "NCLOB",
+		}},
+		{Config{DefaultStringSize: 1024, VarcharSizeIsCharLength: true}, map[string]string{
+			"Name": "NVARCHAR2(100)", "Summary": "NVARCHAR2(1500)", "Long": "NCLOB",
+		}},
+	} {
+		for name, want := range cfg.want {
+			require.Equal(t, want, Dialector{Config: &cfg.config}.DataTypeOf(fields[name]), "%s %+v", name, cfg.config)
+		}
+	}
+
+	// RETURNING INTO sizes the out bind of a sized type without a size tag from the type
+	match, err := stringTypeWithSize.FindStringMatch(strings.ToLower(string(sch.LookUpField("Title").DataType)))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	require.Equal(t, "50", match.GroupByNumber(1).String())
+}
+
+func TestMigrator_NationalStrings(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(testNationalStringModel)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+	require.NoError(t, db.AutoMigrate(model), "expecting re-migration without error")
+
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	require.NoError(t, err)
+	types := map[string]string{}
+	for _, ct := range columnTypes {
+		types[strings.ToUpper(ct.Name())] = ct.DatabaseTypeName()
+	}
+	require.Equal(t, "NVARCHAR2", types["NAME"])
+	require.Equal(t, "NCLOB", types["BODY"])
+
+	want := testNationalStringModel{ID: 1, Name: "東京タワー", Title: "서울", Summary: "Ελληνικά", Body: strings.Repeat("漢字", 3000)}
+	require.NoError(t, db.Create(&want).Error)
+	var got testNationalStringModel
+	require.NoError(t, db.First(&got, 1).Error)
+	require.Equal(t, want, got)
+}
+
+type testNumberPrecisionModel struct {
+	ID      int64   `gorm:"primaryKey"`
+	Amount  float64 `gorm:"type:numeric;precision:10;scale:2"`
+	Balance float64 `gorm:"precision:18;scale:4"`
+	Count   int64   `gorm:"type:number;precision:12"`
+	Ratio   float64
+}
+
+func (testNumberPrecisionModel) TableName() string {
+	return "test_number_precision"
+}
+
+func TestDataTypeOf_NumberPrecisionScale(t *testing.T) {
+	sch, err := schema.Parse(&testNumberPrecisionModel{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+
+	d := Dialector{Config: &Config{}}
+	for name, want := range map[string]string{
+		"Amount":  "NUMBER(10,2)",
+		"Balance": "NUMBER(18,4)",
+		"Count":   "NUMBER(12)",
+		"Ratio":   "FLOAT",
+	} {
+		field := sch.LookUpField(name)
+		require.NotNil(t, field, name)
+		require.Equal(t, want, d.DataTypeOf(field), name)
+	}
+}
+
+type testFloatColumn struct {
+	ID      int64 `gorm:"primaryKey"`
+	Balance float64
+}
+
+func (testFloatColumn) TableName() string {
+	return "test_float_to_number"
+}
+
+type testScaledFloatColumn struct {
+	ID      int64   `gorm:"primaryKey"`
+	Balance float64 `gorm:"type:decimal;precision:18;scale:2"`
+}
+
+func (testScaledFloatColumn) TableName() string {
+	return "test_float_to_number"
+}
+
+func TestMigrator_FloatToScaledNumber(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	balanceType := func(value interface{}) (string, int64, int64) {
+		columnTypes, err := db.Migrator().ColumnTypes(value)
+		require.NoError(t, err, "expecting no error")
+		for _, ct := range columnTypes {
+			if strings.EqualFold(ct.Name(), "BALANCE") {
+				precision, scale, _ := ct.DecimalSize()
+				return ct.DatabaseTypeName(), precision, scale
+			}
+		}
+		t.Fatal("expecting a BALANCE column")
+		return "", 0, 0
+	}
+
+	_ = db.Migrator().DropTable(&testFloatColumn{})
+	require.NoError(t, db.AutoMigrate(&testFloatColumn{}), "expecting no error")
+	typ, _, _ := balanceType(&testFloatColumn{})
+	require.Equal(t, "FLOAT", typ)
+
+	require.NoError(t, db.AutoMigrate(&testScaledFloatColumn{}), "expecting the FLOAT column altered")
+	typ, precision, scale := balanceType(&testScaledFloatColumn{})
+	require.Equal(t, "NUMBER", typ)
+	require.Equal(t, int64(18), precision)
+	require.Equal(t, int64(2), scale)
+
+	require.NoError(t, db.Create(&testScaledFloatColumn{ID: 1, Balance: 1234.567}).Error)
+	var got testScaledFloatColumn
+	require.NoError(t, db.First(&got, 1).Error)
+	require.Equal(t, 1234.57, got.Balance)
+}
+
+func TestMigrator_NumberPrecisionScale(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(testNumberPrecisionModel)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+	require.NoError(t, db.AutoMigrate(model), "expecting re-migration without change")
+
+	want := map[string][2]int64{
+		"Amount":  {10, 2},
+		"Balance": {18, 4},
+		"Count":   {12, 0},
+	}
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	require.NoError(t, err, "expecting no error")
+	stmt := &gorm.Statement{DB: db}
+	require.NoError(t, stmt.Parse(model))
+	for name, ps := range want {
+		field := stmt.Schema.LookUpField(name)
+		require.NotNil(t, field)
+		var found bool
+		for _, ct := range columnTypes {
+			if !strings.EqualFold(ct.Name(), field.DBName) {
+				continue
+			}
+			found = true
+			require.Equal(t, "NUMBER", ct.DatabaseTypeName(), name)
+			precision, scale, ok := ct.DecimalSize()
+			require.True(t, ok, name)
+			require.Equal(t, ps[0], precision, name)
+			require.Equal(t, ps[1], scale, name)
+		}
+		require.True(t, found, "expecting column for %s", name)
+	}
+
+	require.NoError(t, db.Create(&testNumberPrecisionModel{ID: 1, Amount: 12.345, Balance: 1.23456, Count: 7, Ratio: 0.5}).Error)
+	var got testNumberPrecisionModel
+	require.NoError(t, db.First(&got, 1).Error)
+	require.Equal(t, 12.35, got.Amount)
+	require.Equal(t, 1.2346, got.Balance)
+}
+
+func TestMigrator_ColumnTypesKeys(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(TestTableUserUnique)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	require.NoError(t, err, "expecting no error")
+	flags := map[string][3]bool{}
+	for _, ct := range columnTypes {
+		pk, ok := ct.PrimaryKey()
+		require.True(t, ok, ct.Name())
+		unique, ok := ct.Unique()
+		require.True(t, ok, ct.Name())
+		autoIncrement, ok := ct.AutoIncrement()
+		require.True(t, ok, ct.Name())
+		flags[strings.ToUpper(ct.Name())] = [3]bool{pk, unique, autoIncrement}
+	}
+	require.Equal(t, [3]bool{true, false, true}, flags["ID"], "expecting ID to be an identity primary key")
+	require.Equal(t, [3]bool{false, true, false}, flags["UID"], "expecting UID to be unique")
+	require.Equal(t, [3]bool{false, false, false}, flags["NAME"], "expecting NAME to be a plain column")
+}
+
+type testColumnMetadataModel struct {
+	ID     int64   `gorm:"primaryKey"`
+	Status string  `gorm:"size:20;not null;default:'pending';comment:order status"`
+	Amount float64 `gorm:"type:number(10,2)"`
+}
+
+func TestMigrator_ColumnTypesMetadata(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(testColumnMetadataModel)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	require.NoError(t, err, "expecting no error")
+	byName := map[string]gorm.ColumnType{}
+	for _, ct := range columnTypes {
+		byName[strings.ToUpper(ct.Name())] = ct
+	}
+
+	status, ok := byName["STATUS"]
+	require.True(t, ok, "expecting the STATUS column")
+	nullable, ok := status.Nullable()
+	require.True(t, ok)
+	require.False(t, nullable, "expecting STATUS to be not null")
+	def, ok := status.DefaultValue()
+	require.True(t, ok)
+	require.Equal(t, "'pending'", def)
+	comment, ok := status.Comment()
+	require.True(t, ok)
+	require.Equal(t, "order status", comment)
+	length, ok := status.Length()
+	require.True(t, ok)
+	require.EqualValues(t, 20, length)
+	columnType, ok := status.ColumnType()
+	require.True(t, ok)
+	require.Equal(t, "VARCHAR2(20)", columnType)
+
+	amount, ok := byName["AMOUNT"]
+	require.True(t, ok, "expecting the AMOUNT column")
+	nullable, ok = amount.Nullable()
+	require.True(t, ok)
+	require.True(t, nullable, "expecting AMOUNT to be nullable")
+	precision, scale, ok := amount.DecimalSize()
+	require.True(t, ok)
+	require.EqualValues(t, 10, precision)
+	require.EqualValues(t, 2, scale)
+	_, ok = amount.Comment()
+	require.False(t, ok, "expecting no comment on AMOUNT")
+	columnType, _ = amount.ColumnType()
+	require.Equal(t, "NUMBER(10,2)", columnType)
+}
+
+func TestMigrator_ColumnTypesEmptyTableViewAndSynonym(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(TestTableUserUnique)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+
+	columnNames := func(value interface{}) []string {
+		columnTypes, err := db.Migrator().ColumnTypes(value)
+		require.NoError(t, err, "expecting no error")
+		names := make([]string, 0, len(columnTypes))
+		for _, ct := range columnTypes {
+			names = append(names, strings.ToUpper(ct.Name()))
+		}
+		return names
+	}
+
+	columns := columnNames(model)
+	require.Contains(t, columns, "ID", "expecting the column types of an empty table")
+	require.Contains(t, columns, "UID")
+
+	stmt := &gorm.Statement{DB: db}
+	require.NoError(t, stmt.Parse(model))
+	require.NoError(t, db.Exec("CREATE OR REPLACE VIEW TEST_COLUMN_TYPES_VIEW AS SELECT * FROM "+stmt.Table).Error)
+	defer db.Exec("DROP VIEW TEST_COLUMN_TYPES_VIEW")
+	require.Equal(t, columns, columnNames("test_column_types_view"), "expecting the columns of the view")
+
+	if err := db.Exec("CREATE OR REPLACE SYNONYM TEST_COLUMN_TYPES_SYN FOR " + stmt.Table).Error; err != nil {
+		t.Logf("skipping synonym: %v", err)
+		return
+	}
+	defer db.Exec("DROP SYNONYM TEST_COLUMN_TYPES_SYN")
+	require.Equal(t, columns, columnNames("test_column_types_syn"), "expecting the columns of the synonym's table")
+}
+
+type testSequenceModel struct {
+	ID   uint `gorm:"primaryKey;sequence:TEST_SEQUENCE_MODEL_SEQ"`
+	Name string
+}
+
+func (testSequenceModel) TableName() string {
+	return "test_sequence_model"
+}
+
+type testAutoSequenceModel struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+func (testAutoSequenceModel) TableName() string {
+	return "test_auto_sequence_model"
+}
+
+func Test_sequenceName(t *testing.T) {
+	ns := &NamingStrategy{IdentifierMaxLength: 30}
+	tagged, err := schema.Parse(&testSequenceModel{}, &sync.Map{}, ns)
+	require.NoError(t, err)
+	auto, err := schema.Parse(&testAutoSequenceModel{}, &sync.Map{}, ns)
+	require.NoError(t, err)
+
+	require.Equal(t, "TEST_SEQUENCE_MODEL_SEQ", sequenceName(ns, false, tagged.PrioritizedPrimaryField))
+	require.Equal(t, "", sequenceName(ns, false, auto.PrioritizedPrimaryField), "expecting an identity column")
+	require.Equal(t, "SEQ_TEST_AUTO_SEQUENCE_MODEL_ID", sequenceName(&NamingStrategy{IdentifierMaxLength: 128}, true, auto.PrioritizedPrimaryField))
+	require.Equal(t, "", sequenceName(ns, true, auto.LookUpField("Name")), "expecting plain columns left alone")
+	require.Equal(t, "TRG_TEST_SEQUENCE_MODEL_ID", sequenceTriggerName(ns, tagged.PrioritizedPrimaryField))
+
+	require.Equal(t, "INTEGER", Dialector{Config: &Config{}}.DataTypeOf(tagged.PrioritizedPrimaryField))
+	require.Equal(t, "INTEGER GENERATED BY DEFAULT AS IDENTITY", Dialector{Config: &Config{}}.DataTypeOf(auto.PrioritizedPrimaryField))
+	require.Equal(t, "INTEGER", Dialector{Config: &Config{UseSequencesForAutoIncrement: true}}.DataTypeOf(auto.PrioritizedPrimaryField))
+}
+
+func Test_autoIncrementStrategy(t *testing.T) {
+	ns := &NamingStrategy{IdentifierMaxLength: 128}
+	auto, err := schema.Parse(&testAutoSequenceModel{}, &sync.Map{}, ns)
+	require.NoError(t, err)
+	const identity = "INTEGER GENERATED BY DEFAULT AS IDENTITY"
+
+	for _, tt := range []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"auto", Config{}, identity},
+		{"auto 19c", Config{DBVer: "19.3.0.0.0"}, identity},
+		{"auto 11g", Config{DBVer: "11.2.0.4.0"}, "INTEGER"},
+		{"auto with sequences", Config{DBVer: "19.3.0.0.0", UseSequencesForAutoIncrement: true}, "INTEGER"},
+		{"identity", Config{AutoIncrementStrategy: AutoIncrementIdentity, DBVer: "19.3.0.0.0"}, identity},
+		{"identity over sequences", Config{AutoIncrementStrategy: AutoIncrementIdentity, UseSequencesForAutoIncrement: true}, identity},
+		{"sequence trigger", Config{AutoIncrementStrategy: AutoIncrementSequenceTrigger, DBVer: "23.5.0.24.07"}, "INTEGER"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			require.Equal(t, tt.want, Dialector{Config: &cfg}.DataTypeOf(auto.PrioritizedPrimaryField))
+			seq := "SEQ_TEST_AUTO_SEQUENCE_MODEL_ID"
+			if tt.want == identity {
+				seq = ""
+			}
+			require.Equal(t, seq, sequenceName(ns, cfg.sequencesForAutoIncrement(), auto.PrioritizedPrimaryField))
+		})
+	}
+}
+
+func TestMigrator_Sequences(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	count := func(tx *gorm.DB, query string, args ...interface{}) (n int) {
+		require.NoError(t, tx.Raw(query, args...).Scan(&n).Error)
+		return
+	}
+	for _, tt := range []struct {
+		name  string
+		cfg   Config
+		model interface{}
+		table string
+		seq   string
+		rows  func() interface{}
+		ids   func(interface{}) []uint
+	}{
+		{
+			name: "tag", model: &testSequenceModel{}, table: "TEST_SEQUENCE_MODEL", seq: "TEST_SEQUENCE_MODEL_SEQ",
+			rows: func() interface{} { return &[]testSequenceModel{{Name: "a"}, {Name: "b"}} },
+			ids: func(v interface{}) (ids []uint) {
+				for _, r := range *v.(*[]testSequenceModel) {
+					ids = append(ids, r.ID)
+				}
+				return
+			},
+		},
+		{
+			name: "config", cfg: Config{UseSequencesForAutoIncrement: true}, model: &testAutoSequenceModel{}, table: "TEST_AUTO_SEQUENCE_MODEL", seq: "SEQ_TEST_AUTO_SEQUENCE_MODEL_ID",
+			rows: func() interface{} {
+				return &[]testAutoSequenceModel{{Name: "a"}, {Name: "b"}}
+			},
+			ids: func(v interface{}) (ids []uint) {
+				for _, r := range *v.(*[]testAutoSequenceModel) {
+					ids = append(ids, r.ID)
+				}
+				return
+			},
+		},
+		{
+			name: "strategy", cfg: Config{AutoIncrementStrategy: AutoIncrementSequenceTrigger}, model: &testAutoSequenceModel{}, table: "TEST_AUTO_SEQUENCE_MODEL", seq: "SEQ_TEST_AUTO_SEQUENCE_MODEL_ID",
+			rows: func() interface{} {
+				return &[]testAutoSequenceModel{{Name: "a"}, {Name: "b"}}
+			},
+			ids: func(v interface{}) (ids []uint) {
+				for _, r := range *v.(*[]testAutoSequenceModel) {
+					ids = append(ids, r.ID)
+				}
+				return
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Conn = sqlDB
+			tx, err := gorm.Open(New(cfg), &gorm.Config{NamingStrategy: &NamingStrategy{}})
+			require.NoError(t, err)
+
+			_ = tx.Migrator().DropTable(tt.model)
+			require.NoError(t, tx.AutoMigrate(tt.model), "expecting no error")
+			rec := newSQLRecorder()
+			require.NoError(t, tx.Session(&gorm.Session{Logger: rec}).AutoMigrate(tt.model), "expecting re-migration without error")
+			require.Empty(t, rec.matching("CREATE OR REPLACE TRIGGER"), "expecting the existing trigger left alone")
+			require.Equal(t, 1, count(tx, `SELECT COUNT(*) FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?`, tt.seq))
+
+			var trigger string
+			require.NoError(t, tx.Raw(`SELECT TRIGGER_NAME FROM USER_TRIGGERS WHERE TABLE_NAME = ?`, tt.table).Scan(&trigger).Error)
+			require.NoError(t, tx.Exec(`DROP TRIGGER "`+trigger+`"`).Error)
+			require.NoError(t, tx.AutoMigrate(tt.model), "expecting the dropped trigger recreated")
+			require.Equal(t, 1, count(tx, `SELECT COUNT(*) FROM USER_TRIGGERS WHERE TABLE_NAME = ?`, tt.table))
+			require.Zero(t, count(tx, `SELECT COUNT(*) FROM USER_TAB_IDENTITY_COLS WHERE TABLE_NAME = ?`, tt.table), "expecting no identity column")
+
+			before, err := tx.Migrator().(Migrator).CurrentSequenceValue(tt.model)
+			require.NoError(t, err)
+			rows := tt.rows()
+			require.NoError(t, tx.Create(rows).Error)
+			ids := tt.ids(rows)
+			after, err := tx.Migrator().(Migrator).CurrentSequenceValue(tt.model)
+			require.NoError(t, err)
+			require.Greater(t, after, before, "expecting the sequence value advanced")
+			require.Greater(t, after, int64(ids[1]), "expecting the value past the PKs handed out")
+			require.NotZero(t, ids[0], "expecting the PK populated from the sequence")
+			require.Equal(t, ids[0]+1, ids[1], "expecting the next value of the sequence")
+			require.NoError(t, tx.Table(tt.table).Create(map[string]interface{}{"ID": 100, "NAME": "c"}).Error)
+			require.Equal(t, 1, count(tx, `SELECT COUNT(*) FROM `+tt.table+` WHERE ID = 100`), "expecting an explicit PK kept")
+
+			require.NoError(t, tx.Migrator().DropTable(tt.model))
+			if tt.name == "tag" {
+				require.Equal(t, 1, count(tx, `SELECT COUNT(*) FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?`, tt.seq), "expecting a tagged sequence kept")
+				require.NoError(t, tx.Exec("DROP SEQUENCE "+tt.seq).Error)
+				return
+			}
+			require.Zero(t, count(tx, `SELECT COUNT(*) FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?`, tt.seq), "expecting the sequence dropped with the table")
+		})
+	}
+}
+
+func TestMigrator_CurrentSequenceValueIdentity(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := &testAutoSequenceModel{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+	defer db.Migrator().DropTable(model)
+
+	m := db.Migrator().(Migrator)
+	before, err := m.CurrentSequenceValue(model)
+	require.NoError(t, err, "expecting the identity sequence found")
+	rows := []testAutoSequenceModel{{Name: "a"}, {Name: "b"}}
+	require.NoError(t, db.Create(&rows).Error)
+	after, err := m.CurrentSequenceValue(model)
+	require.NoError(t, err)
+	require.Greater(t, after, before, "expecting the identity value advanced")
+	require.Greater(t, after, int64(rows[1].ID))
+
+	_, err = m.CurrentSequenceValue(&testFKChild{})
+	require.Error(t, err, "expecting an error without a table")
+}
+
+type testFKParent struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+func (testFKParent) TableName() string {
+	return "test_fk_parent"
+}
+
+type testFKChild struct {
+	ID       uint `gorm:"primaryKey"`
+	ParentID uint
+	Parent   testFKParent
+}
+
+func (testFKChild) TableName() string {
+	return "test_fk_child"
+}
+
+func TestMigrator_ForeignKeyIndexesWithoutConstraints(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	countDict := func(tx *gorm.DB, query string) (n int) {
+		require.NoError(t, tx.Raw(query).Scan(&n).Error)
+		return
+	}
+	const (
+		fkQuery  = `SELECT COUNT(*) FROM USER_CONSTRAINTS WHERE TABLE_NAME = 'TEST_FK_CHILD' AND CONSTRAINT_TYPE = 'R'`
+		idxQuery = `SELECT COUNT(*) FROM USER_IND_COLUMNS WHERE TABLE_NAME = 'TEST_FK_CHILD' AND COLUMN_NAME = 'PARENT_ID'`
+	)
+
+	for _, indexForeignKeys := range []bool{false, true} {
+		tx, err := gorm.Open(New(Config{Conn: sqlDB, IndexForeignKeys: indexForeignKeys}), &gorm.Config{
+			NamingStrategy:                           &NamingStrategy{},
+			DisableForeignKeyConstraintWhenMigrating: true,
+		})
+		require.NoError(t, err)
+
+		_ = tx.Migrator().DropTable(&testFKChild{}, &testFKParent{})
+		require.NoError(t, tx.AutoMigrate(&testFKParent{}, &testFKChild{}), "expecting no error")
+		require.NoError(t, tx.AutoMigrate(&testFKParent{}, &testFKChild{}), "expecting re-migration without error")
+
+		require.Zero(t, countDict(tx, fkQuery), "expecting no foreign key constraint")
+		if indexForeignKeys {
+			require.Equal(t, 1, countDict(tx, idxQuery), "expecting the foreign key column to be indexed")
+		} else {
+			require.Zero(t, countDict(tx, idxQuery), "expecting no foreign key index")
+		}
+	}
+}
+
+type testFKRestrictChild struct {
+	ID       uint `gorm:"primaryKey"`
+	ParentID uint
+	Parent   testFKParent `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+}
+
+func (testFKRestrictChild) TableName() string {
+	return "test_fk_restrict_child"
+}
+
+func Test_normalizeOnDelete(t *testing.T) {
+	for action, want := range map[string]string{
+		"CASCADE":     "CASCADE",
+		"set  null":   "SET NULL",
+		"RESTRICT":    "",
+		"NO ACTION":   "",
+		"SET DEFAULT": "",
+		"":            "",
+	} {
+		require.Equal(t, want, normalizeOnDelete(action), action)
+	}
+	require.Equal(t,
+		`CONSTRAINT "FK" FOREIGN KEY ("A") REFERENCES "T"("ID")`,
+		stripOnDelete(stripOnUpdate(`CONSTRAINT "FK" FOREIGN KEY ("A") REFERENCES "T"("ID") ON DELETE RESTRICT ON UPDATE CASCADE`)),
+	)
+}
+
+func TestMigrator_ForeignKeyOnDeleteRestrict(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	_ = db.Migrator().DropTable(&testFKRestrictChild{}, &testFKParent{})
+	require.NoError(t, db.AutoMigrate(&testFKParent{}, &testFKRestrictChild{}), "expecting valid DDL for ON DELETE RESTRICT")
+
+	var deleteRule string
+	require.NoError(t, db.Raw(`SELECT DELETE_RULE FROM USER_CONSTRAINTS WHERE TABLE_NAME = 'TEST_FK_RESTRICT_CHILD' AND CONSTRAINT_TYPE = 'R'`).Scan(&deleteRule).Error)
+	require.Equal(t, "NO ACTION", deleteRule)
+
+	require.NoError(t, db.Create(&testFKParent{ID: 1, Name: "parent"}).Error)
+	require.NoError(t, db.Create(&testFKRestrictChild{ID: 1, ParentID: 1}).Error)
+	require.Error(t, db.Delete(&testFKParent{ID: 1}).Error, "expecting the referenced parent to be protected")
+}
+
+type testFKCascadeChild struct {
+	ID       uint `gorm:"primaryKey"`
+	ParentID uint
+	Parent   testFKParent `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (testFKCascadeChild) TableName() string {
+	return "test_fk_cascade_child"
+}
+
+// testFKSetNullChild names its foreign key apart from testFKCascadeChild's, constraint names
+// being derived from the parent table and the column
+type testFKSetNullChild struct {
+	ID      uint `gorm:"primaryKey"`
+	OwnerID *uint
+	Owner   testFKParent `gorm:"foreignKey:OwnerID;constraint:OnDelete:SET NULL"`
+}
+
+func (testFKSetNullChild) TableName() string {
+	return "test_fk_set_null_child"
+}
+
+func TestMigrator_ForeignKeyOnDeleteActions(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	deleteRule := func(tx *gorm.DB, table string) (rule string) {
+		require.NoError(t, tx.Raw(`SELECT DELETE_RULE FROM USER_CONSTRAINTS WHERE TABLE_NAME = ? AND CONSTRAINT_TYPE = 'R'`, table).Scan(&rule).Error)
+		return
+	}
+
+	// inline in CREATE TABLE
+	_ = db.Migrator().DropTable(&testFKCascadeChild{}, &testFKSetNullChild{}, &testFKParent{})
+	require.NoError(t, db.AutoMigrate(&testFKParent{}, &testFKCascadeChild{}, &testFKSetNullChild{}), "expecting no error")
+	require.Equal(t, "CASCADE", deleteRule(db, "TEST_FK_CASCADE_CHILD"))
+	require.Equal(t, "SET NULL", deleteRule(db, "TEST_FK_SET_NULL_CHILD"))
+
+	parentID := uint(1)
+	require.NoError(t, db.Create(&testFKParent{ID: parentID, Name: "parent"}).Error)
+	require.NoError(t, db.Create(&testFKCascadeChild{ID: 1, ParentID: parentID}).Error)
+	require.NoError(t, db.Create(&testFKSetNullChild{ID: 1, OwnerID: &parentID}).Error)
+	require.NoError(t, db.Delete(&testFKParent{ID: parentID}).Error)
+
+	var n int64
+	require.NoError(t, db.Model(&testFKCascadeChild{}).Count(&n).Error)
+	require.Zero(t, n, "expecting the child rows removed with their parent")
+	var orphan testFKSetNullChild
+	require.NoError(t, db.First(&orphan, 1).Error)
+	require.Nil(t, orphan.OwnerID, "expecting the reference cleared")
+
+	// added to an existing table
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	tx, err := gorm.Open(New(Config{Conn: sqlDB}), &gorm.Config{NamingStrategy: &NamingStrategy{}, DisableForeignKeyConstraintWhenMigrating: true})
+	require.NoError(t, err)
+	_ = tx.Migrator().DropTable(&testFKCascadeChild{})
+	require.NoError(t, tx.AutoMigrate(&testFKCascadeChild{}))
+	require.Empty(t, deleteRule(tx, "TEST_FK_CASCADE_CHILD"), "expecting no constraint yet")
+
+	stmt := &gorm.Statement{DB: tx}
+	require.NoError(t, stmt.Parse(&testFKCascadeChild{}))
+	c := stmt.Schema.Relationships.Relations["Parent"].ParseConstraint()
+	require.NotNil(t, c)
+	require.NoError(t, tx.Migrator().CreateConstraint(&testFKCascadeChild{}, c.Name))
+	require.True(t, tx.Migrator().HasConstraint(&testFKCascadeChild{}, c.Name))
+	require.Equal(t, "CASCADE", deleteRule(tx, "TEST_FK_CASCADE_CHILD"))
+}
+
+type testCompositeParent struct {
+	Region string `gorm:"primaryKey;size:16"`
+	Code   int    `gorm:"primaryKey;autoIncrement:false"`
+}
+
+func (testCompositeParent) TableName() string {
+	return "test_composite_parent"
+}
+
+// testCompositeChild names its foreign-key columns so that sorting them would pair
+// A_CODE with REGION and Z_REGION with CODE.
+type testCompositeChild struct {
+	ID      uint   `gorm:"primaryKey"`
+	ZRegion string `gorm:"size:16"`
+	ACode   int
+	Parent  testCompositeParent `gorm:"foreignKey:ZRegion,ACode;references:Region,Code"`
+}
+
+func (testCompositeChild) TableName() string {
+	return "test_composite_child"
+}
+
+func TestMigrator_CompositeForeignKeyPairing(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	_ = db.Migrator().DropTable(&testCompositeChild{}, &testCompositeParent{})
+	require.NoError(t, db.AutoMigrate(&testCompositeParent{}, &testCompositeChild{}), "expecting no error")
+
+	type pair struct {
+		Column     string `gorm:"column:column_name"`
+		Referenced string `gorm:"column:referenced_column"`
+	}
+	var pairs []pair
+	require.NoError(t, db.Raw(`
+		SELECT fk.COLUMN_NAME, pk.COLUMN_NAME AS REFERENCED_COLUMN
+		  FROM USER_CONSTRAINTS c
+		  JOIN USER_CONS_COLUMNS fk ON fk.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+		  JOIN USER_CONS_COLUMNS pk ON pk.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME AND pk.POSITION = fk.POSITION
+		 WHERE c.TABLE_NAME = 'TEST_COMPOSITE_CHILD' AND c.CONSTRAINT_TYPE = 'R'
+		 ORDER BY fk.POSITION`).Scan(&pairs).Error)
+	require.Equal(t, []pair{{"Z_REGION", "REGION"}, {"A_CODE", "CODE"}}, pairs)
+
+	require.NoError(t, db.Create(&testCompositeParent{Region: "EU", Code: 7}).Error)
+	require.NoError(t, db.Create(&testCompositeChild{ID: 1, ZRegion: "EU", ACode: 7}).Error)
+	require.Error(t, db.Create(&testCompositeChild{ID: 2, ZRegion: "US", ACode: 7}).Error, "expecting the composite key to be enforced")
+}
+
+type testConstraintModel struct {
+	ID       uint   `gorm:"primaryKey"`
+	Age      int    `gorm:"check:chk_constraint_age,age > 0"`
+	Email    string `gorm:"size:128;unique"`
+	ParentID uint
+	Parent   testFKParent
+}
+
+func (testConstraintModel) TableName() string {
+	return "test_constraint_model"
+}
+
+func TestMigrator_GetConstraints(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	_ = db.Migrator().DropTable(&testConstraintModel{}, &testFKParent{})
+	require.NoError(t, db.AutoMigrate(&testFKParent{}, &testConstraintModel{}), "expecting no error")
+
+	constraints, err := db.Migrator().(Migrator).GetConstraints(&testConstraintModel{})
+	require.NoError(t, err, "expecting no error")
+
+	byType := map[string]ConstraintInfo{}
+	for _, c := range constraints {
+		require.NotContains(t, byType, c.Type, "expecting one %s constraint", c.Type)
+		byType[c.Type] = c
+	}
+	require.Len(t, byType, 4, "expecting only the declared constraints: %+v", constraints)
+
+	require.Equal(t, []string{"ID"}, byType["PRIMARY KEY"].Columns)
+	require.Equal(t, []string{"EMAIL"}, byType["UNIQUE"].Columns)
+	require.Contains(t, strings.ToUpper(byType["CHECK"].Condition), "AGE > 0")
+
+	fk := byType["FOREIGN KEY"]
+	require.Equal(t, []string{"PARENT_ID"}, fk.Columns)
+	require.Equal(t, "TEST_FK_PARENT", fk.ReferencedTable)
+	require.Equal(t, []string{"ID"}, fk.ReferencedColumns)
+	require.Equal(t, "NO ACTION", fk.DeleteRule)
+
+	// 11g has no SEARCH_CONDITION_VC, the LONG SEARCH_CONDITION is read instead
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(ver string) { cfg.DBVer = ver }(cfg.DBVer)
+	cfg.DBVer = "11.2.0.4.0"
+	legacy, err := db.Migrator().(Migrator).GetConstraints(&testConstraintModel{})
+	require.NoError(t, err, "expecting no error")
+	require.Equal(t, constraints, legacy)
+}
+
+type testDropColumnsModel struct {
+	ID    uint `gorm:"primaryKey"`
+	Name  string
+	Code  string `gorm:"size:16"`
+	Count int
+	Note  string
+}
+
+func (testDropColumnsModel) TableName() string {
+	return "test_drop_columns"
+}
+
+func TestMigrator_DropColumns(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(testDropColumnsModel)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+	require.NoError(t, db.Exec(`ALTER TABLE "TEST_DROP_COLUMNS" ADD CONSTRAINT "UK_TEST_DROP_COLUMNS" UNIQUE ("CODE", "COUNT")`).Error)
+
+	rec := newSQLRecorder()
+	require.NoError(t, db.Session(&gorm.Session{Logger: rec}).Migrator().(Migrator).DropColumns(model, "Code", "Count", "NOTE"))
+	require.Len(t, rec.matching("DROP ("), 1, "expecting a single multi-column drop")
+
+	for _, name := range []string{"Code", "Count", "Note"} {
+		require.False(t, db.Migrator().HasColumn(model, name), "expecting %s to be dropped", name)
+	}
+	require.True(t, db.Migrator().HasColumn(model, "Name"), "expecting Name to be kept")
+}
+
+type testDropColumnChecked struct {
+	ID   uint `gorm:"primaryKey"`
+	Low  int
+	High int    `gorm:"comment:upper bound"`
+	Code string `gorm:"size:16"`
+}
+
+func (testDropColumnChecked) TableName() string {
+	return "test_drop_column_checked"
+}
+
+func TestMigrator_DropColumnWithCheckConstraint(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(testDropColumnChecked)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+	require.NoError(t, db.Exec(`ALTER TABLE "TEST_DROP_COLUMN_CHECKED" ADD CONSTRAINT "CK_TEST_DROP_COLUMN_RANGE" CHECK ("LOW" <= "HIGH")`).Error)
+	require.NoError(t, db.Exec(`CREATE INDEX "IDX_TEST_DROP_COLUMN_CODE" ON "TEST_DROP_COLUMN_CHECKED" ("CODE")`).Error)
+
+	// dropping a column of a multi-column check constraint alone fails with ORA-12991
+	require.NoError(t, db.Migrator().DropColumn(model, "High"), "expecting the check constraint dropped first")
+	require.False(t, db.Migrator().HasColumn(model, "High"))
+	require.False(t, db.Migrator().HasConstraint(model, "CK_TEST_DROP_COLUMN_RANGE"))
+	require.True(t, db.Migrator().HasColumn(model, "Low"), "expecting Low to be kept")
+
+	var comments int
+	require.NoError(t, db.Raw(`SELECT COUNT(*) FROM USER_COL_COMMENTS WHERE TABLE_NAME = 'TEST_DROP_COLUMN_CHECKED' AND COLUMN_NAME = 'HIGH'`).Scan(&comments).Error)
+	require.Zero(t, comments, "expecting the comment gone with the column")
+
+	require.NoError(t, db.Migrator().DropColumn(model, "Code"))
+	require.False(t, db.Migrator().HasIndex(model, "IDX_TEST_DROP_COLUMN_CODE"))
+}
+
+// sqlRecorder records every statement traced by gorm.
+type sqlRecorder struct {
+	logger.Interface
+	mu   sync.Mutex
+	sqls []string
+}
+
+func newSQLRecorder() *sqlRecorder {
+	return &sqlRecorder{Interface: logger.Default.LogMode(logger.Silent)}
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sqls = append(r.sqls, sql)
+}
+
+// matching returns the recorded statements containing substr, case-insensitively.
+func (r *sqlRecorder) matching(substr string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, sql := range r.sqls {
+		if strings.Contains(strings.ToUpper(sql), strings.ToUpper(substr)) {
+			out = append(out, sql)
+		}
+	}
+	return out
+}
+
+type testSmallIntModel struct {
+	ID     uint64 `gorm:"primaryKey"`
+	Small  int16
+	Tiny   uint8
+	Normal int32
+	Big    int64 `gorm:"not null;default:0"`
+}
+
+func (testSmallIntModel) TableName() string {
+	return "test_small_int"
+}
+
+func TestMigrator_SmallIntNoAlter(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(testSmallIntModel)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+
+	rec := newSQLRecorder()
+	require.NoError(t, db.Session(&gorm.Session{Logger: rec}).AutoMigrate(model), "expecting no error")
+	require.Empty(t, rec.matching("ALTER TABLE"), "expecting re-migration to issue no ALTER")
+}
+
+type testFloatModel struct {
+	ID     uint64 `gorm:"primaryKey"`
+	Single float32
+	Double float64
+	Ptr    *float64
+}
+
+func (testFloatModel) TableName() string {
+	return "test_float"
+}
+
+func TestMigrator_FloatNoAlter(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(testFloatModel)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+
+	for i := 0; i < 2; i++ {
+		rec := newSQLRecorder()
+		require.NoError(t, db.Session(&gorm.Session{Logger: rec}).AutoMigrate(model), "expecting no error")
+		require.Empty(t, rec.matching("ALTER TABLE"), "expecting re-migration %d to issue no ALTER", i+1)
+	}
+}
+
+func Test_sameColumnType(t *testing.T) {
+	number := func(precision, scale int64) dictColumn {
+		return dictColumn{DataType: "NUMBER", Precision: sql.NullInt64{Int64: precision, Valid: precision > 0}, Scale: sql.NullInt64{Int64: scale, Valid: true}}
+	}
+	text := func(dataType string, length int64, used string) dictColumn {
+		return dictColumn{DataType: dataType, CharLength: sql.NullInt64{Int64: length, Valid: true}, CharUsed: sql.NullString{String: used, Valid: true}}
+	}
+	timestamp := func(dataType string, precision int64) dictColumn {
+		return dictColumn{DataType: dataType, Scale: sql.NullInt64{Int64: precision, Valid: true}}
+	}
+
+	for _, tt := range []struct {
+		target string
+		cur    dictColumn
+		want   bool
+	}{
+		{"INTEGER GENERATED BY DEFAULT AS IDENTITY", number(0, 0), true},
+		{"NUMBER(1)", number(1, 0), true},
+		{"NUMBER(1)", number(10, 0), false},
+		{"VARCHAR2(50)", text("VARCHAR2", 50, "B"), true},
+		{"VARCHAR2(50 CHAR)", text("VARCHAR2", 50, "C"), true},
+		{"VARCHAR2(50 CHAR)", text("VARCHAR2", 50, "B"), false},
+		{"VARCHAR2(64)", text("VARCHAR2", 50, "B"), false},
+		{"VARCHAR2(50)", text("CHAR", 50, "B"), false},
+		{"char", text("CHAR", 1, "B"), true},
+		{"NVARCHAR2(20)", text("NVARCHAR2", 20, "C"), true},
+		{"TIMESTAMP WITH TIME ZONE", timestamp("TIMESTAMP(6) WITH TIME ZONE", 6), true},
+		{"TIMESTAMP(3) WITH TIME ZONE", timestamp("TIMESTAMP(3) WITH TIME ZONE", 3), true},
+		{"TIMESTAMP(3) WITH TIME ZONE", timestamp("TIMESTAMP(6) WITH TIME ZONE", 6), false},
+		{"TIMESTAMP WITH LOCAL TIME ZONE", timestamp("TIMESTAMP(6) WITH LOCAL TIME ZONE", 6), true},
+		{"TIMESTAMP", timestamp("TIMESTAMP(6)", 6), true},
+		{"TIMESTAMP", timestamp("DATE", 0), false},
+		{"DATE", dictColumn{DataType: "DATE"}, true},
+		{"BOOLEAN", dictColumn{DataType: "BOOLEAN"}, true},
+		{"BINARY_DOUBLE", dictColumn{DataType: "BINARY_DOUBLE"}, true},
+		{"BINARY_FLOAT", dictColumn{DataType: "BINARY_DOUBLE"}, false},
+		{"BINARY_DOUBLE", dictColumn{DataType: "FLOAT", Precision: sql.NullInt64{Int64: 126, Valid: true}}, false},
+		{"FLOAT", dictColumn{DataType: "BINARY_DOUBLE"}, false},
+	} {
+		require.Equal(t, tt.want, Migrator{}.sameColumnType(tt.target, tt.cur), "%s vs %+v", tt.target, tt.cur)
+	}
+}
+
+func TestMigrator_IdentityNoAlter(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := new(TestTableUser)
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.AutoMigrate(model), "expecting no error")
+
+	for i := 0; i < 2; i++ {
+		rec := newSQLRecorder()
+		require.NoError(t, db.Session(&gorm.Session{Logger: rec}).AutoMigrate(model), "expecting re-migration %d to skip the identity column", i+1)
+		require.Empty(t, rec.matching("ALTER TABLE"), "expecting re-migration %d to issue no ALTER", i+1)
+		require.Empty(t, rec.matching("CREATE "), "expecting re-migration %d to issue no CREATE", i+1)
+	}
+}
+
+func Test_matViewClauses(t *testing.T) {
+	require.Equal(t, "BUILD IMMEDIATE REFRESH FORCE ON DEMAND", matViewClauses(nil))
+	require.Equal(t, "BUILD DEFERRED REFRESH FAST ON COMMIT", matViewClauses([]MatViewOption{MatViewRefresh(RefreshFast), MatViewOnCommit(), MatViewBuildDeferred()}))
+	require.Equal(t, "BUILD IMMEDIATE REFRESH COMPLETE ON DEMAND", matViewClauses([]MatViewOption{MatViewRefresh("complete")}))
+}
+
+func TestMigrator_MaterializedView(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	const view = "mv_test_user_types"
+	m := db.Migrator().(Migrator)
+	_ = m.DropMaterializedView(view)
+	_ = db.Migrator().DropTable(&TestTableUser{})
+	require.NoError(t, db.AutoMigrate(&TestTableUser{}), "expecting no error")
+	require.NoError(t, db.Create(&[]TestTableUser{{Name: "a", UserType: 1}, {Name: "b", UserType: 1}, {Name: "c", UserType: 2}}).Error)
+
+	query := db.Model(&TestTableUser{}).Select("user_type, COUNT(*) AS users").Where("name <> ?", "it's").Group("user_type")
+	require.NoError(t, m.CreateMaterializedView(view, query, MatViewRefresh(RefreshComplete)), "expecting no error")
+	require.True(t, m.HasMaterializedView(view))
+
+	var mview struct {
+		RefreshMethod string `gorm:"column:refresh_method"`
+		RefreshMode   string `gorm:"column:refresh_mode"`
+		BuildMode     string `gorm:"column:build_mode"`
+	}
+	require.NoError(t, db.Raw("SELECT REFRESH_METHOD, REFRESH_MODE, BUILD_MODE FROM USER_MVIEWS WHERE MVIEW_NAME = ?", "MV_TEST_USER_TYPES").Scan(&mview).Error)
+	require.Equal(t, "COMPLETE", mview.RefreshMethod)
+	require.Equal(t, "DEMAND", mview.RefreshMode)
+	require.Equal(t, "IMMEDIATE", mview.BuildMode)
+
+	var users []int
+	require.NoError(t, db.Table(view).Order("user_type").Pluck("users", &users).Error)
+	require.Equal(t, []int{2, 1}, users)
+
+	require.NoError(t, db.Create(&TestTableUser{Name: "d", UserType: 2}).Error)
+	require.NoError(t, m.RefreshMaterializedView(view, RefreshComplete), "expecting no error")
+	require.NoError(t, db.Table(view).Order("user_type").Pluck("users", &users).Error)
+	require.Equal(t, []int{2, 2}, users)
+
+	require.NoError(t, m.DropMaterializedView(view), "expecting no error")
+	require.False(t, m.HasMaterializedView(view))
+}
+
+type testUserTypeView struct {
+	Name     string
+	UserType int
+}
+
+func (testUserTypeView) TableName() string {
+	return "v_test_user_types"
+}
+
+func TestMigrator_View(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	view := testUserTypeView{}.TableName()
+	m := db.Migrator().(Migrator)
+	_ = m.DropView(view)
+	_ = db.Migrator().DropTable(&TestTableUser{})
+	require.NoError(t, db.AutoMigrate(&TestTableUser{}), "expecting no error")
+	require.NoError(t, db.Create(&[]TestTableUser{{Name: "a", UserType: 1}, {Name: "it's", UserType: 1}, {Name: "c", UserType: 2}}).Error)
+
+	query := db.Model(&TestTableUser{}).Select("name, user_type").Where("name <> ?", "it's")
+	require.NoError(t, m.CreateView(view, gorm.ViewOption{Query: query, CheckOption: "WITH READ ONLY"}), "expecting no error")
+	require.True(t, m.HasView(view))
+	require.Error(t, m.CreateView(view, gorm.ViewOption{Query: query}), "expecting the view to exist")
+
+	var rows []testUserTypeView
+	require.NoError(t, db.Order("name").Find(&rows).Error)
+	require.Equal(t, []testUserTypeView{{Name: "a", UserType: 1}, {Name: "c", UserType: 2}}, rows)
+
+	require.NoError(t, m.ReplaceView(view, db.Model(&TestTableUser{}).Select("name, user_type").Where("user_type = ?", 1)), "expecting no error")
+	require.NoError(t, db.Order("name").Find(&rows).Error)
+	require.Equal(t, []testUserTypeView{{Name: "a", UserType: 1}, {Name: "it's", UserType: 1}}, rows)
+
+	require.NoError(t, m.DropView(view), "expecting no error")
+	require.False(t, m.HasView(view))
+}
+
 type testFieldNameIsReservedWord struct {
 	ID int64 `gorm:"size:64;not null;autoIncrement:true;autoIncrementIncrement:1;primaryKey"`
 
@@ -314,6 +1580,56 @@ func TestMigrator_FieldNameIsReservedWord(t *testing.T) {
 	}
 }
 
+func TestMigrator_ConcurrentReservedWordMigrations(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	require.NoError(t, stmt.Parse(&testFieldNameIsReservedWord{}))
+	dbNames := append([]string(nil), stmt.Schema.DBNames...)
+
+	tables := []string{"test_reserved_word_a", "test_reserved_word_b"}
+	for _, table := range tables {
+		_ = db.Migrator().DropTable(table)
+	}
+
+	// both migrations share the cached schema of the model; reserved words are quoted while
+	// building each statement, never by renaming the shared fields
+	var wg sync.WaitGroup
+	errs := make([]error, len(tables))
+	for i, table := range tables {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 2 && errs[i] == nil; n++ {
+				errs[i] = db.Table(table).AutoMigrate(&testFieldNameIsReservedWord{})
+			}
+		}()
+	}
+	wg.Wait()
+	for i, table := range tables {
+		require.NoError(t, errs[i], "expecting no error migrating %s", table)
+		require.True(t, db.Table(table).Migrator().HasColumn(&testFieldNameIsReservedWord{}, "DESC"), "expecting the reserved column on %s", table)
+	}
+
+	require.NoError(t, stmt.Parse(&testFieldNameIsReservedWord{}))
+	require.Equal(t, dbNames, stmt.Schema.DBNames, "expecting the shared schema names untouched")
+	for _, dbName := range dbNames {
+		require.NotContains(t, dbName, `"`, "expecting no quoted names in the shared schema")
+		require.Contains(t, stmt.Schema.FieldsByDBName, dbName)
+	}
+
+	for _, table := range tables {
+		_ = db.Migrator().DropTable(table)
+	}
+}
+
 func TestMigrator_DatatypesJsonMapNamingCase(t *testing.T) {
 	if err := dbErrors[0]; err != nil {
 		t.Fatal(err)
@@ -397,3 +1713,234 @@ func TestMigrator_DatatypesJsonMapIgnoreCase(t *testing.T) {
 		})
 	}
 }
+
+type warnCapture struct {
+	logger.Interface
+	mu    sync.Mutex
+	warns []string
+}
+
+func (c *warnCapture) LogMode(logger.LogLevel) logger.Interface { return c }
+
+func (c *warnCapture) Warn(_ context.Context, msg string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warns = append(c.warns, fmt.Sprintf(msg, args...))
+}
+
+func Test_slowDDLLogger(t *testing.T) {
+	capture := &warnCapture{Interface: logger.Discard}
+	l := (&slowDDLLogger{Interface: capture, threshold: time.Second}).LogMode(logger.Info)
+	trace := func(sql string, elapsed time.Duration) {
+		l.Trace(context.Background(), time.Now().Add(-elapsed), func() (string, int64) { return sql, 0 }, nil)
+	}
+	trace("CREATE INDEX IDX_USERS_NAME ON USERS(NAME)", 2*time.Second)
+	trace("  alter table USERS ADD AGE NUMBER", time.Millisecond)
+	trace("SELECT COUNT(*) FROM USER_INDEXES", 2*time.Second)
+
+	require.Len(t, capture.warns, 1, "expecting only the slow DDL statement to warn")
+	require.Contains(t, capture.warns[0], "oracle: slow DDL took")
+	require.Contains(t, capture.warns[0], "CREATE INDEX IDX_USERS_NAME ON USERS(NAME)")
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(threshold time.Duration) { cfg.SlowDDLThreshold = threshold }(cfg.SlowDDLThreshold)
+	cfg.SlowDDLThreshold = time.Nanosecond
+
+	capture.warns = nil
+	tx := db.Session(&gorm.Session{Logger: capture})
+	_ = tx.Migrator().DropTable(&testSmallIntModel{})
+	require.NoError(t, tx.Migrator().AutoMigrate(&testSmallIntModel{}))
+	require.NotEmpty(t, capture.warns, "expecting the CREATE TABLE to exceed a 1ns threshold")
+	for _, warn := range capture.warns {
+		require.Contains(t, warn, "oracle: slow DDL took")
+	}
+}
+
+func Test_tablespaceIdentifier(t *testing.T) {
+	require.Equal(t, clause.Column{Name: `"USERS_DATA"`, Raw: true}, tablespaceIdentifier(" users_data "))
+	require.Equal(t, clause.Column{Name: `"Users_Data"`, Raw: true}, tablespaceIdentifier(`"Users_Data"`))
+}
+
+func TestMigrator_MoveTable(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	m := db.Migrator().(Migrator)
+	require.Error(t, m.MoveTable(&TestTableTenantCode{}, " "), "expecting a tablespace to be required")
+
+	_ = m.DropTable(&TestTableTenantCode{})
+	require.NoError(t, m.AutoMigrate(&TestTableTenantCode{}), "expecting no error")
+	require.NoError(t, db.Create(&[]TestTableTenantCode{{Tenant: "t1", Code: "a"}, {Tenant: "t1", Code: "b"}}).Error)
+
+	// moving within its own tablespace still rebuilds the segment, and invalidates the indexes
+	var tablespace string
+	require.NoError(t, db.Raw(`SELECT TABLESPACE_NAME FROM USER_TABLES WHERE TABLE_NAME = 'TEST_TENANT_CODE'`).Scan(&tablespace).Error)
+	require.NotEmpty(t, tablespace)
+	require.NoError(t, m.MoveTable(&TestTableTenantCode{}, tablespace), "expecting no error")
+
+	var statuses []string
+	require.NoError(t, db.Raw(`SELECT STATUS FROM USER_INDEXES WHERE TABLE_NAME = 'TEST_TENANT_CODE'`).Scan(&statuses).Error)
+	require.NotEmpty(t, statuses)
+	for _, status := range statuses {
+		require.Equal(t, "VALID", status, "expecting every index rebuilt")
+	}
+
+	// the unique index is enforced again
+	require.Error(t, db.Create(&TestTableTenantCode{Tenant: "t1", Code: "a"}).Error, "expecting uk_tenant_code to reject the duplicate")
+	var count int64
+	require.NoError(t, db.Model(&TestTableTenantCode{}).Where("tenant = ? AND code = ?", "t1", "b").Count(&count).Error)
+	require.EqualValues(t, 1, count)
+}
+
+func TestMigrator_RebuildIndexes(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	m := db.Migrator().(Migrator)
+	_ = m.DropTable(&TestTableTenantCode{})
+	require.NoError(t, m.AutoMigrate(&TestTableTenantCode{}), "expecting no error")
+	require.NoError(t, m.RebuildIndexes(&TestTableTenantCode{}), "expecting nothing to rebuild")
+
+	status := func() string {
+		var s string
+		require.NoError(t, db.Raw(`SELECT STATUS FROM USER_INDEXES WHERE INDEX_NAME = 'UK_TENANT_CODE'`).Scan(&s).Error)
+		return s
+	}
+	require.NoError(t, db.Exec(`ALTER INDEX UK_TENANT_CODE UNUSABLE`).Error)
+	require.Equal(t, "UNUSABLE", status())
+	require.Error(t, db.Create(&TestTableTenantCode{Tenant: "t1", Code: "a"}).Error, "expecting the unusable unique index to reject DML")
+
+	require.NoError(t, m.RebuildIndexes(&TestTableTenantCode{}), "expecting no error")
+	require.Equal(t, "VALID", status())
+	require.NoError(t, db.Create(&TestTableTenantCode{Tenant: "t1", Code: "a"}).Error)
+}
+
+func TestMigrator_GatherStats(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	m := db.Migrator().(Migrator)
+	_ = m.DropTable(&TestTableTenantCode{})
+	require.NoError(t, m.AutoMigrate(&TestTableTenantCode{}), "expecting no error")
+	require.NoError(t, db.Create(&[]TestTableTenantCode{{Tenant: "t1", Code: "a"}, {Tenant: "t1", Code: "b"}}).Error)
+	require.NoError(t, db.Exec(`BEGIN DBMS_STATS.DELETE_TABLE_STATS(USER, 'TEST_TENANT_CODE'); END;`).Error)
+
+	var stats struct {
+		LastAnalyzed *time.Time
+		NumRows      *int64
+	}
+	query := `SELECT LAST_ANALYZED, NUM_ROWS FROM USER_TABLES WHERE TABLE_NAME = 'TEST_TENANT_CODE'`
+	require.NoError(t, db.Raw(query).Scan(&stats).Error)
+	require.Nil(t, stats.LastAnalyzed, "expecting no statistics yet")
+
+	require.NoError(t, m.GatherStats(&TestTableTenantCode{}), "expecting no error")
+	require.NoError(t, db.Raw(query).Scan(&stats).Error)
+	require.NotNil(t, stats.LastAnalyzed, "expecting LAST_ANALYZED set")
+	require.NotNil(t, stats.NumRows)
+	require.EqualValues(t, 2, *stats.NumRows)
+}
+
+type testForceQuoted struct {
+	ID   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name string `gorm:"size:50;index:idx_ForceQuoted_name"`
+}
+
+func (testForceQuoted) TableName() string {
+	return "ForceQuoted"
+}
+
+func Test_forceQuoteIdentifiers(t *testing.T) {
+	plain := &NamingStrategy{}
+	forced := &NamingStrategy{ForceQuoteIdentifiers: true}
+
+	require.Equal(t, "FORCE_QUOTED", plain.normalizeQualified("ForceQuoted"))
+	require.Equal(t, `"ForceQuoted"`, forced.normalizeQualified("ForceQuoted"))
+	require.Equal(t, `"app"."Orders"`, forced.normalizeQualified("app.Orders"))
+	require.Equal(t, `"Weird"`, forced.normalizeQualified(`"Weird"`))
+
+	// names derived from the model are cased, then quoted
+	require.Equal(t, "TEST_USERS", plain.TableName("TestUser"))
+	require.Equal(t, `"TEST_USERS"`, forced.TableName("TestUser"))
+	require.Equal(t, "NAME", forced.ColumnName("", "Name"))
+
+	// the dictionary holds the forced names exactly as written
+	require.Equal(t, "FORCEQUOTED", plain.dictCasePart("ForceQuoted"))
+	require.Equal(t, "ForceQuoted", forced.dictCasePart("ForceQuoted"))
+	owner, table, _ := forced.dictQualifiedParts("app.Orders")
+	require.Equal(t, []string{"app", "Orders"}, []string{owner, table})
+	require.Equal(t, "IDX_FORCEQUOTED_NAME", plain.dictQualifiedName("idx_ForceQuoted_name"))
+	require.Equal(t, `"idx_ForceQuoted_name"`, forced.dictQualifiedName("idx_ForceQuoted_name"))
+}
+
+func TestMigrator_ForceQuoteIdentifiers(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	plainDB, err := gorm.Open(New(Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	forcedDB, err := gorm.Open(New(Config{Conn: sqlDB, ForceQuoteIdentifiers: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	createSQL := func(db *gorm.DB) []string {
+		rec := newSQLRecorder()
+		_ = db.Session(&gorm.Session{DryRun: true, Logger: rec}).Migrator().CreateTable(&testForceQuoted{})
+		return rec.matching("CREATE ")
+	}
+	require.Equal(t, []string{
+		`CREATE TABLE FORCE_QUOTED (ID INTEGER,NAME VARCHAR2(50),CONSTRAINT UK_FORCEQUOTED_ID PRIMARY KEY (ID))`,
+		`CREATE INDEX IDX_FORCEQUOTED_NAME ON FORCE_QUOTED (NAME)`,
+	}, createSQL(plainDB))
+	require.Equal(t, []string{
+		`CREATE TABLE "ForceQuoted" ("ID" INTEGER,"NAME" VARCHAR2(50),CONSTRAINT "UK_FORCE_QUOTED_ID" PRIMARY KEY ("ID"))`,
+		`CREATE INDEX "idx_ForceQuoted_name" ON "ForceQuoted" ("NAME")`,
+	}, createSQL(forcedDB))
+
+	m := forcedDB.Migrator()
+	_ = m.DropTable(&testForceQuoted{})
+	require.NoError(t, m.AutoMigrate(&testForceQuoted{}), "expecting no error")
+	require.True(t, m.HasTable(&testForceQuoted{}))
+	require.True(t, m.HasColumn(&testForceQuoted{}, "Name"))
+	require.True(t, m.HasIndex(&testForceQuoted{}, "idx_ForceQuoted_name"))
+	require.NoError(t, m.AutoMigrate(&testForceQuoted{}), "expecting re-migration to find the quoted table")
+
+	var table string
+	require.NoError(t, db.Raw(`SELECT TABLE_NAME FROM USER_TABLES WHERE TABLE_NAME = 'ForceQuoted'`).Scan(&table).Error)
+	require.Equal(t, "ForceQuoted", table)
+	require.NoError(t, forcedDB.Create(&testForceQuoted{ID: 1, Name: "a"}).Error)
+	require.NoError(t, m.DropTable(&testForceQuoted{}))
+	require.False(t, m.HasTable(&testForceQuoted{}))
+}
@@ -0,0 +1,147 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// RefreshMethod is how a materialized view is refreshed, see MatViewRefresh
+type RefreshMethod string
+
+const (
+	// RefreshForce refreshes fast when possible and completely otherwise, Oracle's default
+	RefreshForce RefreshMethod = "FORCE"
+	// RefreshFast applies the changes recorded in the materialized view logs of the base tables
+	RefreshFast RefreshMethod = "FAST"
+	// RefreshComplete re-executes the query of the materialized view
+	RefreshComplete RefreshMethod = "COMPLETE"
+)
+
+// MatViewOption configures a materialized view created by Migrator.CreateMaterializedView
+type MatViewOption func(*matViewOptions)
+
+type matViewOptions struct {
+	refresh  RefreshMethod
+	onCommit bool
+	deferred bool
+}
+
+// MatViewRefresh sets the refresh method of the materialized view, RefreshForce by default
+//
+//goland:noinspection GoUnusedExportedFunction
+func MatViewRefresh(method RefreshMethod) MatViewOption {
+	return func(o *matViewOptions) { o.refresh = method }
+}
+
+// MatViewOnCommit refreshes the materialized view as transactions on its base tables commit,
+// rather than on demand through Migrator.RefreshMaterializedView
+//
+//goland:noinspection GoUnusedExportedFunction
+func MatViewOnCommit() MatViewOption {
+	return func(o *matViewOptions) { o.onCommit = true }
+}
+
+// MatViewBuildDeferred creates the materialized view empty, to be populated by its first refresh,
+// rather than populating it immediately
+//
+//goland:noinspection GoUnusedExportedFunction
+func MatViewBuildDeferred() MatViewOption {
+	return func(o *matViewOptions) { o.deferred = true }
+}
+
+// matViewClauses renders the BUILD and REFRESH clauses of opts
+func matViewClauses(opts []MatViewOption) string {
+	o := matViewOptions{refresh: RefreshForce}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var b strings.Builder
+	if o.deferred {
+		b.WriteString("BUILD DEFERRED")
+	} else {
+		b.WriteString("BUILD IMMEDIATE")
+	}
+	b.WriteString(" REFRESH ")
+	b.WriteString(strings.ToUpper(string(o.refresh)))
+	if o.onCommit {
+		b.WriteString(" ON COMMIT")
+	} else {
+		b.WriteString(" ON DEMAND")
+	}
+	return b.String()
+}
+
+// CreateMaterializedView creates the materialized view name, possibly owner-qualified, over query:
+//
+//	db.Migrator().(oracle.Migrator).CreateMaterializedView("mv_sales", db.Model(&Sale{}).Select("region, SUM(amount) total").Group("region"),
+//		oracle.MatViewRefresh(oracle.RefreshComplete))
+//	// CREATE MATERIALIZED VIEW MV_SALES BUILD IMMEDIATE REFRESH COMPLETE ON DEMAND AS SELECT region, SUM(amount) total FROM SALES GROUP BY REGION
+//
+// The bind variables of query are inlined, DDL takes none. A fast refresh needs a materialized
+// view log on each base table.
+func (m Migrator) CreateMaterializedView(name string, query *gorm.DB, opts ...MatViewOption) error {
+	if query == nil {
+		return fmt.Errorf("oracle: CreateMaterializedView: query of %s is nil", name)
+	}
+	stmt := &gorm.Statement{DB: m.DB}
+	_, _ = stmt.WriteString("CREATE MATERIALIZED VIEW ")
+	m.DB.Dialector.QuoteTo(stmt, name)
+	_ = stmt.WriteByte(' ')
+	_, _ = stmt.WriteString(matViewClauses(opts))
+	_, _ = stmt.WriteString(" AS ")
+	stmt.AddVar(stmt, query)
+	if stmt.Error != nil {
+		return stmt.Error
+	}
+	return m.DB.Exec(m.DB.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)).Error
+}
+
+// DropMaterializedView drops the materialized view name, possibly owner-qualified
+func (m Migrator) DropMaterializedView(name string) error {
+	var drop strings.Builder
+	drop.WriteString("DROP MATERIALIZED VIEW ")
+	m.DB.Dialector.QuoteTo(&drop, name)
+	return m.DB.Exec(drop.String()).Error
+}
+
+// HasMaterializedView reports whether the materialized view name, possibly owner-qualified, exists
+func (m Migrator) HasMaterializedView(name string) bool {
+	ns := getNS(m.DB, m.Dialector)
+	owner, view, hasOwner := ns.dictQualifiedParts(name)
+
+	var exists int
+	var err error
+	if hasOwner {
+		err = m.DB.Raw(
+			`SELECT 1 FROM ALL_MVIEWS WHERE OWNER = :owner AND MVIEW_NAME = :view AND ROWNUM = 1`,
+			sql.Named("owner", owner), sql.Named("view", view),
+		).Scan(&exists).Error
+	} else {
+		err = m.DB.Raw(
+			`SELECT 1 FROM USER_MVIEWS WHERE MVIEW_NAME = :view AND ROWNUM = 1`,
+			sql.Named("view", view),
+		).Scan(&exists).Error
+	}
+	return err == nil && exists == 1
+}
+
+// RefreshMaterializedView refreshes the materialized view name, possibly owner-qualified, through
+// DBMS_MVIEW.REFRESH
+func (m Migrator) RefreshMaterializedView(name string, method RefreshMethod) error {
+	var code string
+	switch RefreshMethod(strings.ToUpper(string(method))) {
+	case RefreshFast:
+		code = "F"
+	case RefreshComplete:
+		code = "C"
+	default:
+		code = "?"
+	}
+	var list strings.Builder
+	m.DB.Dialector.QuoteTo(&list, name)
+	return m.DB.Exec("BEGIN DBMS_MVIEW.REFRESH(list => ?, method => ?); END;", list.String(), code).Error
+}
@@ -1,10 +1,13 @@
 package oracle
 
 import (
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"hash/fnv"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/jinzhu/inflection"
 	"gorm.io/gorm/schema"
@@ -33,20 +36,22 @@ type NamingStrategy struct {
 	SingularTable       bool
 	NameReplacer        Replacer
 	IdentifierMaxLength int
+	// HashSuffixLength is the number of hex digits of the hash suffix of shortened and generated
+	// names, 8 by default and capped to the size of the hash
+	HashSuffixLength int
+	// NewHash returns the hash of that suffix, 32-bit FNV-1a by default; a wider hash such as
+	// fnv.New64a with a longer suffix makes collisions less likely in very large schemas
+	NewHash func() hash.Hash
 
 	PreferredCase          Case // default is SCREAMING_SNAKE_CASE
 	NamingCaseSensitive    bool // whether naming is case-sensitive
+	StrictReservedWords    bool // whether keywords are quoted like reserved words, see IsStrictReservedWord
+	ForceQuoteIdentifiers  bool // whether every identifier is quoted exactly as given, see Config.ForceQuoteIdentifiers
 	capIdentifierMaxLength int
 }
 
 // TableName convert string to table name
 func (ns *NamingStrategy) TableName(str string) string {
-	// Resolve maxLength without mutating receiver
-	maxLength := ns.IdentifierMaxLength
-	if maxLength <= 0 {
-		maxLength = ns.capIdentifierMaxLength
-	}
-
 	qualifiers := make([]qualifier, 0, 3)
 
 	// 1) Handle TablePrefix:
@@ -92,13 +97,15 @@ func (ns *NamingStrategy) TableName(str string) string {
 		}
 	}
 
-	// 4) Normalize the final base name and append as the last qualifier.
+	// 4) Normalize the final base name and append as the last qualifier. Quoted as given, a name
+	//    derived from the model is cased first.
+	if _, ok := IsExplicitQuoted(str); !ok && ns.ForceQuoteIdentifiers {
+		str = ns.toCase(str)
+	}
 	baseName, quoted := ns.normalizePart(str)
 	qualifiers = append(qualifiers, qualifier{name: baseName, quoted: quoted})
 
-	// 5) Join with quoting/capping.
-	//    Use maxLength we computed (do not mutate ns in a value receiver).
-	//    joinQualified already calls shortenIfNeeded; adapt it to accept maxLength if needed.
+	// 5) Join with quoting/capping (joinQualified shortens each part to ns.maxLength()).
 	return ns.joinQualified(qualifiers)
 }
 
@@ -173,7 +180,8 @@ func (ns *NamingStrategy) RelationshipFKName(rel schema.Relationship) string {
 		cols = []string{rel.Name}
 	}
 
-	// stable ordering for composite keys
+	// stable ordering for composite keys; this only affects the name, the constraint DDL keeps
+	// each foreign-key column paired with its referenced column
 	sort.Strings(cols)
 
 	return ns.genToken("FK", baseTable, strings.Join(cols, "_"))
@@ -200,20 +208,19 @@ func (ns *NamingStrategy) UniqueName(table, column string) string {
 // It disambiguates quoted vs. unquoted twins by hashing the *dictionary-case*
 // (OWNER, OBJECT, COLS...). It also respects Oracle's 30/128-byte limit.
 func (ns *NamingStrategy) genToken(kind string, tableOrObject string, cols ...string) string {
-	// Defaults
-	maxLength := ns.IdentifierMaxLength
-	if maxLength <= 0 {
-		maxLength = ns.capIdentifierMaxLength
-	}
+	maxLength := ns.maxLength()
 
 	// 1) Dictionary-case anchor (handles quoted vs unquoted correctly)
 	owner, object, _ := ns.dictQualifiedParts(tableOrObject) // object: exact if quoted, UPPER if unquoted
 
-	// 2) Human-readable base: KIND_<OBJECT> (UPPER_SNAKE for safety)
-	baseObj := ns.toCase(object)
+	// 2) Human-readable base: KIND_<OBJECT> (UPPER_SNAKE for safety), restricted to the characters
+	//    of an unquoted identifier; the name always starts with KIND_, never with a digit
+	baseObj, replaced := identToken(ns.toCase(object))
 	base := kind + "_" + baseObj
 	for _, c := range cols {
-		base += "_" + ns.toCase(c)
+		tc, r := identToken(ns.toCase(c))
+		replaced = replaced || r
+		base += "_" + tc
 	}
 
 	// 3) Build uniqueness seed across schema + object + columns (also in dictionary-case)
@@ -226,14 +233,16 @@ func (ns *NamingStrategy) genToken(kind string, tableOrObject string, cols ...st
 		seed.WriteString(ns.dictCasePart(c))
 	}
 
-	h := fnv.New32a()
-	_, _ = h.Write([]byte(seed.String()))
-	suffix := fmt.Sprintf("_%08X", h.Sum32()) // 9 chars including underscore
+	suffix := "_" + ns.hashSuffix(seed.String()) // 9 chars including underscore by default
 
+	// replaced characters may make distinct inputs look alike, keep the hash to tell them apart
 	name := base
-	if len(name) <= maxLength {
+	if len(name) <= maxLength && !replaced {
 		return name
 	}
+	if len(name)+len(suffix) <= maxLength {
+		return name + suffix
+	}
 
 	// Trim the object portion first, keep KIND_ and the hash suffix
 	// Total len = len(kind) + 1 + len(trimmedObj) + len(suffix)
@@ -252,6 +261,53 @@ func (ns *NamingStrategy) genToken(kind string, tableOrObject string, cols ...st
 	return kind + "_" + baseObj[:maxObj] + suffix
 }
 
+// identToken replaces each character that cannot appear in an unquoted identifier (anything but
+// ASCII letters, digits, _, $ and #) with an underscore, reporting whether it replaced any.
+func identToken(s string) (string, bool) {
+	replaced := false
+	token := strings.Map(func(r rune) rune {
+		switch {
+		case 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z', '0' <= r && r <= '9', r == '_', r == '$', r == '#':
+			return r
+		}
+		replaced = true
+		return '_'
+	}, s)
+	return token, replaced
+}
+
+// hashSuffix returns the upper-case hex digits of the hash of s suffixed to shortened and
+// generated names, HashSuffixLength (default 8) long and capped to the size of the hash
+func (ns *NamingStrategy) hashSuffix(s string) string {
+	newHash := ns.NewHash
+	if newHash == nil {
+		newHash = func() hash.Hash { return fnv.New32a() }
+	}
+	h := newHash()
+	_, _ = h.Write([]byte(s))
+	digits := strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+	n := ns.HashSuffixLength
+	if n <= 0 {
+		n = 8
+	}
+	if n < len(digits) {
+		digits = digits[:n]
+	}
+	return digits
+}
+
+// maxLength is the identifier length cap: IdentifierMaxLength, else the cap of the connected
+// database version, else Oracle's 30-byte limit of the versions before 12.2.
+func (ns *NamingStrategy) maxLength() int {
+	if ns.IdentifierMaxLength > 0 {
+		return ns.IdentifierMaxLength
+	}
+	if ns.capIdentifierMaxLength > 0 {
+		return ns.capIdentifierMaxLength
+	}
+	return 30
+}
+
 // endregion
 
 // region ---------- helpers: case transforms ----------
@@ -268,6 +324,12 @@ func (ns *NamingStrategy) genToken(kind string, tableOrObject string, cols ...st
 //
 // Returns true if s can be emitted unquoted safely.
 func IsSafeOracleUnquoted(s string) bool {
+	return isSafeOracleUnquoted(s, false)
+}
+
+// isSafeOracleUnquoted is IsSafeOracleUnquoted, also rejecting the Keywords when strict is set,
+// see IsStrictReservedWord
+func isSafeOracleUnquoted(s string, strict bool) bool {
 	if s == "" {
 		return false
 	}
@@ -285,7 +347,7 @@ func IsSafeOracleUnquoted(s string) bool {
 		}
 	}
 	up := strings.ToUpper(s)
-	if IsReservedWord(up) {
+	if isReservedWord(up, strict) {
 		return false
 	}
 	return true
@@ -332,10 +394,7 @@ type qualifier struct {
 }
 
 func (ns *NamingStrategy) joinQualified(parts []qualifier) string {
-	maxLength := ns.IdentifierMaxLength
-	if maxLength <= 0 {
-		maxLength = ns.capIdentifierMaxLength
-	}
+	maxLength := ns.maxLength()
 	var out strings.Builder
 	for i, p := range parts {
 		if i > 0 {
@@ -359,36 +418,33 @@ func (ns *NamingStrategy) joinQualified(parts []qualifier) string {
 	return out.String()
 }
 
-// FNV-1a suffix for capped names (Oracle 30-byte max)
+// hash suffix (FNV-1a by default) for capped names (Oracle 30-byte max)
 func (ns *NamingStrategy) shortenIfNeeded(s string) string {
-	maxLength := ns.IdentifierMaxLength
-	if maxLength <= 0 {
-		maxLength = ns.capIdentifierMaxLength
-	}
-	return ns.shortenWithMax(s, maxLength)
+	return ns.shortenWithMax(s, ns.maxLength())
 }
 
 func (ns *NamingStrategy) shortenWithMax(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	h := fnv.New32a()
-	_, _ = h.Write([]byte(s))
-	sum := h.Sum32()
-	const hexdigits = "0123456789ABCDEF"
-	hexValue := func(v uint32) string {
-		b := make([]byte, 8)
-		for i := 7; i >= 0; i-- {
-			b[i] = hexdigits[v&0xF]
-			v >>= 4
-		}
-		return string(b)
-	}(sum)
-	const sufLen = 1 + 8
+	hexValue := ns.hashSuffix(s)
+	sufLen := 1 + len(hexValue)
 	if maxLen <= sufLen {
-		return hexValue
+		return truncateBytes(hexValue, maxLen)
+	}
+	return truncateBytes(s, maxLen-sufLen) + "_" + hexValue
+}
+
+// truncateBytes caps s at n bytes (Oracle limits identifiers in bytes) without cutting a
+// multibyte rune in half
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
 	}
-	return s[:maxLen-sufLen] + "_" + hexValue
+	return s[:n]
 }
 
 func (ns *NamingStrategy) toCase(part string) string {
@@ -415,6 +471,7 @@ func (ns *NamingStrategy) toCase(part string) string {
 //	  - if NamingCaseSensitive=false  -> emit UNQUOTED UPPER_SNAKE (always)
 //	  - if NamingCaseSensitive=true   -> avoid quotes unless required; use UPPER_SNAKE when unquoted
 //	SnakeCase or CamelCase: coerce to quoted exact case always.
+//	ForceQuoteIdentifiers: quoted exactly as given, whatever the case.
 //
 // Explicit quotes in the input (tags like column:"\"Weird\"") are honored: always quoted exact.
 func (ns *NamingStrategy) normalizePart(part string) (name string, quoted bool) {
@@ -422,19 +479,22 @@ func (ns *NamingStrategy) normalizePart(part string) (name string, quoted bool)
 	if inner, ok := IsExplicitQuoted(part); ok {
 		return inner, true
 	}
+	if ns.ForceQuoteIdentifiers {
+		return part, true
+	}
 
 	switch ns.PreferredCase {
 	case ScreamingSnakeCase:
 		canon := ns.toCase(part) // already UPPER_SNAKE
 		if !ns.NamingCaseSensitive {
 			// always unquoted UPPER_SNAKE unless reserved (then quote)
-			if IsSafeOracleUnquoted(canon) {
+			if isSafeOracleUnquoted(canon, ns.StrictReservedWords) {
 				return canon, false
 			}
 			return canon, true
 		}
 		// namingCaseSensitive==true -> avoid quotes unless required
-		if IsSafeOracleUnquoted(canon) {
+		if isSafeOracleUnquoted(canon, ns.StrictReservedWords) {
 			return canon, false
 		}
 		return canon, true
@@ -476,6 +536,9 @@ func (ns NamingStrategy) dictCasePart(s string) string {
 	if inner, ok := IsExplicitQuoted(s); ok {
 		return inner // dictionary stores quoted identifiers case-sensitively
 	}
+	if ns.ForceQuoteIdentifiers {
+		return s // always quoted -> exact
+	}
 
 	// Decide if we *would* emit quotes for this part, but do NOT recase `s`.
 	switch ns.PreferredCase {
@@ -486,7 +549,7 @@ func (ns NamingStrategy) dictCasePart(s string) string {
 	case ScreamingSnakeCase:
 		// avoid quotes unless required; only check safety on UPPER(s)
 		up := strings.ToUpper(s)
-		if IsSafeOracleUnquoted(up) {
+		if isSafeOracleUnquoted(up, ns.StrictReservedWords) {
 			return up // dictionary matches unquoted as UPPER
 		}
 		return s // would be quoted -> exact
@@ -510,6 +573,20 @@ func (ns *NamingStrategy) dictQualifiedParts(ident string) (owner, object string
 	}
 }
 
+// dictQualifiedName renders the possibly owner-qualified name part by part in dictionary case,
+// for generated names (indexes, sequences, triggers) that must not be recased; with
+// ForceQuoteIdentifiers every part is quoted, the dictionary keeping it exact
+func (ns *NamingStrategy) dictQualifiedName(name string) string {
+	if ns.ForceQuoteIdentifiers {
+		return ns.normalizeQualified(name)
+	}
+	parts := splitQualified(name)
+	for i, p := range parts {
+		parts[i] = ns.dictCasePart(p)
+	}
+	return strings.Join(parts, ".")
+}
+
 // endregion
 
 // region ---------- Literal helpers ----------
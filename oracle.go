@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"hash"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/cmmoran/go-ora/v2"
 	"github.com/cmmoran/go-ora/v2/converters"
+	"github.com/cmmoran/go-ora/v2/network"
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
@@ -45,6 +47,68 @@ type Config struct {
 	// use this timezone for the session
 	SessionTimezone string
 	sessionLocation *time.Location
+	// DummyTable replaces DUAL in generated constant selects, for environments providing their own
+	// single-row table; it is read from on Oracle 23 too, where they otherwise have no FROM
+	DummyTable string
+	// IndexForeignKeys makes AutoMigrate index the foreign-key columns of each relationship, also when
+	// gorm.Config.DisableForeignKeyConstraintWhenMigrating skips the constraint itself; unindexed
+	// foreign keys make Oracle lock the child table on parent updates and deletes
+	IndexForeignKeys bool
+	// CallTimeout bounds every statement whose context has no deadline, see gorm.Config.DefaultContextTimeout;
+	// go-ora cancels the call server-side once the deadline passes
+	CallTimeout time.Duration
+	// TagActionWithCallback sets the session action (V$SESSION.ACTION) to the running GORM callback,
+	// e.g. gorm:query, before each statement executed in a transaction or on a db.Connection
+	TagActionWithCallback bool
+	// StrictReservedWords quotes identifiers named after non-reserved SQL keywords (PARTITION, PIVOT,
+	// FETCH, ...) as well as reserved words, see Keywords
+	StrictReservedWords bool
+	// ForceQuoteIdentifiers quotes every identifier exactly as given, the names returned by
+	// TableName, passed to Table or written in index tags alike, for schemas whose mixed-case
+	// objects were created quoted. Names derived from the model, columns included, are still cased
+	// by PreferredCase, then quoted
+	ForceQuoteIdentifiers bool
+	// MergeBatchSize is the maximum number of rows merged by one MERGE statement when upserting a
+	// slice with clause.OnConflict, defaulting to 500; larger slices are merged in a loop of batches.
+	// A negative value merges every row in a single statement
+	MergeBatchSize int
+	// HashSuffixLength and NewHash set the length and hash of the suffix disambiguating shortened
+	// and generated identifiers, see NamingStrategy
+	HashSuffixLength int
+	NewHash          func() hash.Hash
+	// UseSequencesForAutoIncrement makes the migrator feed auto-increment columns from a sequence
+	// SEQ_<TABLE>_<COLUMN> assigned by a BEFORE INSERT trigger instead of declaring them as identity
+	// columns, as a sequence tag does for a single field
+	UseSequencesForAutoIncrement bool
+	// AutoIncrementStrategy selects how auto-increment columns are fed: AutoIncrementIdentity declares
+	// identity columns, AutoIncrementSequenceTrigger feeds them as UseSequencesForAutoIncrement does,
+	// e.g. for tooling expecting sequences on Oracle 12c and later. AutoIncrementAuto, the default,
+	// declares identity columns unless UseSequencesForAutoIncrement is set or the database is Oracle 11g
+	AutoIncrementStrategy AutoIncrementStrategy
+	// Cipher encrypts the fields tagged `encrypt` before they are written and decrypts them when
+	// they are read, independently of Transparent Data Encryption
+	Cipher Cipher
+	// DefaultTimeType is the column type of time fields without a type tag: DATE, TIMESTAMP,
+	// TIMESTAMP WITH LOCAL TIME ZONE or TIMESTAMP WITH TIME ZONE, the default. DATE keeps whole
+	// seconds only, for schemas that store every timestamp as DATE
+	DefaultTimeType string
+	// InListArrayThreshold binds the values of IN conditions holding more values than it as one
+	// collection, `column IN (SELECT COLUMN_VALUE FROM TABLE(:1))`, instead of splitting them into
+	// OR'd lists of 1000 literals; zero keeps splitting. Lists of strings bind as SYS.ODCIVARCHAR2LIST
+	// and lists of numbers as SYS.ODCINUMBERLIST, other values are still split
+	InListArrayThreshold int
+	// SlowDDLThreshold makes the migrator warn through the gorm logger about every DDL statement
+	// taking longer than it, e.g. an index build on a large table; zero disables the warning
+	SlowDDLThreshold time.Duration
+	// UseBinaryFloatTypes maps float32 fields without a type or precision tag to BINARY_FLOAT and
+	// float64 fields to BINARY_DOUBLE, IEEE 754 types holding the Go values exactly, Inf and NaN
+	// included, instead of FLOAT
+	UseBinaryFloatTypes bool
+	// EmptyStringAsNull settles Oracle storing '' as NULL. When true empty strings are written as
+	// NULL and equality conditions against them become IS NULL; when false writing one to a NOT NULL
+	// column, or comparing a column with one, is an error. Unset, empty strings are bound as they are,
+	// except that MERGE writes a single space into NOT NULL string columns
+	EmptyStringAsNull *bool
 
 	namingStrategy *NamingStrategy
 }
@@ -348,7 +412,26 @@ func reflectValueReferenceDepth(obj any, depth int) (reflect.Value, bool) {
 	return reflectValueReferenceDepth(ptrVal, depth-1)
 }
 
+// dummyFrom returns the FROM clause of a constant select: FROM Config.DummyTable when one is
+// configured, else none from Oracle 23 on, which allows SELECT without FROM, and FROM DUAL before.
+func (d Dialector) dummyFrom() string {
+	if d.Config == nil {
+		return " FROM DUAL"
+	}
+	if d.DummyTable != "" {
+		return " FROM " + d.DummyTable
+	}
+	if dbVer, _ := strconv.Atoi(strings.Split(d.DBVer, ".")[0]); dbVer >= 23 {
+		return ""
+	}
+	return " FROM DUAL"
+}
+
+// DummyTableName returns the single-row table constant selects read from, Config.DummyTable or DUAL
 func (d Dialector) DummyTableName() string {
+	if d.Config != nil && d.DummyTable != "" {
+		return d.DummyTable
+	}
 	return "DUAL"
 }
 
@@ -361,23 +444,32 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 		d.NamingCaseSensitive = true
 	}
 	d.namingStrategy = &NamingStrategy{
-		NamingCaseSensitive: d.NamingCaseSensitive,
-		PreferredCase:       d.PreferredCase,
+		NamingCaseSensitive:   d.NamingCaseSensitive,
+		PreferredCase:         d.PreferredCase,
+		StrictReservedWords:   d.StrictReservedWords,
+		ForceQuoteIdentifiers: d.ForceQuoteIdentifiers,
+		HashSuffixLength:      d.HashSuffixLength,
+		NewHash:               d.NewHash,
 	}
 	db.NamingStrategy = d.namingStrategy
 
-	d.DefaultStringSize = 1024
+	if d.DefaultStringSize == 0 {
+		d.DefaultStringSize = 1024
+	}
 
 	// register callbacks
 	config := &callbacks.Config{
 		CreateClauses: []string{"INSERT", "VALUES", "ON CONFLICT", "RETURNING"},
 		UpdateClauses: []string{"UPDATE", "SET", "WHERE", "RETURNING"},
 		DeleteClauses: []string{"DELETE", "FROM", "WHERE", "RETURNING"},
-		QueryClauses:  []string{"SELECT", "FROM", "WHERE", "GROUP BY", "ORDER BY", "LIMIT", "FOR"},
+		QueryClauses:  []string{"WITH", "SELECT", "FROM", "WHERE", "GROUP BY", "ORDER BY", "LIMIT", "FOR"},
 	}
 	callbacks.RegisterDefaultCallbacks(db, config)
 
 	d.DriverName = "oracle"
+	if d.CallTimeout > 0 && db.DefaultContextTimeout == 0 {
+		db.DefaultContextTimeout = d.CallTimeout
+	}
 
 	if d.Conn != nil {
 		db.ConnPool = d.Conn
@@ -416,6 +508,12 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 		return err
 	}
 
+	if sqlDB, ok := db.ConnPool.(*sql.DB); ok && d.InListArrayThreshold > 0 {
+		if err = registerInListTypes(sqlDB); err != nil {
+			return err
+		}
+	}
+
 	d.namingStrategy.capIdentifierMaxLength = 30
 	// https://docs.oracle.com/en/database/oracle/oracle-database/26/sqlrf/Database-Object-Names-and-Qualifiers.html
 	dbverSplits := strings.Split(d.DBVer, ".")
@@ -438,6 +536,17 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 	if err = db.Callback().Query().Replace("gorm:query", Query); err != nil {
 		return
 	}
+	if err = registerContextErrorCallbacks(db); err != nil {
+		return
+	}
+	if err = registerSessionTimezoneCallbacks(db); err != nil {
+		return
+	}
+	if d.TagActionWithCallback {
+		if err = registerActionCallbacks(db); err != nil {
+			return
+		}
+	}
 
 	for k, v := range d.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
@@ -453,30 +562,64 @@ func (d Dialector) ClauseBuilders() (clauseBuilders map[string]clause.ClauseBuil
 	} else {
 		clauseBuilders["LIMIT"] = d.RewriteLimit11
 	}
+	clauseBuilders["FOR"] = d.RewriteLocking
+	clauseBuilders["SELECT"] = d.RewriteSelect
+	clauseBuilders["FROM"] = d.RewriteFrom
+	clauseBuilders["INSERT"] = d.RewriteDML
+	clauseBuilders["UPDATE"] = d.RewriteDML
+	clauseBuilders["DELETE"] = d.RewriteDML
+	clauseBuilders["SET"] = d.RewriteSet
 
 	clauseBuilders["RETURNING"] = func(c clause.Clause, builder clause.Builder) {
-		if _, ok := c.Expression.(Returning); ok {
-			c.Build(builder)
+		stmt, ok := builder.(*gorm.Statement)
+		if !ok {
+			return
 		}
 		if cret, ok := c.Expression.(clause.Returning); ok {
-			stmt, _ := builder.(*gorm.Statement)
 			if len(cret.Columns) > 0 {
 				c.Expression = ReturningWithColumns(cret.Columns)
 			} else {
 				c.Expression = Returning{}
 			}
 			stmt.Clauses["RETURNING"] = c
-			c.Build(builder)
+		}
+		// omit the clause when nothing can be bound, e.g. deleting through a non-addressable model value
+		if ret, ok := c.Expression.(Returning); ok {
+			if fields, _ := ret.bindableFields(stmt); len(fields) > 0 {
+				c.Build(builder)
+			}
 		}
 	}
 	// must support convertToLiteral for Eq and Expr statements and bindVar length limiting to 1000 or less
+	// HAVING conditions comparing a schema field get the same typed conversion as WHERE
+	clauseBuilders["GROUP BY"] = func(c clause.Clause, builder clause.Builder) {
+		stmt, _ := builder.(*gorm.Statement)
+		if groupBy, ok := c.Expression.(clause.GroupBy); ok && stmt != nil && stmt.Schema != nil && len(groupBy.Having) > 0 {
+			having := make([]clause.Expression, len(groupBy.Having))
+			for i, h := range groupBy.Having {
+				having[i] = h
+				if expr, ok := h.(clause.Expr); ok {
+					if f := lookUpHavingField(stmt.Schema, expr); f != nil {
+						having[i] = clause.Expr{
+							SQL:                expr.SQL,
+							Vars:               []any{convertToLiteral(stmt, expr.Vars[0], reflect.Value{}, f)},
+							WithoutParentheses: expr.WithoutParentheses,
+						}
+					}
+				}
+			}
+			groupBy.Having = having
+			c.Expression = groupBy
+		}
+		c.Build(builder)
+	}
 	clauseBuilders["WHERE"] = func(c clause.Clause, builder clause.Builder) {
 		stmt, _ := builder.(*gorm.Statement)
 		if stmt.Schema != nil {
 			for i, ws := range c.Expression.(clause.Where).Exprs {
 				switch wst := ws.(type) {
 				case clause.IN:
-					if newExpr := rewriteINClause(wst, false); newExpr != nil {
+					if newExpr := rewriteINClause(stmt, wst, false); newExpr != nil {
 						c.Expression.(clause.Where).Exprs[i] = newExpr
 					}
 				case clause.Eq:
@@ -488,15 +631,19 @@ func (d Dialector) ClauseBuilders() (clauseBuilders map[string]clause.ClauseBuil
 					}
 
 					if f := stmt.Schema.LookUpField(name); f != nil {
+						value := convertToBinaryFloat(stmt, f, convertToInterval(f, convertToLiteral(stmt, wst.Value, stmt.ReflectValue, f)))
+						if emptyStringCondition(stmt, f, wst.Value) {
+							value = nil // built as IS NULL
+						}
 						c.Expression.(clause.Where).Exprs[i] = clause.Eq{
 							Column: clause.Column{Table: stmt.Table, Name: f.DBName},
-							Value:  convertToLiteral(stmt, wst.Value, stmt.ReflectValue, f),
+							Value:  value,
 						}
 					}
 				case clause.NotConditions:
 					for j, nc := range wst.Exprs {
 						if ne, ok := nc.(clause.IN); ok {
-							if newExpr := rewriteINClause(ne, true); newExpr != nil {
+							if newExpr := rewriteINClause(stmt, ne, true); newExpr != nil {
 								c.Expression.(clause.Where).Exprs[i].(clause.NotConditions).Exprs[j] = newExpr
 							}
 						}
@@ -504,21 +651,25 @@ func (d Dialector) ClauseBuilders() (clauseBuilders map[string]clause.ClauseBuil
 				case clause.Expr:
 					switch {
 					case strings.Contains(wst.SQL, "="):
-						sp := strings.Split(wst.SQL, "=")
-						k := sp[0]
-						if name, ok := IsExplicitQuoted(k); ok {
-							k = name
-						}
-						if f := stmt.Schema.LookUpField(k); f != nil {
-							wst.Vars[0] = convertToLiteral(stmt, wst.Vars[0], stmt.ReflectValue, f)
+						if f := lookUpEqField(stmt.Schema, wst); f != nil {
+							if isNullRaw16(f, wst.Vars[0]) || emptyStringCondition(stmt, f, wst.Vars[0]) {
+								column, _, _ := strings.Cut(wst.SQL, "=")
+								c.Expression.(clause.Where).Exprs[i] = clause.Expr{
+									SQL:                strings.TrimSpace(column) + " IS NULL",
+									WithoutParentheses: wst.WithoutParentheses,
+								}
+								continue
+							}
+							vars := append([]any(nil), wst.Vars...)
+							vars[0] = convertToBinaryFloat(stmt, f, convertToInterval(f, convertToLiteral(stmt, vars[0], stmt.ReflectValue, f)))
 							c.Expression.(clause.Where).Exprs[i] = clause.Expr{
 								SQL:                wst.SQL,
-								Vars:               wst.Vars,
+								Vars:               vars,
 								WithoutParentheses: wst.WithoutParentheses,
 							}
 						}
 					case isInExpr(wst.SQL):
-						if newExpr := rewriteExprINClause(wst); newExpr != nil {
+						if newExpr := rewriteExprINClause(stmt, wst); newExpr != nil {
 							c.Expression.(clause.Where).Exprs[i] = newExpr
 						}
 					}
@@ -526,16 +677,77 @@ func (d Dialector) ClauseBuilders() (clauseBuilders map[string]clause.ClauseBuil
 			}
 			stmt.Clauses["WHERE"] = c
 		}
+		if stmt != nil && isCaseSensitive(stmt) {
+			c.Expression = clause.Where{Exprs: binaryConditions(c.Expression.(clause.Where).Exprs)}
+		}
 		c.Build(builder)
 	}
 
 	return
 }
 
-func rewriteINClause(in clause.IN, negation bool) clause.Expression {
+// lookUpEqField returns the field compared by a single `column = ?` condition. The column may be
+// quoted and/or qualified with the table name; anything more complex is left alone.
+func lookUpEqField(sch *schema.Schema, expr clause.Expr) *schema.Field {
+	if len(expr.Vars) != 1 {
+		return nil
+	}
+	sp := strings.Split(expr.SQL, "=")
+	if len(sp) != 2 || strings.TrimSpace(sp[1]) != "?" {
+		return nil
+	}
+	k := strings.TrimSpace(sp[0])
+	if k == "" || strings.ContainsAny(k[len(k)-1:], "<>!") {
+		return nil
+	}
+	return lookUpColumnField(sch, k)
+}
+
+// havingComparison matches a HAVING condition comparing a column, or the MIN/MAX of a column, to a
+// single bind variable; other aggregates do not keep the column type.
+var havingComparison = regexp.MustCompile(`(?i)^\s*(?:(?:MIN|MAX)\s*\(\s*([^()\s]+)\s*\)|([^()\s]+))\s*(?:=|<>|!=|<=|>=|<|>)\s*\?\s*$`)
+
+// lookUpHavingField returns the field compared by a `MAX(column) > ?` style HAVING condition.
+func lookUpHavingField(sch *schema.Schema, expr clause.Expr) *schema.Field {
+	if len(expr.Vars) != 1 {
+		return nil
+	}
+	m := havingComparison.FindStringSubmatch(expr.SQL)
+	if m == nil {
+		return nil
+	}
+	if m[1] != "" {
+		return lookUpColumnField(sch, m[1])
+	}
+	return lookUpColumnField(sch, m[2])
+}
+
+// lookUpColumnField resolves a possibly quoted and table-qualified column name to its field.
+func lookUpColumnField(sch *schema.Schema, k string) *schema.Field {
+	if parts := splitQualified(k); len(parts) > 1 {
+		k = parts[len(parts)-1]
+	}
+	if name, ok := IsExplicitQuoted(k); ok {
+		k = name
+	}
+	if f := sch.LookUpField(k); f != nil {
+		return f
+	}
+	for _, dbName := range sch.DBNames {
+		if strings.EqualFold(dbName, k) {
+			return sch.LookUpField(dbName)
+		}
+	}
+	return nil
+}
+
+func rewriteINClause(stmt *gorm.Statement, in clause.IN, negation bool) clause.Expression {
 	// Case 1: single value that is itself a slice (e.g. []uuid.UUID)
 	if len(in.Values) == 1 {
 		if flat, ok := flattenSlice(in.Values[0]); ok {
+			if expr := inListArrayExpr(stmt, in.Column, flat); expr != nil {
+				return expr
+			}
 			if len(flat) <= 1000 {
 				return clause.IN{
 					Column: in.Column,
@@ -559,6 +771,9 @@ func rewriteINClause(in clause.IN, negation bool) clause.Expression {
 	}
 
 	// Case 2: flat Values slice already
+	if expr := inListArrayExpr(stmt, in.Column, in.Values); expr != nil {
+		return expr
+	}
 	if len(in.Values) <= 1000 {
 		return nil
 	}
@@ -577,7 +792,7 @@ func rewriteINClause(in clause.IN, negation bool) clause.Expression {
 	return clause.Or(orExprs...)
 }
 
-func rewriteExprINClause(w clause.Expr) clause.Expression {
+func rewriteExprINClause(stmt *gorm.Statement, w clause.Expr) clause.Expression {
 	// Only support a single "?" arg
 	if len(w.Vars) != 1 {
 		return nil
@@ -589,6 +804,27 @@ func rewriteExprINClause(w clause.Expr) clause.Expression {
 		return nil
 	}
 
+	if threshold := inListArrayThreshold(stmt); threshold > 0 && len(flat) > threshold && strings.Count(w.SQL, "?") == 1 {
+		sql := strings.Replace(w.SQL, "?", "(SELECT COLUMN_VALUE FROM TABLE(?))", 1)
+		exprs := make([]clause.Expression, 0, len(flat)/inListMaxElements+1)
+		for _, chk := range chunkAny(flat, inListMaxElements) {
+			value, ok := inListValue(chk)
+			if !ok {
+				exprs = nil
+				break
+			}
+			exprs = append(exprs, clause.Expr{SQL: sql, Vars: []interface{}{value}, WithoutParentheses: w.WithoutParentheses})
+		}
+		switch {
+		case len(exprs) == 1:
+			return exprs[0]
+		case len(exprs) > 1 && strings.Contains(strings.Join(strings.Fields(strings.ToUpper(w.SQL)), " "), " NOT IN "):
+			return clause.And(exprs...)
+		case len(exprs) > 1:
+			return clause.Or(exprs...)
+		}
+	}
+
 	// Always normalize to []any even if small, so typed slices (e.g. []uuid.UUID)
 	// become something the existing bind logic already handles.
 	if len(flat) <= 1000 {
@@ -613,6 +849,47 @@ func rewriteExprINClause(w clause.Expr) clause.Expression {
 	return clause.Or(orExprs...)
 }
 
+// inListArrayThreshold returns Config.InListArrayThreshold of the dialector of stmt
+func inListArrayThreshold(stmt *gorm.Statement) int {
+	if stmt == nil || stmt.DB == nil {
+		return 0
+	}
+	v, _ := reflectDereference(stmt.DB.Dialector)
+	if d, ok := v.(Dialector); ok && d.Config != nil {
+		return d.InListArrayThreshold
+	}
+	return 0
+}
+
+// inListArrayExpr binds values as in-list collections, `column IN (SELECT COLUMN_VALUE FROM TABLE(?))`,
+// when they exceed Config.InListArrayThreshold; it returns nil when they don't or can't be bound so.
+// Lists longer than a collection holds are OR'd, also when negated: NOT (a IN (...) OR a IN (...)).
+func inListArrayExpr(stmt *gorm.Statement, column any, values []any) clause.Expression {
+	if threshold := inListArrayThreshold(stmt); threshold <= 0 || len(values) <= threshold {
+		return nil
+	}
+	if name, ok := column.(string); ok {
+		column = clause.Column{Name: name}
+	}
+	if _, ok := column.(clause.Column); !ok {
+		return nil
+	}
+
+	chunks := chunkAny(values, inListMaxElements)
+	exprs := make([]clause.Expression, len(chunks))
+	for i, chk := range chunks {
+		value, ok := inListValue(chk)
+		if !ok {
+			return nil
+		}
+		exprs[i] = clause.Expr{SQL: "? IN (SELECT COLUMN_VALUE FROM TABLE(?))", Vars: []interface{}{column, value}}
+	}
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return clause.Or(exprs...)
+}
+
 // Flatten a single value into []any if it's a non-[]byte slice.
 // Returns (nil, false) if v is not a slice (or is []byte).
 func flattenSlice(v interface{}) ([]any, bool) {
@@ -660,10 +937,106 @@ func isInExpr(sql string) bool {
 	return false
 }
 
+// RewriteLocking rewrite the FOR clause into Oracle's FOR UPDATE OF <table>.<column> form
+//
+// Oracle's OF list takes columns rather than tables, and a plain FOR UPDATE in a joined query
+// locks the rows of every joined table. When the query has joins and no table was specified,
+// only the rows of the primary model are locked. A table that resolves to no primary key column
+// is reported as an error.
+//
+//	SELECT ... FROM orders LEFT JOIN customers ... FOR UPDATE OF orders.ID
+func (d Dialector) RewriteLocking(c clause.Clause, builder clause.Builder) {
+	locking, ok := c.Expression.(clause.Locking)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+
+	table := locking.Table
+	if table.Name == "" && hasJoins(stmt) {
+		table = clause.Table{Name: clause.CurrentTable}
+	}
+	if table.Name == "" || table.Raw {
+		c.Build(builder)
+		return
+	}
+
+	column, ok := lockingColumn(stmt, table)
+	if !ok {
+		if locking.Table.Name != "" {
+			// FOR UPDATE OF <table> is not Oracle SQL, the OF list takes columns
+			_ = stmt.AddError(fmt.Errorf("oracle: cannot lock %q, FOR UPDATE OF needs the model or a joined relation with a primary key", locking.Table.Name))
+			return
+		}
+		c.Build(builder)
+		return
+	}
+
+	_, _ = builder.WriteString("FOR ")
+	_, _ = builder.WriteString(locking.Strength)
+	_, _ = builder.WriteString(" OF ")
+	builder.WriteQuoted(column)
+	if locking.Options != "" {
+		_ = builder.WriteByte(' ')
+		_, _ = builder.WriteString(locking.Options)
+	}
+}
+
+func hasJoins(stmt *gorm.Statement) bool {
+	if len(stmt.Joins) > 0 {
+		return true
+	}
+	if from, ok := stmt.Clauses["FROM"].Expression.(clause.From); ok {
+		return len(from.Joins) > 0
+	}
+	return false
+}
+
+// lockingColumn resolves the column used to name table in a FOR UPDATE OF list: the
+// prioritized primary key of the statement's model, or of the joined relation whose alias matches.
+func lockingColumn(stmt *gorm.Statement, table clause.Table) (clause.Column, bool) {
+	if stmt.Schema == nil {
+		return clause.Column{}, false
+	}
+
+	name := table.Name
+	if table.Alias != "" {
+		name = table.Alias
+	}
+
+	sch := stmt.Schema
+	if name == clause.CurrentTable || name == stmt.Table || name == sch.Table {
+		name = stmt.Table
+	} else if rel, ok := sch.Relationships.Relations[name]; ok && rel.FieldSchema != nil {
+		sch = rel.FieldSchema
+	} else {
+		return clause.Column{}, false
+	}
+
+	field := sch.PrioritizedPrimaryField
+	if field == nil && len(sch.PrimaryFields) > 0 {
+		field = sch.PrimaryFields[0]
+	}
+	if field == nil {
+		if len(sch.DBNames) == 0 {
+			return clause.Column{}, false
+		}
+		return clause.Column{Table: name, Name: sch.DBNames[0]}, true
+	}
+	return clause.Column{Table: name, Name: field.DBName}, true
+}
+
+// getLimitRows follows gorm's clause.Limit: Limit(0) fetches no rows at all, a negative limit
+// (Limit(-1)) cancels an earlier one and fetches every row
 func (d Dialector) getLimitRows(limit clause.Limit) (limitRows int, hasLimit bool) {
 	if l := limit.Limit; l != nil {
 		limitRows = *l
-		hasLimit = limitRows > 0
+		hasLimit = limitRows >= 0
 	}
 	return
 }
@@ -673,15 +1046,17 @@ func (d Dialector) RewriteLimit(c clause.Clause, builder clause.Builder) {
 		limitRows, hasLimit := d.getLimitRows(limit)
 
 		if stmt, ok := builder.(*gorm.Statement); ok {
-			if _, hasOrderBy := stmt.Clauses["ORDER BY"]; !hasOrderBy && hasLimit {
+			// OFFSET and FETCH need a deterministic order to page consistently
+			if _, hasOrderBy := stmt.Clauses["ORDER BY"]; !hasOrderBy && (hasLimit || limit.Offset > 0) {
 				s := stmt.Schema
 				_, _ = builder.WriteString("ORDER BY ")
 				if s != nil && s.PrioritizedPrimaryField != nil {
-					builder.WriteQuoted(s.PrioritizedPrimaryField.DBName)
+					// qualified, as joined tables and the ROWID subquery of lockLimitedRows may share the column name
+					builder.WriteQuoted(clause.Column{Table: clause.CurrentTable, Name: s.PrioritizedPrimaryField.DBName})
 					_ = builder.WriteByte(' ')
 				} else {
-					_, _ = builder.WriteString("(SELECT NULL FROM ")
-					_, _ = builder.WriteString(d.DummyTableName())
+					_, _ = builder.WriteString("(SELECT NULL")
+					_, _ = builder.WriteString(d.dummyFrom())
 					_, _ = builder.WriteString(")")
 				}
 			}
@@ -709,11 +1084,15 @@ func (d Dialector) RewriteLimit(c clause.Clause, builder clause.Builder) {
 //
 // # Only Limit
 //
-//	SELECT * FROM table_name WHERE ROWNUM <= limit ORDER BY column
+//	SELECT * FROM (SELECT * FROM table_name ORDER BY column) WHERE ROWNUM <= limit
 //
 // # Only Offset
 //
-//	SELECT * FROM table_name WHERE ROWNUM > offset ORDER BY column
+//	SELECT * FROM (SELECT T.*, ROW_NUMBER() OVER (ORDER BY column) AS ROW_NUM FROM table_name T)
+//	WHERE ROW_NUM > offset
+//
+// ROWNUM is assigned as rows pass the filter, so ROWNUM > offset never holds. Without an ORDER BY the
+// rows of a model query are numbered by its primary key.
 func (d Dialector) RewriteLimit11(c clause.Clause, builder clause.Builder) {
 	limit, ok := c.Expression.(clause.Limit)
 	if !ok {
@@ -731,51 +1110,31 @@ func (d Dialector) RewriteLimit11(c clause.Clause, builder clause.Builder) {
 		return
 	}
 
-	if hasLimit && hasOffset {
+	if hasOffset {
 		// Implementing pagination queries using ROW_NUMBER() and subqueries
 		if d.RowNumberAliasForOracle11 == "" {
 			d.RowNumberAliasForOracle11 = "ROW_NUM"
 		}
+		rows := fmt.Sprintf("> %d", offsetRows)
+		if hasLimit {
+			rows = fmt.Sprintf("BETWEEN %d AND %d", offsetRows+1, offsetRows+limitRows)
+		}
 		subQuerySQL := fmt.Sprintf(
-			"SELECT * FROM (SELECT T.*, ROW_NUMBER() OVER (ORDER BY %s) AS %s FROM (%s) T) WHERE %s BETWEEN %d AND %d",
+			"SELECT * FROM (SELECT T.*, ROW_NUMBER() OVER (ORDER BY %s) AS %s FROM (%s) T) WHERE %s %s",
 			d.getOrderByColumns(stmt),
 			d.RowNumberAliasForOracle11,
 			strings.TrimSpace(stmt.SQL.String()),
 			d.RowNumberAliasForOracle11,
-			offsetRows+1,
-			offsetRows+limitRows,
+			rows,
 		)
 		stmt.SQL.Reset()
 		stmt.SQL.WriteString(subQuerySQL)
-	} else if hasLimit {
-		d.rewriteRownumStmt(stmt, builder, " <= ", limitRows)
-	} else {
-		d.rewriteRownumStmt(stmt, builder, " > ", offsetRows)
-	}
-}
-
-func (d Dialector) rewriteRownumStmt(stmt *gorm.Statement, builder clause.Builder, operator string, rows int) {
-	limitSql := strings.Builder{}
-	if _, ok := stmt.Clauses["WHERE"]; !ok {
-		limitSql.WriteString(" WHERE ")
 	} else {
-		limitSql.WriteString(" AND ")
-	}
-	limitSql.WriteString("ROWNUM")
-	limitSql.WriteString(operator)
-	limitSql.WriteString(strconv.Itoa(rows))
-
-	if _, hasOrderBy := stmt.Clauses["ORDER BY"]; !hasOrderBy {
-		_, _ = builder.WriteString(limitSql.String())
-	} else {
-		// "ORDER BY" before insert
-		sqlTmp := strings.Builder{}
-		sqlOld := stmt.SQL.String()
-		orderIndex := strings.Index(sqlOld, "ORDER BY") - 1
-		sqlTmp.WriteString(sqlOld[:orderIndex])
-		sqlTmp.WriteString(limitSql.String())
-		sqlTmp.WriteString(sqlOld[orderIndex:])
-		stmt.SQL = sqlTmp
+		// wrapped rather than AND-ed into the WHERE, so the limit applies after ORDER BY and leaves
+		// ROWNUM predicates of the query alone
+		subQuerySQL := fmt.Sprintf("SELECT * FROM (%s) WHERE ROWNUM <= %d", strings.TrimSpace(stmt.SQL.String()), limitRows)
+		stmt.SQL.Reset()
+		stmt.SQL.WriteString(subQuerySQL)
 	}
 }
 
@@ -796,6 +1155,20 @@ func (d Dialector) getOrderByColumns(stmt *gorm.Statement) string {
 			return orderByBuilder.String()
 		}
 	}
+	// number the rows of a plain model query by primary key, so its pages neither overlap nor skip rows
+	if sch := stmt.Schema; sch != nil && len(sch.PrimaryFieldDBNames) > 0 && stmt.Table == sch.Table &&
+		len(stmt.Selects) == 0 && len(stmt.Omits) == 0 && len(stmt.Joins) == 0 {
+		if _, grouped := stmt.Clauses["GROUP BY"]; !grouped {
+			orderByBuilder := strings.Builder{}
+			for i, name := range sch.PrimaryFieldDBNames {
+				if i > 0 {
+					orderByBuilder.WriteString(", ")
+				}
+				d.QuoteTo(&orderByBuilder, name)
+			}
+			return orderByBuilder.String()
+		}
+	}
 	return "NULL"
 }
 
@@ -804,6 +1177,11 @@ func (d Dialector) DefaultValueOf(*schema.Field) clause.Expression {
 }
 
 func (d Dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	if d.Config != nil && d.SlowDDLThreshold > 0 {
+		if _, ok := db.Logger.(*slowDDLLogger); !ok {
+			db = db.Session(&gorm.Session{Logger: &slowDDLLogger{Interface: db.Logger, threshold: d.SlowDDLThreshold}})
+		}
+	}
 	return Migrator{
 		Migrator: migrator.Migrator{
 			Config: migrator.Config{
@@ -856,16 +1234,60 @@ func isSixteenByteType(t reflect.Type) bool {
 	return t.ConvertibleTo(ty16Byte)
 }
 
+// nativeVectors reports whether the database has the VECTOR type, Oracle 23ai and later
+func (d Dialector) nativeVectors() bool {
+	if d.Config == nil {
+		return false
+	}
+	dbVer, _ := strconv.Atoi(strings.Split(d.DBVer, ".")[0])
+	return dbVer >= 23
+}
+
+// defaultTimeType returns the data type of time fields without a type tag, see Config.DefaultTimeType
+func (d Dialector) defaultTimeType() schema.DataType {
+	if d.Config == nil || strings.TrimSpace(d.DefaultTimeType) == "" {
+		return "timestamp with time zone"
+	}
+	return schema.DataType(strings.ToLower(strings.Join(strings.Fields(d.DefaultTimeType), " ")))
+}
+
 func (d Dialector) DataTypeOf(field *schema.Field) string {
 	// Do not mutate TagSettings here; schema.Field can be shared across goroutines.
 
+	// Encrypted fields hold their ciphertext
+	if isEncryptedField(field) {
+		return encryptedDataType(field)
+	}
+
 	// Handle any uuid/ulid as RAW(16)
 	if isSixteenByteType(field.FieldType) {
 		return "RAW(16)"
 	}
 
+	// Handle slices declared as VARRAY/nested table collections
+	if ct, ok := parseCollectionType(field); ok {
+		return ct.Name
+	}
+
+	// Handle embeddings as VECTOR, or their literal in a CLOB before Oracle 23ai
+	if isVectorField(field) {
+		if !d.nativeVectors() {
+			return "CLOB"
+		}
+		return strings.ToUpper(strings.Join(strings.Fields(string(field.DataType)), ""))
+	}
+
+	// Handle time.Duration as nanoseconds-precise intervals
+	if isIntervalField(field) && (field.DataType == schema.Int || strings.EqualFold(strings.Join(strings.Fields(string(field.DataType)), " "), "interval day to second")) {
+		return "INTERVAL DAY(9) TO SECOND(9)"
+	}
+
 	var sqlType string
-	switch field.DataType {
+	dataType := field.DataType
+	if dataType == schema.Time {
+		dataType = d.defaultTimeType()
+	}
+	switch dataType {
 	case schema.Bool:
 		booleanType := "NUMBER(1)"
 		if len(d.DBVer) > 0 {
@@ -880,15 +1302,38 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 			sqlType = "SMALLINT"
 		}
 
-		if field.AutoIncrement {
+		if field.AutoIncrement && !usesSequence(d.Config.sequencesForAutoIncrement(), field) {
 			sqlType += " GENERATED BY DEFAULT AS IDENTITY"
 		}
 	case schema.Float:
 		sqlType = "FLOAT"
+		if field.Precision > 0 {
+			sqlType = numberType(field)
+		} else if d.UseBinaryFloatTypes {
+			sqlType = "BINARY_DOUBLE"
+			if field.Size == 32 {
+				sqlType = "BINARY_FLOAT"
+			}
+		}
+	case "binary_float", "BINARY_FLOAT":
+		sqlType = "BINARY_FLOAT"
+	case "binary_double", "BINARY_DOUBLE":
+		sqlType = "BINARY_DOUBLE"
+	case "numeric", "decimal", "number", "NUMERIC", "DECIMAL", "NUMBER":
+		sqlType = numberType(field)
 	case schema.String, "VARCHAR2", "varchar2":
 		size := field.Size
 		defaultSize := d.DefaultStringSize
 
+		if size < 0 {
+			// size:-1 asks for unbounded text, whatever the default string size
+			if d.Config.UseClobForTextType {
+				sqlType = "CLOB"
+			} else {
+				sqlType = "VARCHAR2(4000)"
+			}
+			break
+		}
 		if size == 0 {
 			if defaultSize > 0 {
 				size = int(defaultSize)
@@ -924,7 +1369,11 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 				}
 			}
 		}
-	case schema.Time, "timestamp with time zone":
+	case "nvarchar2", "NVARCHAR2":
+		sqlType = d.nationalStringType(field)
+	case "nclob", "NCLOB":
+		sqlType = "NCLOB"
+	case "timestamp with time zone":
 		if field.Precision > 0 && field.Precision <= 9 {
 			sqlType = fmt.Sprintf("TIMESTAMP(%d) WITH TIME ZONE", field.Precision)
 		} else {
@@ -932,7 +1381,7 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 		}
 	case schema.Bytes:
 		sqlType = "BLOB"
-	case "timestamp without time zone":
+	case "timestamp without time zone", "timestamp with local time zone":
 		if field.Precision > 0 && field.Precision <= 9 {
 			sqlType = fmt.Sprintf("TIMESTAMP(%d) WITH LOCAL TIME ZONE", field.Precision)
 		} else {
@@ -947,7 +1396,7 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 	case "date":
 		sqlType = "DATE"
 	default:
-		sqlType = string(field.DataType)
+		sqlType = string(dataType)
 
 		if strings.EqualFold(sqlType, "text") {
 			if d.Config.UseClobForTextType {
@@ -970,6 +1419,43 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 	return sqlType
 }
 
+// nationalStringType returns NVARCHAR2(n) for the field's size, with the thresholds of VARCHAR2: an
+// NVARCHAR2 length is always in characters, at most 2000 of them in the 4000 bytes of AL16UTF16
+func (d Dialector) nationalStringType(field *schema.Field) string {
+	size := field.Size
+	if size < 0 {
+		if d.Config.UseClobForTextType {
+			return "NCLOB"
+		}
+		return "NVARCHAR2(2000)"
+	}
+	if size == 0 {
+		size = int(d.DefaultStringSize)
+	}
+	if size > 0 && size <= 2000 {
+		return fmt.Sprintf("NVARCHAR2(%d)", size)
+	}
+	if d.Config.UseClobForTextType || d.VarcharSizeIsCharLength {
+		return "NCLOB"
+	}
+	if field.Size > 0 {
+		return fmt.Sprintf("NVARCHAR2(%d)", field.Size)
+	}
+	return "NVARCHAR2(2000)"
+}
+
+// numberType returns NUMBER(p,s) for the field's precision and scale, or a bare NUMBER without precision.
+func numberType(field *schema.Field) string {
+	switch {
+	case field.Precision > 0 && field.Scale > 0:
+		return fmt.Sprintf("NUMBER(%d,%d)", field.Precision, field.Scale)
+	case field.Precision > 0:
+		return fmt.Sprintf("NUMBER(%d)", field.Precision)
+	default:
+		return "NUMBER"
+	}
+}
+
 func (d Dialector) SavePoint(tx *gorm.DB, name string) error {
 	tx.Exec("SAVEPOINT " + name)
 	return tx.Error
@@ -1004,5 +1490,94 @@ func (d Dialector) Translate(err error) error {
 		}
 		return terr
 	}
+	switch oracleErrorCode(err) {
+	case 1013:
+		// go-ora breaks the call when its context is done; see translateContextError for deadlines
+		return fmt.Errorf("%w: %w", context.Canceled, err)
+	case 40, 3156, 51616:
+		// the call exceeded a server-side time limit
+		return fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+	case 1791:
+		// not a SELECTed expression
+		return fmt.Errorf("oracle: ORDER BY of a DISTINCT query orders by a column it does not select; select the column or drop Distinct: %w", err)
+	case 1:
+		// unique constraint violated
+		return &translatedError{sentinel: gorm.ErrDuplicatedKey, err: err}
+	case 2291, 2292:
+		// parent key not found, child record found
+		return &translatedError{sentinel: gorm.ErrForeignKeyViolated, err: err}
+	case 2290:
+		// check constraint violated
+		return &translatedError{sentinel: gorm.ErrCheckConstraintViolated, err: err}
+	case 1400, 1407:
+		// cannot insert NULL into, cannot update to NULL
+		return &translatedError{sentinel: ErrNotNullViolated, err: err}
+	}
 	return err
 }
+
+// ErrNotNullViolated is reported, with TranslateError, for ORA-01400 and ORA-01407: a NULL written
+// to a NOT NULL column.
+var ErrNotNullViolated = errors.New("violates not null constraint")
+
+// translatedError reports an ORA error as one of gorm's sentinel errors: errors.Is matches the
+// sentinel and errors.Unwrap returns the ORA error.
+type translatedError struct {
+	sentinel error
+	err      error
+}
+
+func (e *translatedError) Error() string {
+	return e.sentinel.Error() + ": " + e.err.Error()
+}
+
+func (e *translatedError) Unwrap() error {
+	return e.err
+}
+
+func (e *translatedError) Is(target error) bool {
+	return target == e.sentinel
+}
+
+// registerContextErrorCallbacks reports calls aborted by an expired statement context as
+// context.DeadlineExceeded rather than context.Canceled, see translateContextError.
+func registerContextErrorCallbacks(db *gorm.DB) (err error) {
+	cb := db.Callback()
+	if err = cb.Create().After("gorm:create").Register("oracle:context_error", translateContextError); err != nil {
+		return
+	}
+	if err = cb.Query().After("gorm:query").Register("oracle:context_error", translateContextError); err != nil {
+		return
+	}
+	if err = cb.Update().After("gorm:update").Register("oracle:context_error", translateContextError); err != nil {
+		return
+	}
+	if err = cb.Delete().After("gorm:delete").Register("oracle:context_error", translateContextError); err != nil {
+		return
+	}
+	if err = cb.Row().After("gorm:row").Register("oracle:context_error", translateContextError); err != nil {
+		return
+	}
+	return cb.Raw().After("gorm:raw").Register("oracle:context_error", translateContextError)
+}
+
+// translateContextError: ORA-01013 does not tell a canceled context from an expired one, the
+// statement context does.
+func translateContextError(db *gorm.DB) {
+	if !db.TranslateError || db.Error == nil || !errors.Is(db.Statement.Context.Err(), context.DeadlineExceeded) {
+		return
+	}
+	var oraErr *network.OracleError
+	if errors.As(db.Error, &oraErr) && oraErr.ErrCode == 1013 {
+		db.Error = fmt.Errorf("%w: %w", context.DeadlineExceeded, oraErr)
+	}
+}
+
+// oracleErrorCode returns the ORA- code of err, or 0 when err is not an Oracle error.
+func oracleErrorCode(err error) int {
+	var oraErr *network.OracleError
+	if errors.As(err, &oraErr) {
+		return oraErr.ErrCode
+	}
+	return 0
+}
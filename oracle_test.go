@@ -3,18 +3,27 @@ package oracle
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
+	"hash/fnv"
 	"log"
 	"log/slog"
+	"math"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/cmmoran/go-ora/v2"
 	"github.com/cmmoran/go-ora/v2/converters"
+	"github.com/cmmoran/go-ora/v2/network"
 	"github.com/docker/go-connections/nat"
 	gofrs "github.com/gofrs/uuid/v3"
 	"github.com/google/uuid"
@@ -413,6 +422,17 @@ func (TestTableDefaultValues) TableName() string {
 	return "test_user_defaults"
 }
 
+type TestTableServerDefaults struct {
+	ID    uint64 `gorm:"primaryKey;autoIncrement"`
+	Name  string `gorm:"size:50"`
+	Token string `gorm:"->;default:SYS_GUID()"`
+	Ref   []byte `gorm:"->;type:raw(16);default:SYS_GUID()"`
+}
+
+func (TestTableServerDefaults) TableName() string {
+	return "test_server_defaults"
+}
+
 // ==== Query and clause behavior ====
 
 func TestCountLimit0(t *testing.T) {
@@ -451,6 +471,125 @@ func TestCountLimit0(t *testing.T) {
 	}
 }
 
+func TestLimitSemantics(t *testing.T) {
+	limit := func(n int) *int { return &n }
+	build := func(d Dialector, rewrite func(clause.Clause, clause.Builder), l clause.Limit) (string, []interface{}) {
+		stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Clauses: map[string]clause.Clause{}}
+		stmt.SQL.WriteString("SELECT * FROM T ")
+		rewrite(clause.Clause{Expression: l}, stmt)
+		return strings.TrimSpace(stmt.SQL.String()), stmt.Vars
+	}
+
+	d := Dialector{Config: &Config{}}
+	for _, tt := range []struct {
+		name  string
+		limit clause.Limit
+		sql   string
+		vars  []interface{}
+	}{
+		{"Limit(0) fetches no rows", clause.Limit{Limit: limit(0)}, "SELECT * FROM T ORDER BY (SELECT NULL FROM DUAL) FETCH NEXT :1 ROWS ONLY", []interface{}{0}},
+		{"Limit(-1) fetches every row", clause.Limit{Limit: limit(-1)}, "SELECT * FROM T", nil},
+		{"Limit(10)", clause.Limit{Limit: limit(10)}, "SELECT * FROM T ORDER BY (SELECT NULL FROM DUAL) FETCH NEXT :1 ROWS ONLY", []interface{}{10}},
+		{"Offset only", clause.Limit{Offset: 5}, "SELECT * FROM T ORDER BY (SELECT NULL FROM DUAL) OFFSET :1 ROWS", []interface{}{5}},
+		{"Offset with Limit(-1)", clause.Limit{Limit: limit(-1), Offset: 5}, "SELECT * FROM T ORDER BY (SELECT NULL FROM DUAL) OFFSET :1 ROWS", []interface{}{5}},
+		{"Offset and Limit", clause.Limit{Limit: limit(10), Offset: 5}, "SELECT * FROM T ORDER BY (SELECT NULL FROM DUAL) OFFSET :1 ROWS FETCH NEXT :2 ROWS ONLY", []interface{}{5, 10}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, vars := build(d, d.RewriteLimit, tt.limit)
+			assert.Equal(t, tt.sql, sql)
+			assert.Equal(t, tt.vars, vars)
+		})
+	}
+
+	// Oracle 11g has neither OFFSET nor FETCH
+	for _, tt := range []struct {
+		name  string
+		limit clause.Limit
+		sql   string
+	}{
+		{"Limit(0) fetches no rows", clause.Limit{Limit: limit(0)}, "SELECT * FROM (SELECT * FROM T) WHERE ROWNUM <= 0"},
+		{"Limit(-1) fetches every row", clause.Limit{Limit: limit(-1)}, "SELECT * FROM T"},
+		{"Offset only", clause.Limit{Offset: 5}, "SELECT * FROM (SELECT T.*, ROW_NUMBER() OVER (ORDER BY NULL) AS ROW_NUM FROM (SELECT * FROM T) T) WHERE ROW_NUM > 5"},
+		{"Offset and Limit", clause.Limit{Limit: limit(10), Offset: 5}, "SELECT * FROM (SELECT T.*, ROW_NUMBER() OVER (ORDER BY NULL) AS ROW_NUM FROM (SELECT * FROM T) T) WHERE ROW_NUM BETWEEN 6 AND 15"},
+	} {
+		t.Run("11g "+tt.name, func(t *testing.T) {
+			sql, _ := build(d, d.RewriteLimit11, tt.limit)
+			assert.Equal(t, tt.sql, sql)
+		})
+	}
+
+	// without an ORDER BY, 11g numbers the rows of a model query by its primary key
+	sch, err := schema.Parse(&TestTableDefaultValues{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	offsetOnly := func(d Dialector) string {
+		stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Clauses: map[string]clause.Clause{}, Schema: sch, Table: sch.Table}
+		stmt.SQL.WriteString("SELECT * FROM " + sch.Table + " ")
+		d.RewriteLimit11(clause.Clause{Expression: clause.Limit{Offset: 1}}, stmt)
+		return stmt.SQL.String()
+	}
+	assert.Equal(t,
+		`SELECT * FROM (SELECT T.*, ROW_NUMBER() OVER (ORDER BY ID) AS ROW_NUM FROM (SELECT * FROM test_user_defaults) T) WHERE ROW_NUM > 1`,
+		offsetOnly(Dialector{Config: &Config{namingStrategy: &NamingStrategy{}}}))
+
+	// a bare 11g limit wraps the query, leaving its ROWNUM predicates and ORDER BY to apply first
+	rownumLimit := func(d Dialector) string {
+		stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Clauses: map[string]clause.Clause{
+			"WHERE":    {Name: "WHERE", Expression: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "ROWNUM <= 3"}}}},
+			"ORDER BY": {Name: "ORDER BY", Expression: clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "name"}, Desc: true}}}},
+		}}
+		stmt.SQL.WriteString("SELECT * FROM " + sch.Table + " WHERE ROWNUM <= 3 ORDER BY name DESC ")
+		d.RewriteLimit11(clause.Clause{Expression: clause.Limit{Limit: limit(1)}}, stmt)
+		return stmt.SQL.String()
+	}
+	assert.Equal(t,
+		`SELECT * FROM (SELECT * FROM test_user_defaults WHERE ROWNUM <= 3 ORDER BY name DESC) WHERE ROWNUM <= 1`,
+		rownumLimit(d))
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+	_ = db.Migrator().DropTable(&TestTableDefaultValues{})
+	require.NoError(t, db.Migrator().AutoMigrate(TestTableDefaultValues{}), "expecting no error")
+	rows := []TestTableDefaultValues{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	require.NoError(t, db.Create(&rows).Error)
+
+	// offset-only paging with the 11g syntax; it is what Offset builds on Oracle 11 and lower, and still valid
+	// on later versions, which page with OFFSET
+	var paged []TestTableDefaultValues
+	if dbVer, _ := strconv.Atoi(strings.Split(db.Dialector.(*Dialector).DBVer, ".")[0]); dbVer > 11 {
+		require.NoError(t, db.Raw(offsetOnly(*db.Dialector.(*Dialector))).Scan(&paged).Error)
+	} else {
+		require.NoError(t, db.Offset(1).Find(&paged).Error)
+	}
+	require.Len(t, paged, 2, "expecting Offset(1) to skip a row")
+	assert.Equal(t, "b", paged[0].Name)
+	assert.Equal(t, "c", paged[1].Name)
+
+	// a user ROWNUM filter plus a limit, with the 11g syntax as above
+	var last []TestTableDefaultValues
+	if dbVer, _ := strconv.Atoi(strings.Split(db.Dialector.(*Dialector).DBVer, ".")[0]); dbVer > 11 {
+		require.NoError(t, db.Raw(rownumLimit(*db.Dialector.(*Dialector))).Scan(&last).Error)
+	} else {
+		require.NoError(t, db.Where("ROWNUM <= ?", 3).Order("name DESC").Limit(1).Find(&last).Error)
+	}
+	require.Len(t, last, 1)
+	assert.Equal(t, "c", last[0].Name, "expecting the limit applied after ORDER BY")
+
+	var got []TestTableDefaultValues
+	require.NoError(t, db.Limit(0).Find(&got).Error)
+	assert.Empty(t, got, "expecting Limit(0) to fetch no rows")
+	require.NoError(t, db.Limit(-1).Find(&got).Error)
+	assert.Len(t, got, 3, "expecting Limit(-1) to fetch every row")
+	require.NoError(t, db.Offset(1).Find(&got).Error)
+	assert.Len(t, got, 2, "expecting Offset(1) to skip a row")
+	require.NoError(t, db.Limit(10).Offset(1).Order("id").Find(&got).Error)
+	require.Len(t, got, 2)
+	assert.Equal(t, "b", got[0].Name)
+}
+
 func TestChunkIn(t *testing.T) {
 	ctx := currentContext()
 	db := dbNamingCase
@@ -520,6 +659,608 @@ func TestChunkIn(t *testing.T) {
 	require.EqualValuesf(t, maxIds[0], finds[0].User, "expecting ID to match")
 }
 
+type TestTableInList struct {
+	ID   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name string `gorm:"size:50"`
+}
+
+func (TestTableInList) TableName() string {
+	return "test_in_list"
+}
+
+func Test_inListValue(t *testing.T) {
+	name := "b"
+	for _, tt := range []struct {
+		name   string
+		values []any
+		want   any
+		ok     bool
+	}{
+		{"strings", []any{"a", &name}, go_ora.Object{Owner: "SYS", Name: "ODCIVARCHAR2LIST", Value: []string{"a", "b"}}, true},
+		{"integers", []any{1, int8(2), uint64(3)}, go_ora.Object{Owner: "SYS", Name: "ODCINUMBERLIST", Value: []int64{1, 2, 3}}, true},
+		{"floats", []any{1.5, float32(2)}, go_ora.Object{Owner: "SYS", Name: "ODCINUMBERLIST", Value: []float64{1.5, 2}}, true},
+		{"mixed", []any{1, "a"}, nil, false},
+		{"uuid", []any{uuid.New()}, nil, false},
+		{"nil", []any{nil}, nil, false},
+		{"empty", nil, nil, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := inListValue(tt.values)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInListArray(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	require.NoError(t, err)
+
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(threshold int) { cfg.InListArrayThreshold = threshold }(cfg.InListArrayThreshold)
+	cfg.InListArrayThreshold = 1000
+	require.NoError(t, RegisterInListTypes(db))
+
+	_ = db.Migrator().DropTable(&TestTableInList{})
+	require.NoError(t, db.AutoMigrate(&TestTableInList{}))
+
+	const total = 10000
+	rows := make([]TestTableInList, total)
+	ids := make([]uint64, total)
+	names := make([]string, total)
+	for i := range rows {
+		rows[i] = TestTableInList{ID: uint64(i + 1), Name: fmt.Sprintf("name-%05d", i+1)}
+		ids[i] = rows[i].ID
+		names[i] = rows[i].Name
+	}
+	require.NoError(t, db.Create(&rows).Error)
+
+	stmt := db.Session(&gorm.Session{DryRun: true}).Where("id IN ?", ids).Find(&[]TestTableInList{}).Statement
+	require.Less(t, len(stmt.SQL.String()), 200, stmt.SQL.String())
+	require.Len(t, stmt.Vars, 1)
+
+	var count int64
+	require.NoError(t, db.Model(&TestTableInList{}).Where("id IN ?", ids).Count(&count).Error)
+	require.EqualValues(t, total, count)
+	require.NoError(t, db.Model(&TestTableInList{}).Where(map[string]any{"name": names[:total/2]}).Count(&count).Error)
+	require.EqualValues(t, total/2, count)
+	require.NoError(t, db.Model(&TestTableInList{}).Not(map[string]any{"id": ids[1:]}).Count(&count).Error)
+	require.EqualValues(t, 1, count)
+}
+
+type TestTableLockCustomer struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string `gorm:"size:50"`
+}
+
+func (TestTableLockCustomer) TableName() string {
+	return "test_lock_customer"
+}
+
+type TestTableLockOrder struct {
+	ID         uint64 `gorm:"primaryKey"`
+	CustomerID uint64
+	Customer   TestTableLockCustomer
+	Amount     int
+}
+
+func (TestTableLockOrder) TableName() string {
+	return "test_lock_order"
+}
+
+func TestLockingWithJoins(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Joins("Customer").Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).Find(&orders)
+	})
+	upperSQL := strings.ToUpper(toSQL)
+	assert.Contains(t, upperSQL, "LEFT JOIN")
+	assert.True(t, strings.HasSuffix(upperSQL, "FOR UPDATE OF TEST_LOCK_ORDER.ID"), "expected lock to target the driving table: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Joins("Customer").Clauses(clause.Locking{
+			Strength: clause.LockingStrengthUpdate,
+			Table:    clause.Table{Name: "Customer"},
+		}).Find(&orders)
+	})
+	assert.True(t, strings.HasSuffix(strings.ToUpper(toSQL), `FOR UPDATE OF CUSTOMER.ID`), "expected lock to target the joined relation: %s", toSQL)
+
+	var orders []TestTableLockOrder
+	err := db.Session(&gorm.Session{DryRun: true}).Joins("Customer").Clauses(clause.Locking{
+		Strength: clause.LockingStrengthUpdate,
+		Table:    clause.Table{Name: "Unknown"},
+	}).Find(&orders).Error
+	assert.ErrorContains(t, err, `cannot lock "Unknown"`, "expecting an unresolved table reported rather than locked by name")
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).Find(&orders)
+	})
+	assert.True(t, strings.HasSuffix(strings.ToUpper(toSQL), "FOR UPDATE"), "expected plain lock without joins: %s", toSQL)
+}
+
+func TestLockingWithLimit(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	skipLocked := clause.Locking{Strength: clause.LockingStrengthUpdate, Options: "SKIP LOCKED"}
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Clauses(skipLocked).Where("amount > ?", 0).Order("id").Limit(2).Find(&orders)
+	})
+	upperSQL := strings.ToUpper(toSQL)
+	assert.Contains(t, upperSQL, ".ROWID IN (SELECT ROW_ID FROM (SELECT ", "expecting the limited rows picked in a subquery: %s", toSQL)
+	assert.True(t, strings.HasSuffix(upperSQL, ")) ORDER BY ID FOR UPDATE SKIP LOCKED"), "expecting no limit outside the subquery: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Joins("Customer").Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: "WAIT 5"}).Limit(1).Find(&orders)
+	})
+	assert.True(t, strings.HasSuffix(strings.ToUpper(toSQL), "FOR UPDATE OF TEST_LOCK_ORDER.ID WAIT 5"), "expecting the lock to keep its target: %s", toSQL)
+
+	_ = db.Migrator().DropTable(&TestTableLockOrder{}, &TestTableLockCustomer{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableLockCustomer{}, &TestTableLockOrder{}))
+	require.NoError(t, db.Create(&TestTableLockCustomer{ID: 1, Name: "c"}).Error)
+	require.NoError(t, db.Create(&[]TestTableLockOrder{
+		{ID: 1, CustomerID: 1, Amount: 10}, {ID: 2, CustomerID: 1, Amount: 20}, {ID: 3, CustomerID: 1, Amount: 30},
+	}).Error)
+
+	tx := db.Begin()
+	require.NoError(t, tx.Error)
+	defer tx.Rollback()
+	var locked []TestTableLockOrder
+	require.NoError(t, tx.Clauses(skipLocked).Order("id").Limit(2).Find(&locked).Error)
+	require.Len(t, locked, 2)
+	assert.EqualValues(t, 1, locked[0].ID)
+	assert.EqualValues(t, 2, locked[1].ID)
+
+	other := db.Begin()
+	require.NoError(t, other.Error)
+	defer other.Rollback()
+	var next []TestTableLockOrder
+	require.NoError(t, other.Clauses(skipLocked).Where("id > ?", 2).Order("id").Limit(1).Find(&next).Error)
+	require.Len(t, next, 1)
+	assert.EqualValues(t, 3, next[0].ID)
+	err := other.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: "NOWAIT"}).Order("id").Limit(1).Find(&next).Error
+	assert.ErrorContains(t, err, "ORA-00054", "expecting the first row locked by the other transaction")
+}
+
+func TestOptimizerHints(t *testing.T) {
+	stmt := &gorm.Statement{}
+	assert.Equal(t, "", optimizerHint(stmt))
+	stmt.Settings.Store("gorm:oracle_hint", []string{" /*+ INDEX(t idx_foo) */", "", "FIRST_ROWS(10)"})
+	assert.Equal(t, "INDEX(t idx_foo) FIRST_ROWS(10)", optimizerHint(stmt))
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Set("gorm:oracle_hint", "INDEX(test_lock_order) ").Where("amount > ?", 0).Find(&orders)
+	})
+	assert.True(t, strings.HasPrefix(toSQL, "SELECT /*+ INDEX(test_lock_order) */ "), "expecting the hint right after SELECT: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Set("gorm:oracle_hint", []string{"/*+ FIRST_ROWS(2) */", "NO_PARALLEL"}).Order("id").Limit(2).Offset(1).Find(&orders)
+	})
+	assert.Equal(t, 1, strings.Count(toSQL, "/*+"), "expecting a single hint comment: %s", toSQL)
+	assert.Contains(t, toSQL, "SELECT /*+ FIRST_ROWS(2) NO_PARALLEL */ ", "expecting the hints joined: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Find(&orders)
+	})
+	assert.NotContains(t, toSQL, "/*+", "expecting no hint unless set: %s", toSQL)
+
+	_ = db.Migrator().DropTable(&TestTableLockOrder{}, &TestTableLockCustomer{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableLockCustomer{}, &TestTableLockOrder{}))
+	require.NoError(t, db.Create(&TestTableLockCustomer{ID: 1, Name: "c"}).Error)
+	require.NoError(t, db.Create(&[]TestTableLockOrder{{ID: 1, CustomerID: 1, Amount: 10}, {ID: 2, CustomerID: 1, Amount: 20}}).Error)
+
+	var orders []TestTableLockOrder
+	require.NoError(t, db.Set("gorm:oracle_hint", "FIRST_ROWS(1)").Order("id").Limit(1).Offset(1).Find(&orders).Error)
+	require.Len(t, orders, 1)
+	assert.EqualValues(t, 2, orders[0].ID)
+}
+
+func TestTableHint(t *testing.T) {
+	assert.Equal(t, tableHints{"FULL(u)"}, TableHint("u", " FULL "))
+	assert.Equal(t, tableHints{"INDEX(u idx_users_name)"}, TableHint("u", "INDEX(idx_users_name)"))
+	assert.Equal(t, tableHints{"INDEX(U idx_users_name)"}, TableHint("u", "INDEX(U idx_users_name)"))
+	assert.Equal(t, tableHints{"INDEX_DESC(users idx_users_name)"}, TableHint("users", "INDEX_DESC( idx_users_name)"))
+
+	stmt := &gorm.Statement{Clauses: map[string]clause.Clause{}}
+	stmt.AddClause(TableHint("o", "FULL").(clause.Interface))
+	stmt.AddClause(TableHint("o", "PARALLEL(4)").(clause.Interface))
+	stmt.Settings.Store("gorm:oracle_hint", "FIRST_ROWS(10)")
+	assert.Equal(t, "FIRST_ROWS(10) FULL(o) PARALLEL(o 4)", optimizerHint(stmt))
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Table("test_lock_order o").Clauses(TableHint("o", "INDEX(test_lock_order_pk)")).Where("o.amount > ?", 0).Find(&orders)
+	})
+	assert.True(t, strings.HasPrefix(toSQL, "SELECT /*+ INDEX(o test_lock_order_pk) */ "), "expecting the hint right after SELECT: %s", toSQL)
+	assert.Contains(t, toSQL, " FROM test_lock_order o WHERE ", "expecting the table left as written: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Clauses(TableHint("test_lock_order", "FULL")).Order("id").Limit(2).Offset(1).Find(&orders)
+	})
+	assert.Equal(t, 1, strings.Count(toSQL, "/*+"), "expecting a single hint comment: %s", toSQL)
+	assert.Contains(t, toSQL, "SELECT /*+ FULL(test_lock_order) */ ", "expecting the hint in the paged query: %s", toSQL)
+}
+
+func TestParallelHint(t *testing.T) {
+	d := Dialector{Config: &Config{namingStrategy: &NamingStrategy{}}}
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Clauses: map[string]clause.Clause{}, Table: "orders"}
+	stmt.AddClause(clause.Delete{})
+	d.RewriteDML(stmt.Clauses["DELETE"], stmt)
+	assert.Equal(t, "DELETE", stmt.SQL.String(), "expecting no hint without a Parallel clause")
+
+	stmt.SQL.Reset()
+	stmt.AddClause(Parallel(2).(clause.Interface))
+	stmt.AddClause(Parallel(4).(clause.Interface))
+	d.RewriteDML(stmt.Clauses["DELETE"], stmt)
+	assert.Equal(t, "DELETE /*+ ENABLE_PARALLEL_DML PARALLEL(4) */", stmt.SQL.String())
+
+	stmt.SQL.Reset()
+	stmt.AddClause(clause.Update{})
+	d.RewriteDML(stmt.Clauses["UPDATE"], stmt)
+	assert.Equal(t, "UPDATE /*+ ENABLE_PARALLEL_DML PARALLEL(4) */ ORDERS", stmt.SQL.String())
+
+	stmt.SQL.Reset()
+	stmt.AddClause(Parallel(0).(clause.Interface))
+	stmt.AddClause(clause.Insert{})
+	d.RewriteDML(stmt.Clauses["INSERT"], stmt)
+	assert.Equal(t, "INSERT /*+ ENABLE_PARALLEL_DML PARALLEL */ INTO ORDERS", stmt.SQL.String())
+	assert.Equal(t, "PARALLEL", optimizerHint(stmt))
+
+	stmt.SQL.Reset()
+	stmt.AddClause(Append().(clause.Interface))
+	d.RewriteDML(stmt.Clauses["INSERT"], stmt)
+	assert.Equal(t, "INSERT /*+ APPEND ENABLE_PARALLEL_DML PARALLEL */ INTO ORDERS", stmt.SQL.String())
+	stmt.SQL.Reset()
+	d.RewriteDML(stmt.Clauses["UPDATE"], stmt)
+	assert.Equal(t, "UPDATE /*+ ENABLE_PARALLEL_DML PARALLEL */ ORDERS", stmt.SQL.String(), "expecting APPEND on inserts only")
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Clauses(Parallel(4)).Where("amount > ?", 0).Find(&orders)
+	})
+	assert.True(t, strings.HasPrefix(toSQL, "SELECT /*+ PARALLEL(4) */ "), "expecting the hint right after SELECT: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(Parallel(4)).Create(&TestTableLockOrder{ID: 1, CustomerID: 1, Amount: 10})
+	})
+	assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "INSERT /*+ ENABLE_PARALLEL_DML PARALLEL(4) */ INTO TEST_LOCK_ORDER "), "expecting the hint right after INSERT: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(Parallel(4)).Model(&TestTableLockOrder{}).Where("amount > ?", 0).Update("amount", 0)
+	})
+	assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "UPDATE /*+ ENABLE_PARALLEL_DML PARALLEL(4) */ TEST_LOCK_ORDER SET "), "expecting the hint right after UPDATE: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(Parallel(4)).Where("amount > ?", 0).Delete(&TestTableLockOrder{})
+	})
+	assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "DELETE /*+ ENABLE_PARALLEL_DML PARALLEL(4) */ FROM TEST_LOCK_ORDER "), "expecting the hint right after DELETE: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(Parallel(4), clause.OnConflict{UpdateAll: true}).Create(&[]TestTableLockOrder{{ID: 1, CustomerID: 1, Amount: 10}})
+	})
+	assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "MERGE /*+ ENABLE_PARALLEL_DML PARALLEL(4) */ INTO TEST_LOCK_ORDER "), "expecting the hint right after MERGE: %s", toSQL)
+
+	_ = db.Migrator().DropTable(&TestTableLockOrder{}, &TestTableLockCustomer{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableLockCustomer{}, &TestTableLockOrder{}))
+	require.NoError(t, db.Create(&TestTableLockCustomer{ID: 1, Name: "c"}).Error)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(Parallel(2)).Create(&[]TestTableLockOrder{{ID: 1, CustomerID: 1, Amount: 10}, {ID: 2, CustomerID: 1, Amount: 20}}).Error
+	}))
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(Parallel(2)).Model(&TestTableLockOrder{}).Where("amount > ?", 15).Update("amount", 25).Error
+	}))
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(Parallel(2)).Where("amount < ?", 15).Delete(&TestTableLockOrder{}).Error
+	}))
+
+	var orders []TestTableLockOrder
+	require.NoError(t, db.Clauses(Parallel(2)).Order("id").Find(&orders).Error)
+	require.Len(t, orders, 1)
+	assert.EqualValues(t, 2, orders[0].ID)
+	assert.Equal(t, 25, orders[0].Amount)
+}
+
+func TestWithClause(t *testing.T) {
+	d := Dialector{Config: &Config{namingStrategy: &NamingStrategy{}}}
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Clauses: map[string]clause.Clause{}}
+	stmt.AddClause(With{CTEs: []CTE{{Name: "n", Columns: []string{"i"}, Query: clause.Expr{SQL: "SELECT 1 FROM DUAL UNION ALL SELECT i + 1 FROM n WHERE i < ?", Vars: []any{5}}}}})
+	stmt.AddClause(With{CTEs: []CTE{{Name: "m", Query: clause.Expr{SQL: "SELECT i FROM n"}}}})
+	stmt.Clauses["WITH"].Build(stmt)
+	assert.Equal(t, "WITH N(I) AS (SELECT 1 FROM DUAL UNION ALL SELECT i + 1 FROM n WHERE i < :1), M AS (SELECT i FROM n)", stmt.SQL.String())
+	assert.Equal(t, []interface{}{5}, stmt.Vars)
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	bigOrders := With{CTEs: []CTE{{Name: "big_orders", Query: db.Model(&TestTableLockOrder{}).Where("amount > ?", 15)}}}
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Clauses(bigOrders).Table("big_orders").Where("customer_id = ?", 1).Limit(2).Offset(1).Find(&orders)
+	})
+	upperSQL := strings.ToUpper(toSQL)
+	assert.Regexp(t, `^(SELECT \* FROM \(SELECT T\.\*, ROW_NUMBER\(\) .* FROM \()?WITH BIG_ORDERS AS \(SELECT \* FROM TEST_LOCK_ORDER WHERE AMOUNT > 15\) SELECT \* FROM BIG_ORDERS WHERE CUSTOMER_ID = 1`, upperSQL,
+		"expecting the CTE to head the paged query: %s", toSQL)
+
+	numbers := With{CTEs: []CTE{{Name: "n", Columns: []string{"i"}, Query: clause.Expr{SQL: "SELECT 1 FROM DUAL UNION ALL SELECT i + 1 FROM n WHERE i < ?", Vars: []any{5}}}}}
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var rows []map[string]interface{}
+		return tx.Clauses(numbers).Table("n").Find(&rows)
+	})
+	assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "WITH N(I) AS (SELECT 1 FROM DUAL UNION ALL SELECT I + 1 FROM N WHERE I < 5) SELECT * FROM N"),
+		"expecting the recursive CTE with its column list: %s", toSQL)
+
+	tree := With{CTEs: []CTE{{Name: "tree", Query: clause.Expr{SQL: "SELECT LEVEL AS depth FROM DUAL CONNECT BY LEVEL <= ?", Vars: []any{4}}}}}
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var count int64
+		return tx.Clauses(tree).Table("tree").Count(&count)
+	})
+	assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "WITH TREE AS (SELECT LEVEL AS DEPTH FROM DUAL CONNECT BY LEVEL <= 4) SELECT COUNT(*) FROM TREE"),
+		"expecting the CONNECT BY query factored out: %s", toSQL)
+
+	_ = db.Migrator().DropTable(&TestTableLockOrder{}, &TestTableLockCustomer{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableLockCustomer{}, &TestTableLockOrder{}))
+	require.NoError(t, db.Create(&TestTableLockCustomer{ID: 1, Name: "c"}).Error)
+	require.NoError(t, db.Create(&[]TestTableLockOrder{
+		{ID: 1, CustomerID: 1, Amount: 10}, {ID: 2, CustomerID: 1, Amount: 20}, {ID: 3, CustomerID: 1, Amount: 30}, {ID: 4, CustomerID: 1, Amount: 40},
+	}).Error)
+
+	var orders []TestTableLockOrder
+	require.NoError(t, db.Clauses(bigOrders).Table("big_orders").Where("customer_id = ?", 1).Order("id").Limit(2).Offset(1).Find(&orders).Error)
+	require.Len(t, orders, 2)
+	assert.EqualValues(t, 3, orders[0].ID)
+	assert.EqualValues(t, 4, orders[1].ID)
+
+	var count int64
+	require.NoError(t, db.Clauses(tree).Table("tree").Count(&count).Error)
+	assert.EqualValues(t, 4, count)
+}
+
+type TestTableBinaryMatch struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string `gorm:"size:32"`
+}
+
+func (TestTableBinaryMatch) TableName() string {
+	return "test_binary_match"
+}
+
+func TestCaseSensitive(t *testing.T) {
+	d := Dialector{Config: &Config{namingStrategy: &NamingStrategy{}}}
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Clauses: map[string]clause.Clause{}}
+	stmt.AddClause(clause.Where{Exprs: binaryConditions([]clause.Expression{
+		clause.Eq{Column: clause.Column{Table: "T", Name: "NAME"}, Value: "Alice"},
+		clause.Expr{SQL: "code != ?", Vars: []any{"x"}},
+		clause.AndConditions{Exprs: []clause.Expression{clause.IN{Column: "TAG", Values: []any{"a", "B"}}}},
+		clause.Eq{Column: "ID", Value: 1},
+		clause.Expr{SQL: "name LIKE ?", Vars: []any{"A%"}},
+		clause.Expr{SQL: "tag IN ?", Vars: []any{[]string{"c"}}},
+		clause.Expr{SQL: "id IN ?", Vars: []any{[]int{2}}},
+	})})
+	stmt.Clauses["WHERE"].Build(stmt)
+	assert.Equal(t, "WHERE NLSSORT(T.NAME,'NLS_SORT=BINARY') = NLSSORT(:1,'NLS_SORT=BINARY') AND "+
+		"NLSSORT(code,'NLS_SORT=BINARY') <> NLSSORT(:2,'NLS_SORT=BINARY') AND "+
+		"NLSSORT(TAG,'NLS_SORT=BINARY') IN (NLSSORT(:3,'NLS_SORT=BINARY'),NLSSORT(:4,'NLS_SORT=BINARY')) AND "+
+		"ID = :5 AND name LIKE :6 AND NLSSORT(tag,'NLS_SORT=BINARY') IN (NLSSORT(:7,'NLS_SORT=BINARY')) AND id IN (:8)", stmt.SQL.String())
+	assert.Equal(t, []interface{}{"Alice", "x", "a", "B", 1, "A%", "c", 2}, stmt.Vars)
+
+	db, err := dbIgnoreCase, dbErrors[1]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableBinaryMatch{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableBinaryMatch{}))
+	require.NoError(t, db.Create(&[]TestTableBinaryMatch{{ID: 1, Name: "Alice"}, {ID: 2, Name: "ALICE"}, {ID: 3, Name: "bob"}}).Error)
+
+	var rows []TestTableBinaryMatch
+	require.NoError(t, db.Where("name = ?", "alice").Find(&rows).Error)
+	assert.Len(t, rows, 2, "expecting the connection to ignore case")
+
+	rows = nil
+	require.NoError(t, db.Scopes(CaseSensitive).Where("name = ?", "Alice").Find(&rows).Error)
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, 1, rows[0].ID)
+
+	rows = nil
+	require.NoError(t, db.Scopes(CaseSensitive).Where(&TestTableBinaryMatch{Name: "ALICE"}).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, 2, rows[0].ID)
+
+	var count int64
+	require.NoError(t, db.Scopes(CaseSensitive).Model(&TestTableBinaryMatch{}).Where("name IN ?", []string{"alice", "BOB"}).Count(&count).Error)
+	assert.EqualValues(t, 0, count)
+	require.NoError(t, db.Scopes(CaseSensitive).Model(&TestTableBinaryMatch{}).Where("name IN ?", []string{"Alice", "bob"}).Count(&count).Error)
+	assert.EqualValues(t, 2, count)
+}
+
+type TestTableReadDefault struct {
+	ID    uint64 `gorm:"primaryKey"`
+	Score int    `gorm:"readDefault:0"`
+	Label string `gorm:"size:10;readDefault:'n/a'"`
+}
+
+func (TestTableReadDefault) TableName() string {
+	return "test_read_default"
+}
+
+func TestReadDefault(t *testing.T) {
+	sch, err := schema.Parse(&TestTableReadDefault{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	assert.Equal(t, "", readExpression(sch.LookUpField("ID")))
+	assert.Equal(t, "NVL(?,0)", readExpression(sch.LookUpField("Score")))
+	assert.Equal(t, "NVL(?,'n/a')", readExpression(sch.LookUpField("Label")))
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var rows []TestTableReadDefault
+		return tx.Find(&rows)
+	})
+	assert.Contains(t, toSQL, `NVL("TEST_READ_DEFAULT"."SCORE",0) "SCORE"`, "expecting the column read through NVL: %s", toSQL)
+
+	_ = db.Migrator().DropTable(&TestTableReadDefault{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableReadDefault{}))
+	require.NoError(t, db.Omit("Score", "Label").Create(&TestTableReadDefault{ID: 1}).Error)
+	require.NoError(t, db.Create(&TestTableReadDefault{ID: 2, Score: 7, Label: "x"}).Error)
+
+	var rows []TestTableReadDefault
+	require.NoError(t, db.Order("id").Find(&rows).Error)
+	require.Len(t, rows, 2)
+	assert.Equal(t, TestTableReadDefault{ID: 1, Score: 0, Label: "n/a"}, rows[0])
+	assert.Equal(t, TestTableReadDefault{ID: 2, Score: 7, Label: "x"}, rows[1])
+
+	var nulls int64
+	require.NoError(t, db.Model(&TestTableReadDefault{}).Where("score IS NULL").Count(&nulls).Error)
+	assert.EqualValues(t, 1, nulls, "expecting the stored value left NULL")
+}
+
+func TestSample(t *testing.T) {
+	d := Dialector{Config: &Config{namingStrategy: &NamingStrategy{}}}
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Clauses: map[string]clause.Clause{}, Table: "orders"}
+	stmt.AddClause(Sample(2.5).(clause.Interface))
+	d.RewriteFrom(clause.Clause{Name: "FROM", Expression: clause.From{}}, stmt)
+	assert.Equal(t, "FROM ORDERS SAMPLE(2.5)", stmt.SQL.String())
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Clauses(Sample(10)).Where("amount > ?", 0).Limit(5).Find(&orders)
+	})
+	assert.Contains(t, strings.ToUpper(toSQL), "FROM TEST_LOCK_ORDER SAMPLE(10) WHERE AMOUNT > 0", "expecting the sample after the table: %s", toSQL)
+
+	toSQL = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var orders []TestTableLockOrder
+		return tx.Clauses(Sample(10)).Joins("Customer").Find(&orders)
+	})
+	assert.Contains(t, strings.ToUpper(toSQL), "FROM TEST_LOCK_ORDER SAMPLE(10) LEFT JOIN", "expecting the sample ahead of the joins: %s", toSQL)
+
+	var orders []TestTableLockOrder
+	assert.ErrorContains(t, db.Clauses(Sample(100)).Find(&orders).Error, "out of range")
+
+	_ = db.Migrator().DropTable(&TestTableLockOrder{}, &TestTableLockCustomer{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableLockCustomer{}, &TestTableLockOrder{}))
+	require.NoError(t, db.Create(&TestTableLockCustomer{ID: 1, Name: "c"}).Error)
+	const total = 2000
+	rows := make([]TestTableLockOrder, total)
+	for i := range rows {
+		rows[i] = TestTableLockOrder{ID: uint64(i + 1), CustomerID: 1, Amount: i % 2}
+	}
+	require.NoError(t, db.CreateInBatches(&rows, 500).Error)
+
+	var sampled int64
+	require.NoError(t, db.Model(&TestTableLockOrder{}).Clauses(Sample(50)).Count(&sampled).Error)
+	assert.InDelta(t, total/2, sampled, total/5, "expecting about half the rows")
+
+	var filtered []TestTableLockOrder
+	require.NoError(t, db.Clauses(Sample(50)).Where("amount = ?", 1).Limit(100).Find(&filtered).Error)
+	assert.NotEmpty(t, filtered)
+	assert.LessOrEqual(t, len(filtered), 100)
+	for _, o := range filtered {
+		assert.Equal(t, 1, o.Amount)
+	}
+}
+
+func TestDeleteWithJoins(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&testFKChild{}, &testFKParent{})
+	require.NoError(t, db.Migrator().AutoMigrate(&testFKParent{}, &testFKChild{}))
+	require.NoError(t, db.Create(&[]testFKParent{{ID: 1, Name: "keep"}, {ID: 2, Name: "drop"}}).Error)
+	require.NoError(t, db.Create(&[]testFKChild{{ID: 1, ParentID: 1}, {ID: 2, ParentID: 2}, {ID: 3, ParentID: 2}}).Error)
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Joins("Parent").Where("1 = 1").Delete(&testFKChild{})
+	})
+	assert.Contains(t, toSQL, "WHERE ROWID IN (SELECT ", "expecting the join moved into a subquery: %s", toSQL)
+	assert.Contains(t, toSQL, ".ROWID FROM ", "expecting only ROWID selected: %s", toSQL)
+	assert.NotContains(t, toSQL, "__", "expecting no joined columns selected: %s", toSQL)
+
+	result := db.Joins("JOIN test_fk_parent p ON p.id = test_fk_child.parent_id").
+		Where("p.name = ?", "drop").
+		Delete(&testFKChild{})
+	require.NoError(t, result.Error, "expecting no error")
+	assert.EqualValues(t, 2, result.RowsAffected)
+
+	var remaining []testFKChild
+	require.NoError(t, db.Order("id").Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	assert.EqualValues(t, 1, remaining[0].ID)
+
+	result = db.Joins("JOIN test_fk_parent p ON p.id = test_fk_child.parent_id").Delete(&testFKChild{})
+	assert.ErrorIs(t, result.Error, gorm.ErrMissingWhereClause, "expecting joins alone not to count as conditions")
+}
+
 // ==== UUID/ULID types ====
 
 func TestGUUIDType(t *testing.T) {
@@ -574,7 +1315,7 @@ func TestGUUIDType(t *testing.T) {
 	require.EqualValuesf(t, test00, test4, "expecting User to match")
 }
 
-func TestGUUIDTypePluck(t *testing.T) {
+func TestGUUIDNullRef(t *testing.T) {
 	ctx := currentContext()
 	db := dbNamingCase
 	if db == nil {
@@ -586,34 +1327,407 @@ func TestGUUIDTypePluck(t *testing.T) {
 	err := db.Migrator().AutoMigrate(TestTableGUUID{})
 	require.NoError(t, err, "expecting no error")
 
-	test0 := &TestTableGUUID{
-		Name: "test0",
-		User: uuid.New(),
-	}
-	test1 := &TestTableGUUID{
-		Name: "test1",
-		User: uuid.New(),
+	ref := uuid.New()
+	rows := []*TestTableGUUID{
+		{Name: "null0", User: uuid.New()},
+		{Name: "ref0", User: uuid.New(), Ref: &ref},
 	}
-	result := db.Create([]*TestTableGUUID{test0, test1})
+	result := db.Create(rows)
 	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValues(t, int64(2), result.RowsAffected, "expecting two records created")
+	require.EqualValues(t, 2, result.RowsAffected)
 
-	var finds []TestTableGUUID
-	result = db.Order("ID").Find(&finds)
+	single := &TestTableGUUID{Name: "null1", User: uuid.New()}
+	require.NoError(t, db.Create(single).Error, "expecting no error")
+
+	var nulls []TestTableGUUID
+	result = db.Where(map[string]any{"ref": (*uuid.UUID)(nil)}).Order("id").Find(&nulls)
 	require.NoError(t, result.Error, "expecting no error")
-	require.Len(t, finds, 2)
-	require.EqualValues(t, test0.User, finds[0].User)
-	require.EqualValues(t, test1.User, finds[1].User)
+	require.Len(t, nulls, 2)
+	require.Nil(t, nulls[0].Ref)
+	require.Nil(t, nulls[1].Ref)
 
-	var users []uuid.UUID
-	result = db.Model(&TestTableGUUID{}).Order("ID").Pluck("USER", &users)
+	nulls = nil
+	result = db.Where("ref = ?", (*uuid.UUID)(nil)).Order("id").Find(&nulls)
 	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValues(t, []uuid.UUID{test0.User, test1.User}, users)
-}
+	require.Len(t, nulls, 2)
 
-func TestGUUIDPrimaryTypePluckWithAlias(t *testing.T) {
-	ctx := currentContext()
-	db := dbNamingCase
+	result = db.Model(&TestTableGUUID{}).Where("id = ?", rows[1].ID).Update("ref", (*uuid.UUID)(nil))
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValues(t, 1, result.RowsAffected)
+
+	var count int64
+	require.NoError(t, db.Model(&TestTableGUUID{}).Where("ref IS NULL").Count(&count).Error)
+	require.EqualValues(t, 3, count)
+
+	result = db.Model(single).Updates(map[string]any{"ref": &ref})
+	require.NoError(t, result.Error, "expecting no error")
+	found := &TestTableGUUID{}
+	require.NoError(t, db.Where(map[string]any{"ref": ref}).First(found).Error)
+	require.Equal(t, single.ID, found.ID)
+}
+
+func TestGUUIDStringQuery(t *testing.T) {
+	ctx := currentContext()
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(ctx)
+	_ = db.Migrator().DropTable(&TestTableGUUID{})
+	err := db.Migrator().AutoMigrate(TestTableGUUID{})
+	require.NoError(t, err, "expecting no error")
+
+	ref := uuid.New()
+	want := &TestTableGUUID{Name: "test0", User: uuid.New(), Ref: &ref}
+	require.NoError(t, db.Create([]*TestTableGUUID{want, {Name: "test1", User: uuid.New()}}).Error)
+
+	found := &TestTableGUUID{}
+	result := db.Where(`"USER" = ?`, want.User.String()).First(found)
+	require.NoError(t, result.Error, "expecting no error")
+	require.Equal(t, want.ID, found.ID)
+
+	found = &TestTableGUUID{}
+	result = db.Where("ref = ?", strings.ReplaceAll(ref.String(), "-", "")).First(found)
+	require.NoError(t, result.Error, "expecting no error")
+	require.Equal(t, want.ID, found.ID)
+
+	found = &TestTableGUUID{}
+	result = db.Where(map[string]any{"user": strings.ToUpper(want.User.String())}).First(found)
+	require.NoError(t, result.Error, "expecting no error")
+	require.Equal(t, want.ID, found.ID)
+}
+
+func TestRawToUUID(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+	_ = db.Migrator().DropTable(&TestTableGUUID{})
+	require.NoError(t, db.Migrator().AutoMigrate(TestTableGUUID{}), "expecting no error")
+
+	want := &TestTableGUUID{Name: "test0", User: uuid.New()}
+	require.NoError(t, db.Create(want).Error, "expecting no error")
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&TestTableGUUID{}).Select("?", RawToUUID("user")).Find(&[]string{})
+	})
+	assert.Contains(t, strings.ToUpper(toSQL), `REGEXP_REPLACE(LOWER(RAWTOHEX("USER"))`, "expecting formatted column: %s", toSQL)
+
+	var formatted string
+	require.NoError(t, db.Model(&TestTableGUUID{}).
+		Select("?", RawToUUID("user")).
+		Where("id = ?", want.ID).
+		Scan(&formatted).Error, "expecting no error")
+	assert.Equal(t, want.User.String(), formatted)
+
+	var id uint64
+	require.NoError(t, db.Model(&TestTableGUUID{}).
+		Select("id").
+		Where("? = ?", clause.Column{Name: "user"}, UUIDToRaw(strings.ToUpper(want.User.String()))).
+		Scan(&id).Error, "expecting no error")
+	assert.Equal(t, want.ID, id)
+}
+
+func Test_lookUpEqField(t *testing.T) {
+	sch, err := schema.Parse(&TestTableGUUID{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+
+	for _, sql := range []string{"ref = ?", `"ref"=?`, "test_user_uuid.ref = ?", "REF = ?"} {
+		f := lookUpEqField(sch, clause.Expr{SQL: sql, Vars: []any{1}})
+		require.NotNil(t, f, sql)
+		require.Equal(t, "Ref", f.Name, sql)
+	}
+	for _, sql := range []string{"ref >= ?", "ref != ?", "ref = ? OR name = ?", "ref = name", "upper(name) = ?"} {
+		require.Nil(t, lookUpEqField(sch, clause.Expr{SQL: sql, Vars: []any{1}}), sql)
+	}
+
+	u := uuid.New()
+	field := sch.LookUpField("Ref")
+	want := clause.Expr{SQL: "HEXTORAW(?)", Vars: []any{fmt.Sprintf("%x", u[:])}}
+	require.Equal(t, want, convertToLiteral(nil, u.String(), reflect.Value{}, field))
+	require.Equal(t, "not-a-uuid", convertToLiteral(nil, "not-a-uuid", reflect.Value{}, field))
+}
+
+func Test_convertToBind_NullRaw16(t *testing.T) {
+	sch, err := schema.Parse(&TestTableGUUID{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	field := sch.LookUpField("Ref")
+	require.NotNil(t, field)
+
+	require.Equal(t, []byte(nil), convertToBind(nil, field, (*uuid.UUID)(nil)))
+	require.Equal(t, []byte(nil), convertToBind(nil, field, nil))
+
+	require.Equal(t, []byte(nil), castRaw16((*uuid.UUID)(nil)))
+	require.Equal(t, []byte(nil), castValue(nil, (*uuid.UUID)(nil), "RAW(16)", 0, false))
+	require.Equal(t, []byte(nil), castSelectVar((*uuid.UUID)(nil)))
+
+	require.True(t, isNullRaw16(field, (*uuid.UUID)(nil)))
+	require.True(t, isNullRaw16(field, nil))
+	require.False(t, isNullRaw16(field, uuid.New()))
+
+	u := uuid.New()
+	require.Equal(t, &u, convertToBind(nil, field, &u))
+	require.Equal(t, clause.Expr{SQL: "HEXTORAW(?)", Vars: []any{fmt.Sprintf("%x", u[:])}}, castRaw16(&u))
+}
+
+func Test_castValue_sqlNull(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tt := range []struct {
+		name     string
+		val      any
+		dataType string
+		want     any
+	}{
+		{"string", sql.NullString{String: "a", Valid: true}, "VARCHAR2(10)", clause.Expr{SQL: "CAST(? AS VARCHAR2(10))", Vars: []any{"a"}}},
+		{"string null", sql.NullString{}, "VARCHAR2(10)", clause.Expr{SQL: "CAST(NULL AS VARCHAR2(10))"}},
+		{"int64", sql.NullInt64{Int64: 7, Valid: true}, "NUMBER", int64(7)},
+		{"int64 null", &sql.NullInt64{}, "INTEGER", clause.Expr{SQL: "CAST(NULL AS INTEGER)"}},
+		{"int32 null", sql.NullInt32{}, "NUMBER(10)", clause.Expr{SQL: "CAST(NULL AS NUMBER(10))"}},
+		{"int32", sql.NullInt32{Int32: 7, Valid: true}, "NUMBER", int32(7)},
+		{"int16", sql.NullInt16{Int16: 7, Valid: true}, "NUMBER", int16(7)},
+		{"byte", sql.NullByte{Byte: 7, Valid: true}, "NUMBER", byte(7)},
+		{"float64", sql.NullFloat64{Float64: 1.5, Valid: true}, "BINARY_DOUBLE", 1.5},
+		{"bool", sql.NullBool{Bool: true, Valid: true}, "NUMBER(1)", 1},
+		{"bool null", sql.NullBool{}, "NUMBER(1)", clause.Expr{SQL: "CAST(NULL AS NUMBER(1))"}},
+		{"time", sql.NullTime{Time: ts, Valid: true}, "DATE", castTime(ts, "DATE", 0, nil)},
+		{"time null", sql.NullTime{}, "DATE", clause.Expr{SQL: "CAST(NULL AS DATE)"}},
+		{"generic", sql.Null[string]{V: "a", Valid: true}, "VARCHAR2(10)", clause.Expr{SQL: "CAST(? AS VARCHAR2(10))", Vars: []any{"a"}}},
+		{"generic null", sql.Null[int]{}, "NUMBER", clause.Expr{SQL: "CAST(NULL AS NUMBER)"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, castValue(nil, tt.val, tt.dataType, 0, false))
+		})
+	}
+}
+
+// skuCode is stored upper case, as its Value returns it
+type skuCode string
+
+func (c skuCode) Value() (driver.Value, error) {
+	return strings.ToUpper(string(c)), nil
+}
+
+func (c *skuCode) Scan(val interface{}) error {
+	switch v := val.(type) {
+	case string:
+		*c = skuCode(v)
+	case []byte:
+		*c = skuCode(v)
+	default:
+		return fmt.Errorf("cannot scan %T into skuCode", val)
+	}
+	return nil
+}
+
+// dayStamp is a day, 2006-01-02, stored as the midnight UTC its Value returns
+type dayStamp string
+
+func (d dayStamp) Value() (driver.Value, error) {
+	return time.Parse(time.DateOnly, string(d))
+}
+
+func (d *dayStamp) Scan(val interface{}) error {
+	t, ok := val.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into dayStamp", val)
+	}
+	*d = dayStamp(t.UTC().Format(time.DateOnly))
+	return nil
+}
+
+// refText is a uuid kept as its text, which its Value returns
+type refText string
+
+func (r refText) Value() (driver.Value, error) {
+	return string(r), nil
+}
+
+// failingValuer is a Valuer whose Value always fails
+type failingValuer struct{}
+
+func (failingValuer) Value() (driver.Value, error) {
+	return nil, errors.New("failing valuer")
+}
+
+type TestTableValuer struct {
+	ID  uint64   `gorm:"primaryKey;autoIncrement:false"`
+	SKU skuCode  `gorm:"size:20"`
+	Day dayStamp `gorm:"type:timestamp with time zone;precision:3"`
+	Ref uuid.UUID
+}
+
+func (TestTableValuer) TableName() string {
+	return "test_valuer"
+}
+
+func Test_convertToLiteral_valuer(t *testing.T) {
+	sch, err := schema.Parse(&TestTableValuer{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: Dialector{Config: &Config{}}}}, Context: context.Background()}
+
+	assert.Equal(t, "ABC-1", convertToLiteral(stmt, skuCode("abc-1"), reflect.Value{}, sch.LookUpField("SKU")))
+	sku := skuCode("abc-1")
+	assert.Equal(t, "ABC-1", convertToLiteral(stmt, &sku, reflect.Value{}, sch.LookUpField("SKU")))
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), convertToLiteral(stmt, dayStamp("2024-01-02"), reflect.Value{}, sch.LookUpField("Day")),
+		"expecting the time returned by Value converted for its column")
+	u := uuid.New()
+	assert.Equal(t, u, convertToLiteral(stmt, u, reflect.Value{}, sch.LookUpField("Ref")), "expecting uuids bound as RAW(16), not replaced by their Value")
+	assert.Equal(t, castRaw16(u.String()), convertToLiteral(stmt, refText(u.String()), reflect.Value{}, sch.LookUpField("Ref")),
+		"expecting a uuid string returned by Value bound as RAW(16)")
+
+	assert.Equal(t, failingValuer{}, convertToLiteral(stmt, failingValuer{}, reflect.Value{}, sch.LookUpField("SKU")))
+	assert.EqualError(t, stmt.Error, "failing valuer")
+	stmt.Error = nil
+	assert.Equal(t, failingValuer{}, castValue(stmt, failingValuer{}, "VARCHAR2(20)", 0, false))
+	assert.EqualError(t, stmt.Error, "failing valuer")
+
+	assert.Equal(t, clause.Expr{SQL: "CAST(? AS VARCHAR2(20))", Vars: []any{"ABC-1"}}, castValue(nil, skuCode("abc-1"), "VARCHAR2(20)", 0, false))
+	assert.Equal(t, castTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "TIMESTAMP(3) WITH TIME ZONE", 0, nil), castValue(nil, dayStamp("2024-01-02"), "TIMESTAMP(3) WITH TIME ZONE", 0, false))
+	assert.Equal(t, clause.Expr{SQL: "CAST(NULL AS XMLTYPE)"}, castValue(nil, XML(""), "XMLTYPE", 0, false), "expecting a nil driver value cast as NULL")
+	assert.Equal(t, castRaw16(u), castValue(nil, u, "RAW(16)", 0, false))
+}
+
+func TestValuerConditions(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableValuer{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableValuer{}))
+	ref := uuid.New()
+	require.NoError(t, db.Create(&[]TestTableValuer{
+		{ID: 1, SKU: "abc-1", Day: "2024-01-02", Ref: ref},
+		{ID: 2, SKU: "xyz-2", Day: "2024-01-03", Ref: uuid.New()},
+	}).Error)
+
+	var got TestTableValuer
+	require.NoError(t, db.Where(&TestTableValuer{SKU: "Abc-1"}).First(&got).Error, "expecting the condition bound by its Value")
+	assert.EqualValues(t, 1, got.ID)
+	assert.Equal(t, skuCode("ABC-1"), got.SKU)
+	assert.Equal(t, dayStamp("2024-01-02"), got.Day)
+
+	require.NoError(t, db.Where("sku = ?", skuCode("xyz-2")).First(&got).Error)
+	assert.EqualValues(t, 2, got.ID)
+	require.NoError(t, db.Where(&TestTableValuer{Day: "2024-01-02"}).First(&got).Error, "expecting the time returned by Value to match")
+	assert.EqualValues(t, 1, got.ID)
+	require.NoError(t, db.Where(&TestTableValuer{Ref: ref}).First(&got).Error)
+	assert.EqualValues(t, 1, got.ID)
+	got = TestTableValuer{}
+	require.NoError(t, db.Where("ref = ?", refText(ref.String())).First(&got).Error, "expecting the uuid string returned by Value bound as RAW(16)")
+	assert.EqualValues(t, 1, got.ID)
+}
+
+type TestTableEmptyString struct {
+	ID   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name string `gorm:"size:20"`
+	Code string `gorm:"size:20;not null"`
+}
+
+func Test_isEmptyString(t *testing.T) {
+	empty, name := "", "x"
+	assert.True(t, isEmptyString(""))
+	assert.True(t, isEmptyString(&empty))
+	assert.False(t, isEmptyString(skuCode("")), "expecting a driver.Valuer left to its Value")
+	assert.False(t, isEmptyString(&name))
+	assert.False(t, isEmptyString((*string)(nil)))
+	assert.False(t, isEmptyString(0))
+
+	sch, err := schema.Parse(&TestTableEmptyString{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	assert.Equal(t, "", convertToBind(nil, sch.LookUpField("Name"), ""), "expecting empty strings bound as they are when unset")
+}
+
+func TestEmptyStringAsNull(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(asNull *bool) { cfg.EmptyStringAsNull = asNull }(cfg.EmptyStringAsNull)
+
+	count := func(query string) (n int) {
+		require.NoError(t, db.Raw(query).Scan(&n).Error)
+		return
+	}
+	_ = db.Migrator().DropTable(&TestTableEmptyString{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableEmptyString{}))
+
+	asNull := true
+	cfg.EmptyStringAsNull = &asNull
+	require.NoError(t, db.Create(&TestTableEmptyString{ID: 1, Name: "", Code: "a"}).Error)
+	require.NoError(t, db.Create(&[]TestTableEmptyString{{ID: 2, Name: "", Code: "b"}, {ID: 3, Name: "c", Code: "c"}}).Error)
+	assert.Equal(t, 2, count(`SELECT COUNT(*) FROM TEST_TABLE_EMPTY_STRING WHERE NAME IS NULL`), "expecting empty strings stored as NULL")
+	require.Error(t, db.Create(&TestTableEmptyString{ID: 4, Code: ""}).Error, "expecting NULL rejected by the NOT NULL column")
+
+	var rows []TestTableEmptyString
+	require.NoError(t, db.Where("name = ?", "").Order("id").Find(&rows).Error)
+	require.Len(t, rows, 2, "expecting = '' to match the NULLs")
+	assert.EqualValues(t, 1, rows[0].ID)
+	require.NoError(t, db.Where(map[string]interface{}{"name": ""}).Find(&rows).Error)
+	assert.Len(t, rows, 2)
+	require.NoError(t, db.Model(&TestTableEmptyString{ID: 3}).Update("name", "").Error)
+	assert.Equal(t, 3, count(`SELECT COUNT(*) FROM TEST_TABLE_EMPTY_STRING WHERE NAME IS NULL`), "expecting an update to '' stored as NULL")
+
+	asNull = false
+	err := db.Create(&TestTableEmptyString{ID: 5, Name: "e", Code: ""}).Error
+	require.Error(t, err, "expecting an empty string refused for a NOT NULL column")
+	assert.Contains(t, err.Error(), "empty string for NOT NULL column")
+	require.NoError(t, db.Create(&TestTableEmptyString{ID: 6, Name: "", Code: "f"}).Error, "expecting a nullable column to take it")
+	err = db.Where("name = ?", "").Find(&rows).Error
+	require.Error(t, err, "expecting a comparison with an empty string refused")
+	assert.Contains(t, err.Error(), "matches no rows")
+	require.NoError(t, db.Where("name IS NULL").Find(&rows).Error)
+	assert.Len(t, rows, 4)
+}
+
+func TestGUUIDTypePluck(t *testing.T) {
+	ctx := currentContext()
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(ctx)
+	_ = db.Migrator().DropTable(&TestTableGUUID{})
+	err := db.Migrator().AutoMigrate(TestTableGUUID{})
+	require.NoError(t, err, "expecting no error")
+
+	test0 := &TestTableGUUID{
+		Name: "test0",
+		User: uuid.New(),
+	}
+	test1 := &TestTableGUUID{
+		Name: "test1",
+		User: uuid.New(),
+	}
+	result := db.Create([]*TestTableGUUID{test0, test1})
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValues(t, int64(2), result.RowsAffected, "expecting two records created")
+
+	var finds []TestTableGUUID
+	result = db.Order("ID").Find(&finds)
+	require.NoError(t, result.Error, "expecting no error")
+	require.Len(t, finds, 2)
+	require.EqualValues(t, test0.User, finds[0].User)
+	require.EqualValues(t, test1.User, finds[1].User)
+
+	var users []uuid.UUID
+	result = db.Model(&TestTableGUUID{}).Order("ID").Pluck("USER", &users)
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValues(t, []uuid.UUID{test0.User, test1.User}, users)
+}
+
+func TestGUUIDPrimaryTypePluckWithAlias(t *testing.T) {
+	ctx := currentContext()
+	db := dbNamingCase
 	if db == nil {
 		t.Log("db is nil!")
 		return
@@ -805,110 +1919,1054 @@ func TestULIDType(t *testing.T) {
 	require.NoError(t, result.Error, "expecting no error")
 }
 
-// ==== Time types ====
+// ==== Collection types ====
 
-func TestTimeTypes(t *testing.T) {
-	ctx := currentContext()
+type TestTableCollection struct {
+	ID     uint64   `gorm:"primaryKey"`
+	Name   string   `gorm:"size:50"`
+	Tags   []string `gorm:"size:100;type:TEST_TAG_LIST;oracle_collection;oracle_collection_limit:10"`
+	Scores []int64  `gorm:"type:TEST_SCORE_TAB;oracle_collection;oracle_collection_kind:table"`
+}
+
+func (TestTableCollection) TableName() string {
+	return "test_collection"
+}
+
+func Test_parseCollectionType(t *testing.T) {
+	sch, err := schema.Parse(&TestTableCollection{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+
+	ct, ok := parseCollectionType(sch.LookUpField("Tags"))
+	require.True(t, ok)
+	assert.Equal(t, "CREATE TYPE TEST_TAG_LIST AS VARRAY(10) OF VARCHAR2(100)", ct.createSQL())
+	assert.Equal(t, "VARCHAR2", ct.ElemBase)
+
+	ct, ok = parseCollectionType(sch.LookUpField("Scores"))
+	require.True(t, ok)
+	assert.Equal(t, "CREATE TYPE TEST_SCORE_TAB AS TABLE OF NUMBER", ct.createSQL())
+	assert.Equal(t, "NUMBER", ct.ElemBase)
+
+	_, ok = parseCollectionType(sch.LookUpField("Name"))
+	assert.False(t, ok)
+}
+
+func TestCollectionTypes(t *testing.T) {
 	db := dbNamingCase
 	if db == nil {
 		t.Log("db is nil!")
 		return
 	}
-	db = db.WithContext(ctx)
-	_ = db.Migrator().DropTable(&TestTableTime{})
-	err := db.Migrator().AutoMigrate(TestTableTime{})
-	require.NoError(t, err, "expecting no error")
+	db = db.WithContext(currentContext())
 
-	nowTime := db.NowFunc().UTC()
-	nowPrimeTime := db.NowFunc().UTC()
-	loc := db.Dialector.(*Dialector).sessionLocation
-	if loc == nil {
-		loc = time.Local
-	}
-	test0Name := "test0"
-	test00Name := "test00"
-	test0 := &TestTableTime{
-		ID:           0,
-		Name:         &test0Name,
-		Date:         nowTime,
-		Timestamp:    nowTime,
-		TimestampTZ:  nowTime,
-		TimestampLTZ: nowTime,
-	}
-	test00 := &TestTableTime{
-		Name:         &test00Name,
-		Date:         nowPrimeTime,
-		Timestamp:    nowPrimeTime,
-		TimestampTZ:  nowPrimeTime,
-		TimestampLTZ: nowPrimeTime,
-	}
-	result := db.Create([]*TestTableTime{test0, test00})
-	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValuesf(t, result.RowsAffected, int64(2), "expecting two records created")
-	require.EqualValuesf(t, test0.ID, int64(1), "expecting ID to be 1")
-	test0Date := test0.Date
-	test0Timestamp := converters.ToTimestamp(test0.Timestamp)
-	test0TimestampTZ := test0.TimestampTZ
-	test0TimestampLTZ := test0.TimestampLTZ
-	id := test0.ID
-	test0 = &TestTableTime{
-		ID: id,
-	}
-	result = db.First(test0)
-	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValuesf(t, test0.ID, int64(1), "expecting ID to be 1")
-	require.EqualValuesf(t, test0Date, test0.Date, "expecting Date to match")
-	require.EqualValuesf(t, test0Timestamp, test0.Timestamp, "expecting Timestamp to match")
-	require.EqualValuesf(t, test0TimestampTZ, test0.TimestampTZ, "expecting TimestampTZ to match")
-	require.EqualValuesf(t, test0TimestampLTZ, test0.TimestampLTZ, "expecting TimestampLTZ to match")
+	_ = db.Migrator().DropTable(&TestTableCollection{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableCollection{}), "expecting no error")
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableCollection{}), "expecting re-migration to be a no-op")
 
-	test1 := &TestTableTime{}
-	result = db.Model(test1).Where(`date`, test0Date).First(test1)
-	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
-	require.EqualValuesf(t, test0Date, test1.Date, "expecting Time to match")
+	model := &TestTableCollection{ID: 1, Name: "Alpha", Tags: []string{"red", "green", "blue"}, Scores: []int64{3, 5, 8}}
+	require.NoError(t, db.Create(model).Error, "expecting no error")
 
-	test1 = &TestTableTime{}
-	result = db.Model(test1).Where(`timestamp`, test0Timestamp).First(test1)
-	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
-	require.EqualValuesf(t, test0Timestamp, test1.Timestamp, "expecting Date to match")
+	var got TestTableCollection
+	require.NoError(t, db.First(&got, 1).Error, "expecting no error")
+	assert.Equal(t, model.Tags, got.Tags)
+	assert.Equal(t, model.Scores, got.Scores)
 
-	test1 = &TestTableTime{}
-	result = db.Model(test1).Where(`timestamp_tz`, test0TimestampTZ).First(test1)
-	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
-	require.EqualValuesf(t, test0TimestampTZ, test1.TimestampTZ, "expecting Date to match")
+	// a new process, with a driver no type was registered with, migrating the existing table
+	dsn, _ := findDbContextInfo(currentContext())
+	connector, err := go_ora.NewDriver().OpenConnector(dsn)
+	require.NoError(t, err)
+	fresh, err := gorm.Open(New(Config{
+		Conn:                    sql.OpenDB(connector),
+		VarcharSizeIsCharLength: true,
+		UseClobForTextType:      true,
+		NamingCaseSensitive:     true,
+	}), &gorm.Config{NamingStrategy: &NamingStrategy{}})
+	require.NoError(t, err)
+	defer func() {
+		if sqlDB, err := fresh.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}()
+	require.NoError(t, fresh.WithContext(currentContext()).AutoMigrate(&TestTableCollection{}), "expecting the existing table migrated")
+	got = TestTableCollection{}
+	require.NoError(t, fresh.WithContext(currentContext()).First(&got, 1).Error, "expecting the collection types registered by AutoMigrate")
+	assert.Equal(t, model.Tags, got.Tags)
+	assert.Equal(t, model.Scores, got.Scores)
+}
 
-	test1 = &TestTableTime{}
-	result = db.Model(test1).Where(`timestamp_ltz`, test0TimestampLTZ).First(test1)
-	require.NoError(t, result.Error, "expecting no error")
-	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
-	require.EqualValuesf(t, test0TimestampLTZ, test1.TimestampLTZ, "expecting Date to match")
+// ==== XML types ====
+
+type TestTableXML struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string `gorm:"size:50"`
+	Doc  XML
 }
 
-func TestTimePtrTypes(t *testing.T) {
-	ctx := currentContext()
+func (TestTableXML) TableName() string {
+	return "test_xml"
+}
+
+func TestXMLType(t *testing.T) {
 	db := dbNamingCase
 	if db == nil {
 		t.Log("db is nil!")
 		return
 	}
-	db = db.WithContext(ctx)
-	_ = db.Migrator().DropTable(&TestTableTimePtrs{})
-	err := db.Migrator().AutoMigrate(TestTableTimePtrs{})
-	require.NoError(t, err, "expecting no error")
+	db = db.WithContext(currentContext())
 
-	nowTime := ptr(db.NowFunc())
-	nowPrimeTime := ptr(db.NowFunc())
-	loc := db.Dialector.(*Dialector).sessionLocation
-	if loc == nil {
-		loc = time.Local
-	}
-	test0Name := "test0"
-	test00Name := "test00"
-	test0 := &TestTableTimePtrs{
-		ID:           0,
+	_ = db.Migrator().DropTable(&TestTableXML{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableXML{}), "expecting no error")
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableXML{}), "expecting re-migration to be a no-op")
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Find(&[]TestTableXML{})
+	})
+	assert.Contains(t, toSQL, "XMLSERIALIZE(CONTENT", "expecting XMLTYPE column to be read as text: %s", toSQL)
+
+	doc := XML(`<order><id>42</id><customer>Alpha</customer></order>`)
+	require.NoError(t, db.Create(&TestTableXML{ID: 1, Name: "Alpha", Doc: doc}).Error, "expecting no error")
+	require.NoError(t, db.Create(&TestTableXML{ID: 2, Name: "Empty"}).Error, "expecting no error")
+
+	var got TestTableXML
+	require.NoError(t, db.First(&got, 1).Error, "expecting no error")
+	assert.Equal(t, doc, got.Doc)
+
+	var empty TestTableXML
+	require.NoError(t, db.First(&empty, 2).Error, "expecting no error")
+	assert.Equal(t, XML(""), empty.Doc)
+
+	var customer string
+	require.NoError(t, db.Model(&TestTableXML{}).
+		Select("XMLCAST(XMLQUERY('/order/customer/text()' PASSING ? RETURNING CONTENT) AS VARCHAR2(50))", clause.Column{Name: "doc"}).
+		Where("? = ?", clause.Column{Name: "id"}, 1).
+		Scan(&customer).Error, "expecting no error")
+	assert.Equal(t, "Alpha", customer)
+
+	var byID TestTableXML
+	require.NoError(t, db.Where("EXTRACTVALUE(?, '/order/id') = ?", clause.Column{Name: "doc"}, "42").First(&byID).Error, "expecting no error")
+	assert.Equal(t, uint64(1), byID.ID)
+
+	require.NoError(t, db.Model(&got).Update("doc", XML(`<order><id>43</id></order>`)).Error, "expecting no error")
+	require.NoError(t, db.First(&got, 1).Error, "expecting no error")
+	assert.Equal(t, XML(`<order><id>43</id></order>`), got.Doc)
+}
+
+type TestTableXMLString struct {
+	ID   uint64  `gorm:"primaryKey"`
+	Doc  string  `gorm:"type:xmltype"`
+	Note *string `gorm:"type:xmltype"`
+}
+
+func (TestTableXMLString) TableName() string {
+	return "test_xml_string"
+}
+
+func TestXMLTypeString(t *testing.T) {
+	assert.Contains(t, Migrator{}.GetTypeAliases("xmltype"), "sys.xmltype")
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableXMLString{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableXMLString{}), "expecting no error")
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableXMLString{}), "expecting re-migration to be a no-op")
+
+	doc := `<order><id>7</id></order>`
+	require.NoError(t, db.Create(&TestTableXMLString{ID: 1, Doc: doc}).Error, "expecting no error")
+
+	var got TestTableXMLString
+	require.NoError(t, db.First(&got, 1).Error, "expecting no error")
+	assert.Equal(t, doc, got.Doc)
+	assert.Nil(t, got.Note)
+
+	note := `<note>rush</note>`
+	require.NoError(t, db.Model(&got).Update("note", &note).Error, "expecting no error")
+	require.NoError(t, db.First(&got, 1).Error, "expecting no error")
+	require.NotNil(t, got.Note)
+	assert.Equal(t, note, *got.Note)
+}
+
+type testXMLItem struct {
+	SKU string `gorm:"column:sku"`
+	Qty int    `gorm:"column:qty"`
+}
+
+func TestXMLTable(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableXML{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableXML{}), "expecting no error")
+
+	require.NoError(t, db.Create(&[]TestTableXML{
+		{ID: 1, Name: "Alpha", Doc: `<order><item qty="2"><sku>A-1</sku></item><item qty="5"><sku>B-2</sku></item></order>`},
+		{ID: 2, Name: "Beta", Doc: `<order><item qty="1"><sku>C-3</sku></item></order>`},
+	}).Error, "expecting no error")
+
+	shred := func(tx *gorm.DB) *gorm.DB {
+		return XMLTable(tx.Model(&TestTableXML{}), "doc", "/order/item",
+			XMLColumn{Name: "sku", Type: "VARCHAR2(20)"},
+			XMLColumn{Name: "qty", Type: "NUMBER", Path: "@qty"},
+		).Where("? = ?", clause.Column{Table: clause.CurrentTable, Name: "id"}, 1)
+	}
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return shred(tx).Scan(&[]testXMLItem{})
+	})
+	assert.Contains(t, toSQL, "XMLTABLE('/order/item' PASSING", "expecting XMLTABLE row source: %s", toSQL)
+	assert.Contains(t, toSQL, "PATH '@qty'", "expecting column path: %s", toSQL)
+
+	var items []testXMLItem
+	require.NoError(t, shred(db).Order(clause.OrderByColumn{Column: clause.Column{Table: xmlTableAlias, Name: "sku"}}).Scan(&items).Error, "expecting no error")
+	assert.Equal(t, []testXMLItem{{SKU: "A-1", Qty: 2}, {SKU: "B-2", Qty: 5}}, items)
+
+	err := XMLTable(db.Model(&TestTableXML{}), "doc", "/order/item").Scan(&items).Error
+	assert.ErrorContains(t, err, "XMLTable requires at least one column")
+}
+
+// ==== JSON types ====
+
+type TestTableJSONDoc struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string `gorm:"size:50"`
+	Doc  string `gorm:"type:CLOB"`
+}
+
+func (TestTableJSONDoc) TableName() string {
+	return "test_json_doc"
+}
+
+func TestJSONPath(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableJSONDoc{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableJSONDoc{}), "expecting no error")
+	require.NoError(t, db.Create(&[]TestTableJSONDoc{
+		{ID: 1, Name: "Alpha", Doc: `{"address":{"city":"Paris","zip":"75001"},"age":31}`},
+		{ID: 2, Name: "Beta", Doc: `{"address":{"city":"Oslo"},"age":45}`},
+		{ID: 3, Name: "Gamma", Doc: `{"age":27}`},
+	}).Error, "expecting no error")
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(JSONExists("doc", "$.address.zip")).Find(&[]TestTableJSONDoc{})
+	})
+	assert.Contains(t, toSQL, "JSON_EXISTS(", "expecting JSON_EXISTS: %s", toSQL)
+	assert.Contains(t, toSQL, "'$.address.zip')", "expecting path literal: %s", toSQL)
+
+	var byCity TestTableJSONDoc
+	require.NoError(t, db.Where("? = ?", JSONValue("doc", "$.address.city"), "Oslo").First(&byCity).Error, "expecting no error")
+	assert.Equal(t, uint64(2), byCity.ID)
+
+	var withZip []TestTableJSONDoc
+	require.NoError(t, db.Where(JSONExists("doc", "$.address.zip")).Find(&withZip).Error, "expecting no error")
+	require.Len(t, withZip, 1)
+	assert.Equal(t, uint64(1), withZip[0].ID)
+
+	type projection struct {
+		Name string `gorm:"column:name"`
+		City string `gorm:"column:city"`
+		Age  int    `gorm:"column:age"`
+	}
+	var rows []projection
+	require.NoError(t, db.Model(&TestTableJSONDoc{}).
+		Select("?, ?, ?", clause.Column{Name: "name"}, JSONValue("doc", "$.address.city").As("city"), JSONValue("doc", "$.age").Returning("NUMBER").As("age")).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}}).
+		Scan(&rows).Error, "expecting no error")
+	assert.Equal(t, []projection{{"Alpha", "Paris", 31}, {"Beta", "Oslo", 45}, {"Gamma", "", 27}}, rows)
+
+	var age int64
+	require.NoError(t, db.Model(&TestTableJSONDoc{}).
+		Select("?", JSONValue("doc", "$.age")).
+		Where("? = ?", clause.Column{Name: "id"}, 1).
+		Scan(&age).Error, "expecting no error")
+	assert.EqualValues(t, 31, age, "expecting VARCHAR2 JSON_VALUE to scan into an integer")
+}
+
+type testJSONItem struct {
+	SKU string  `gorm:"column:sku"`
+	Qty float64 `gorm:"column:qty"`
+}
+
+func TestJSONTable(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableJSONDoc{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableJSONDoc{}), "expecting no error")
+	require.NoError(t, db.Create(&[]TestTableJSONDoc{
+		{ID: 1, Name: "Alpha", Doc: `{"items":[{"sku":"A-1","quantity":2},{"sku":"B-2","quantity":5.5}]}`},
+		{ID: 2, Name: "Beta", Doc: `{"items":[{"sku":"C-3","quantity":1}]}`},
+	}).Error, "expecting no error")
+
+	project := func(tx *gorm.DB) *gorm.DB {
+		return JSONTable(tx.Model(&TestTableJSONDoc{}), "doc", "$.items[*]",
+			JSONColumn{Name: "sku", Type: "VARCHAR2(20)"},
+			JSONColumn{Name: "qty", Type: "NUMBER", Path: "$.quantity"},
+		).Where("? = ?", clause.Column{Table: clause.CurrentTable, Name: "id"}, 1)
+	}
+
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return project(tx).Scan(&[]testJSONItem{})
+	})
+	assert.Contains(t, toSQL, "'$.items[*]' COLUMNS (", "expecting JSON_TABLE row source: %s", toSQL)
+	assert.Contains(t, toSQL, "PATH '$.sku'", "expecting default column path: %s", toSQL)
+
+	var items []testJSONItem
+	require.NoError(t, project(db).Order(clause.OrderByColumn{Column: clause.Column{Table: jsonTableAlias, Name: "sku"}}).Scan(&items).Error, "expecting no error")
+	assert.Equal(t, []testJSONItem{{SKU: "A-1", Qty: 2}, {SKU: "B-2", Qty: 5.5}}, items)
+
+	err := JSONTable(db.Model(&TestTableJSONDoc{}), "doc", "$.items[*]").Scan(&items).Error
+	assert.ErrorContains(t, err, "JSONTable requires at least one column")
+}
+
+// ==== Time types ====
+
+func TestTimeTypes(t *testing.T) {
+	ctx := currentContext()
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(ctx)
+	_ = db.Migrator().DropTable(&TestTableTime{})
+	err := db.Migrator().AutoMigrate(TestTableTime{})
+	require.NoError(t, err, "expecting no error")
+
+	nowTime := db.NowFunc().UTC()
+	nowPrimeTime := db.NowFunc().UTC()
+	loc := db.Dialector.(*Dialector).sessionLocation
+	if loc == nil {
+		loc = time.Local
+	}
+	test0Name := "test0"
+	test00Name := "test00"
+	test0 := &TestTableTime{
+		ID:           0,
+		Name:         &test0Name,
+		Date:         nowTime,
+		Timestamp:    nowTime,
+		TimestampTZ:  nowTime,
+		TimestampLTZ: nowTime,
+	}
+	test00 := &TestTableTime{
+		Name:         &test00Name,
+		Date:         nowPrimeTime,
+		Timestamp:    nowPrimeTime,
+		TimestampTZ:  nowPrimeTime,
+		TimestampLTZ: nowPrimeTime,
+	}
+	result := db.Create([]*TestTableTime{test0, test00})
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValuesf(t, result.RowsAffected, int64(2), "expecting two records created")
+	require.EqualValuesf(t, test0.ID, int64(1), "expecting ID to be 1")
+	test0Date := test0.Date
+	test0Timestamp := converters.ToTimestamp(test0.Timestamp)
+	test0TimestampTZ := test0.TimestampTZ
+	test0TimestampLTZ := test0.TimestampLTZ
+	id := test0.ID
+	test0 = &TestTableTime{
+		ID: id,
+	}
+	result = db.First(test0)
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValuesf(t, test0.ID, int64(1), "expecting ID to be 1")
+	require.EqualValuesf(t, test0Date, test0.Date, "expecting Date to match")
+	require.EqualValuesf(t, test0Timestamp, test0.Timestamp, "expecting Timestamp to match")
+	require.EqualValuesf(t, test0TimestampTZ, test0.TimestampTZ, "expecting TimestampTZ to match")
+	require.EqualValuesf(t, test0TimestampLTZ, test0.TimestampLTZ, "expecting TimestampLTZ to match")
+
+	test1 := &TestTableTime{}
+	result = db.Model(test1).Where(`date`, test0Date).First(test1)
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
+	require.EqualValuesf(t, test0Date, test1.Date, "expecting Time to match")
+
+	test1 = &TestTableTime{}
+	result = db.Model(test1).Where(`timestamp`, test0Timestamp).First(test1)
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
+	require.EqualValuesf(t, test0Timestamp, test1.Timestamp, "expecting Date to match")
+
+	test1 = &TestTableTime{}
+	result = db.Model(test1).Where(`timestamp_tz`, test0TimestampTZ).First(test1)
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
+	require.EqualValuesf(t, test0TimestampTZ, test1.TimestampTZ, "expecting Date to match")
+
+	test1 = &TestTableTime{}
+	result = db.Model(test1).Where(`timestamp_ltz`, test0TimestampLTZ).First(test1)
+	require.NoError(t, result.Error, "expecting no error")
+	require.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
+	require.EqualValuesf(t, test0TimestampLTZ, test1.TimestampLTZ, "expecting Date to match")
+}
+
+func Test_castSelectVar(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("", 3600))
+	tsExpr := clause.Expr{
+		SQL:  "CAST(TO_TIMESTAMP_TZ(?, ?) AS TIMESTAMP(9) WITH TIME ZONE)",
+		Vars: []any{"2024-01-02 03:04:05.123456789+01:00", converters.NlsTimestampTzFormat},
+	}
+	assert.Equal(t, tsExpr, castSelectVar(ts))
+	assert.Equal(t, tsExpr, castSelectVar(&ts))
+	assert.Equal(t, clause.Expr{SQL: "CAST(NULL AS TIMESTAMP WITH TIME ZONE)"}, castSelectVar((*time.Time)(nil)))
+	assert.Equal(t, 1, castSelectVar(true))
+	assert.Equal(t, 0, castSelectVar(false))
+
+	u := uuid.New()
+	assert.Equal(t, clause.Expr{SQL: "HEXTORAW(?)", Vars: []any{fmt.Sprintf("%x", u[:])}}, castSelectVar(u))
+	assert.Equal(t, "x", castSelectVar("x"))
+	assert.Equal(t, 7, castSelectVar(7))
+}
+
+func TestSelectBoundTime(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("", 3600))
+	u := uuid.New()
+	stmt := db.Session(&gorm.Session{DryRun: true}).Table("DUAL").Select("? AS at, ? AS flag", ts, true).Find(&[]map[string]any{}).Statement
+	require.Equal(t,
+		"SELECT CAST(TO_TIMESTAMP_TZ('2024-01-02 03:04:05.123456789+01:00', 'YYYY-MM-DD HH24:MI:SS.FF9TZH:TZM') AS TIMESTAMP(9) WITH TIME ZONE) AS at, 1 AS flag FROM DUAL",
+		db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...))
+
+	var got struct {
+		At   time.Time
+		Flag bool
+		Ref  uuid.UUID
+	}
+	require.NoError(t, db.Table("DUAL").Select("? AS at, ? AS flag, ? AS ref", ts, true, u).Take(&got).Error)
+	require.True(t, ts.Equal(got.At), "expecting %v, got %v", ts, got.At)
+	_, offset := got.At.Zone()
+	require.Equal(t, 3600, offset)
+	require.True(t, got.Flag)
+	require.Equal(t, u, got.Ref)
+}
+
+type TestTableTimePrecision struct {
+	ID uint64    `gorm:"primaryKey;autoIncrement:false"`
+	At time.Time `gorm:"precision:3"`
+}
+
+func (TestTableTimePrecision) TableName() string {
+	return "test_time_precision"
+}
+
+func Test_timePrecision(t *testing.T) {
+	sch, err := schema.Parse(&TestTableTimePrecision{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	field := sch.LookUpField("At")
+	assert.Equal(t, "TIMESTAMP(3) WITH TIME ZONE", Dialector{Config: &Config{}}.DataTypeOf(field))
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123500000, time.UTC)
+	rounded := time.Date(2024, 1, 2, 3, 4, 5, 124000000, time.UTC)
+	assert.Equal(t, rounded, convertToBind(nil, field, ts))
+	assert.Equal(t, rounded, convertToBind(nil, field, &ts))
+	assert.Equal(t, (*time.Time)(nil), convertToBind(nil, field, (*time.Time)(nil)))
+	assert.Equal(t, castTime(rounded, "TIMESTAMP WITH TIME ZONE", 3, nil), castTime(ts, "TIMESTAMP(3) WITH TIME ZONE", 0, nil))
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), trimFracTo(time.Date(2024, 1, 2, 3, 4, 5, 999600000, time.UTC), 3))
+}
+
+type TestTableDefaultTime struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement:false"`
+	At        time.Time
+	Precise   time.Time `gorm:"type:timestamp with time zone"`
+	UpdatedAt time.Time
+}
+
+func (TestTableDefaultTime) TableName() string {
+	return "test_default_time"
+}
+
+func Test_defaultTimeType(t *testing.T) {
+	sch, err := schema.Parse(&TestTableDefaultTime{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	at, precise := sch.LookUpField("At"), sch.LookUpField("Precise")
+
+	for defaultType, want := range map[string]string{
+		"":                                "TIMESTAMP WITH TIME ZONE",
+		"DATE":                            "DATE",
+		"timestamp":                       "TIMESTAMP",
+		"TIMESTAMP  WITH LOCAL TIME ZONE": "TIMESTAMP WITH LOCAL TIME ZONE",
+	} {
+		d := Dialector{Config: &Config{DefaultTimeType: defaultType}}
+		assert.Equal(t, want, d.DataTypeOf(at), defaultType)
+		assert.Equal(t, "TIMESTAMP WITH TIME ZONE", d.DataTypeOf(precise), defaultType)
+	}
+
+	db := &gorm.DB{Config: &gorm.Config{Dialector: Dialector{Config: &Config{DefaultTimeType: "DATE"}}}}
+	stmt := &gorm.Statement{DB: db}
+	assert.True(t, isDateField(stmt, at))
+	assert.False(t, isDateField(stmt, precise))
+	assert.False(t, isDateField(nil, at))
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 999999999, time.UTC)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), convertToBind(stmt, at, ts))
+	assert.Equal(t, ts, convertToBind(stmt, precise, ts))
+}
+
+func TestDefaultTimeTypeDate(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	require.NoError(t, err)
+
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(timeType string) { cfg.DefaultTimeType = timeType }(cfg.DefaultTimeType)
+	cfg.DefaultTimeType = "DATE"
+
+	_ = db.Migrator().DropTable(&TestTableDefaultTime{})
+	require.NoError(t, db.AutoMigrate(&TestTableDefaultTime{}))
+	columnTypes, err := db.Migrator().ColumnTypes(&TestTableDefaultTime{})
+	require.NoError(t, err)
+	for _, ct := range columnTypes {
+		switch strings.ToUpper(ct.Name()) {
+		case "AT", "UPDATED_AT":
+			require.Equal(t, "DATE", ct.DatabaseTypeName(), ct.Name())
+		case "PRECISE":
+			require.Equal(t, "TIMESTAMP WITH TIME ZONE", ct.DatabaseTypeName(), ct.Name())
+		}
+	}
+
+	// DATE keeps whole seconds: the fraction is truncated as the value is written
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 987654321, time.UTC)
+	require.NoError(t, db.Create(&TestTableDefaultTime{ID: 1, At: ts, Precise: ts}).Error)
+
+	var got TestTableDefaultTime
+	require.NoError(t, db.Where("at = ?", ts).First(&got).Error, "expecting the written time to match at second precision")
+	require.True(t, got.At.Equal(ts.Truncate(time.Second)), "expecting %v, got %v", ts.Truncate(time.Second), got.At)
+	require.True(t, got.Precise.Equal(ts.Truncate(time.Microsecond)), "expecting %v, got %v", ts.Truncate(time.Microsecond), got.Precise)
+	require.Zero(t, got.UpdatedAt.Nanosecond())
+}
+
+func TestTimePrecisionRoundTrip(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	require.NoError(t, err)
+
+	_ = db.Migrator().DropTable(&TestTableTimePrecision{})
+	require.NoError(t, db.AutoMigrate(&TestTableTimePrecision{}))
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123567891, time.UTC)
+	require.NoError(t, db.Create(&TestTableTimePrecision{ID: 1, At: ts}).Error)
+	require.NoError(t, db.Create(&[]TestTableTimePrecision{{ID: 2, At: ts.Add(time.Second)}}).Error)
+
+	var got TestTableTimePrecision
+	require.NoError(t, db.Where("at = ?", ts).First(&got).Error, "expecting the written time to match at millisecond precision")
+	require.EqualValues(t, 1, got.ID)
+	require.True(t, got.At.Equal(trimFracTo(ts, 3)), "expecting %v, got %v", trimFracTo(ts, 3), got.At)
+
+	var rows []TestTableTimePrecision
+	require.NoError(t, db.Where(&TestTableTimePrecision{At: ts.Add(time.Second)}).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	require.EqualValues(t, 2, rows[0].ID)
+}
+
+type TestTableInterval struct {
+	ID      uint64 `gorm:"primaryKey"`
+	Elapsed time.Duration
+	Timeout *time.Duration
+	Nanos   int64 `gorm:"type:interval day to second"`
+}
+
+func (TestTableInterval) TableName() string {
+	return "test_interval"
+}
+
+// xorCipher is a stub Cipher: the plaintext xor-ed with 0x5a behind an "x1:" prefix
+type xorCipher struct{}
+
+func (xorCipher) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	ciphertext := append([]byte("x1:"), plaintext...)
+	for i := 3; i < len(ciphertext); i++ {
+		ciphertext[i] ^= 0x5a
+	}
+	return ciphertext, nil
+}
+
+func (c xorCipher) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	if !strings.HasPrefix(string(ciphertext), "x1:") {
+		return nil, errors.New("not encrypted by xorCipher")
+	}
+	plaintext := append([]byte(nil), ciphertext[3:]...)
+	for i := range plaintext {
+		plaintext[i] ^= 0x5a
+	}
+	return plaintext, nil
+}
+
+type TestTableEncrypted struct {
+	ID     uint64  `gorm:"primaryKey;autoIncrement:false"`
+	SSN    string  `gorm:"encrypt;size:64"`
+	Note   *string `gorm:"encrypt"`
+	Secret []byte  `gorm:"encrypt;size:64"`
+}
+
+func (TestTableEncrypted) TableName() string {
+	return "test_encrypted"
+}
+
+func TestEncryptedFields(t *testing.T) {
+	sch, err := schema.Parse(&TestTableEncrypted{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	d := Dialector{Config: &Config{namingStrategy: &NamingStrategy{}, Cipher: xorCipher{}}}
+	assert.Equal(t, "RAW(64)", d.DataTypeOf(sch.LookUpField("SSN")))
+	assert.Equal(t, "BLOB", d.DataTypeOf(sch.LookUpField("Note")))
+	assert.Equal(t, "RAW(64)", d.DataTypeOf(sch.LookUpField("Secret")))
+
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}, Context: context.Background()}
+	ciphertext := encryptValue(stmt, sch.LookUpField("SSN"), "123-45-6789")
+	require.IsType(t, WrappedBytes{}, ciphertext)
+	assert.NotContains(t, string(ciphertext.(WrappedBytes)), "123-45-6789")
+	plaintext, err := decryptValue(stmt, sch.LookUpField("SSN"), ciphertext.(WrappedBytes))
+	require.NoError(t, err)
+	assert.Equal(t, "123-45-6789", plaintext)
+	assert.Equal(t, WrappedBytes(nil), encryptValue(stmt, sch.LookUpField("Note"), (*string)(nil)))
+
+	plain := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: Dialector{Config: &Config{}}}}, Context: context.Background()}
+	encryptValue(plain, sch.LookUpField("SSN"), "123-45-6789")
+	assert.ErrorContains(t, plain.Error, "Config.Cipher is not set")
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(cipher Cipher) { cfg.Cipher = cipher }(cfg.Cipher)
+	cfg.Cipher = xorCipher{}
+
+	_ = db.Migrator().DropTable(&TestTableEncrypted{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableEncrypted{}), "expecting no error")
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableEncrypted{}), "expecting re-migration to keep the ciphertext columns")
+
+	note := "call after 5pm"
+	require.NoError(t, db.Create(&TestTableEncrypted{ID: 1, SSN: "123-45-6789", Note: &note, Secret: []byte{1, 2, 3}}).Error, "expecting no error")
+	require.NoError(t, db.Create(&TestTableEncrypted{ID: 2, SSN: "987-65-4321"}).Error, "expecting no error")
+
+	var stored []byte
+	require.NoError(t, db.Model(&TestTableEncrypted{}).Select("ssn").Where("id = ?", 1).Row().Scan(&stored))
+	expected, _ := xorCipher{}.Encrypt(context.Background(), []byte("123-45-6789"))
+	assert.Equal(t, expected, stored, "expecting the ciphertext stored")
+
+	var got TestTableEncrypted
+	require.NoError(t, db.First(&got, 1).Error, "expecting no error")
+	assert.Equal(t, "123-45-6789", got.SSN)
+	require.NotNil(t, got.Note)
+	assert.Equal(t, note, *got.Note)
+	assert.Equal(t, []byte{1, 2, 3}, got.Secret)
+
+	var empty TestTableEncrypted
+	require.NoError(t, db.First(&empty, 2).Error, "expecting no error")
+	assert.Equal(t, "987-65-4321", empty.SSN)
+	assert.Nil(t, empty.Note)
+
+	require.NoError(t, db.Model(&got).Update("ssn", "000-00-0000").Error, "expecting no error")
+	require.NoError(t, db.Model(&got).Updates(TestTableEncrypted{Note: &note}).Error, "expecting no error")
+	require.NoError(t, db.First(&got, 1).Error, "expecting no error")
+	assert.Equal(t, "000-00-0000", got.SSN)
+}
+
+func TestIntervalConversion(t *testing.T) {
+	sch, err := schema.Parse(&TestTableInterval{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	d := Dialector{Config: &Config{}}
+	for _, name := range []string{"Elapsed", "Timeout", "Nanos"} {
+		assert.True(t, isIntervalField(sch.LookUpField(name)), name)
+		assert.Equal(t, "INTERVAL DAY(9) TO SECOND(9)", d.DataTypeOf(sch.LookUpField(name)), name)
+	}
+	assert.False(t, isIntervalField(sch.LookUpField("ID")))
+
+	for d, literal := range map[time.Duration]string{
+		0: "+0 00:00:00.000000000",
+		36*time.Hour + 4*time.Minute + 5*time.Nanosecond: "+1 12:04:00.000000005",
+		-1500 * time.Millisecond:                         "-0 00:00:01.500000000",
+		math.MinInt64:                                    "-106751 23:47:16.854775808",
+	} {
+		assert.Equal(t, literal, formatIntervalDS(d))
+	}
+	for text, want := range map[string]time.Duration{
+		"+00 00:00:00.000000":  0,
+		"+01 12:04:00.000005":  36*time.Hour + 4*time.Minute + 5*time.Microsecond,
+		"-00 00:00:01.500000":  -1500 * time.Millisecond,
+		"+106751 23:47:16.854": 106751*24*time.Hour + 23*time.Hour + 47*time.Minute + 16854*time.Millisecond,
+	} {
+		got, err := parseIntervalDS(text)
+		require.NoError(t, err, text)
+		assert.Equal(t, want, got, text)
+	}
+	_, err = parseIntervalDS("12:00")
+	assert.Error(t, err)
+
+	field := sch.LookUpField("Timeout")
+	assert.Equal(t, clause.Expr{SQL: "CAST(NULL AS INTERVAL DAY TO SECOND)"}, convertToBind(nil, field, (*time.Duration)(nil)))
+	timeout := 90 * time.Second
+	assert.Equal(t, clause.Expr{SQL: "TO_DSINTERVAL(?)", Vars: []any{"+0 00:01:30.000000000"}}, convertToBind(nil, field, &timeout))
+	scanned, err := scannedInterval(field, int64(timeout))
+	require.NoError(t, err)
+	assert.Equal(t, timeout, scanned)
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+	_ = db.Migrator().DropTable(&TestTableInterval{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableInterval{}))
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableInterval{}), "expecting the interval columns to migrate again")
+
+	rows := []TestTableInterval{
+		{ID: 1, Elapsed: 36*time.Hour + 4*time.Minute + 123456789*time.Nanosecond, Timeout: &timeout, Nanos: int64(time.Millisecond)},
+		{ID: 2, Elapsed: -(2*time.Hour + 1*time.Nanosecond)},
+		{ID: 3},
+	}
+	require.NoError(t, db.Create(&rows).Error)
+
+	var got []TestTableInterval
+	require.NoError(t, db.Order("id").Find(&got).Error)
+	require.Equal(t, rows, got, "expecting the durations to round-trip to the nanosecond")
+
+	// a nil duration binds no variable, the rows around it keep their own
+	more := []TestTableInterval{{ID: 4, Elapsed: time.Second}, {ID: 5, Elapsed: time.Minute, Timeout: &timeout}}
+	require.NoError(t, db.Create(&more).Error)
+	require.NoError(t, db.Create(&TestTableInterval{ID: 6, Timeout: &timeout}).Error, "expecting a single row with TO_DSINTERVAL(?) bound")
+	got = nil
+	require.NoError(t, db.Where("id > ?", 3).Order("id").Find(&got).Error)
+	require.Equal(t, append(more, TestTableInterval{ID: 6, Timeout: &timeout}), got)
+
+	var found TestTableInterval
+	require.NoError(t, db.Where(&TestTableInterval{Elapsed: rows[1].Elapsed}).First(&found).Error)
+	assert.EqualValues(t, 2, found.ID)
+	require.NoError(t, db.Where("elapsed > ?", time.Hour).First(&found).Error)
+	assert.EqualValues(t, 1, found.ID)
+
+	require.NoError(t, db.Model(&TestTableInterval{ID: 3}).Updates(map[string]any{"elapsed": time.Minute, "timeout": &timeout}).Error)
+	var raw []TestTableInterval
+	require.NoError(t, db.Raw(`SELECT * FROM "TEST_INTERVAL" WHERE "ID" = ?`, 3).Find(&raw).Error)
+	require.Len(t, raw, 1)
+	assert.Equal(t, time.Minute, raw[0].Elapsed, "expecting the interval text read back")
+	assert.Equal(t, &timeout, raw[0].Timeout)
+}
+
+// TestTableDurationNumber keeps a duration in a NUMBER column, as before INTERVAL DAY TO SECOND
+type TestTableDurationNumber struct {
+	ID      uint64        `gorm:"primaryKey"`
+	Elapsed time.Duration `gorm:"type:number"`
+}
+
+func (TestTableDurationNumber) TableName() string {
+	return "test_duration_number"
+}
+
+func TestDurationNumberColumn(t *testing.T) {
+	sch, err := schema.Parse(&TestTableDurationNumber{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	field := sch.LookUpField("Elapsed")
+	assert.False(t, isIntervalField(field), "expecting type:number to keep the NUMBER mapping")
+	assert.Equal(t, "NUMBER", strings.ToUpper(Dialector{Config: &Config{}}.DataTypeOf(field)))
+	assert.Empty(t, readExpression(field), "expecting the column read as stored")
+	assert.Equal(t, time.Second, convertToBind(nil, field, convertToInterval(field, time.Second)))
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+	_ = db.Migrator().DropTable(&TestTableDurationNumber{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableDurationNumber{}))
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableDurationNumber{}), "expecting the NUMBER column left alone")
+
+	rows := []TestTableDurationNumber{{ID: 1, Elapsed: 36*time.Hour + 5*time.Nanosecond}, {ID: 2, Elapsed: -time.Millisecond}}
+	require.NoError(t, db.Create(&rows).Error)
+	var got []TestTableDurationNumber
+	require.NoError(t, db.Order("id").Find(&got).Error)
+	require.Equal(t, rows, got)
+	var found TestTableDurationNumber
+	require.NoError(t, db.Where("elapsed < ?", time.Duration(0)).First(&found).Error)
+	assert.EqualValues(t, 2, found.ID)
+}
+
+type TestTableBinaryFloat struct {
+	ID     uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Single float32
+	Double float64
+	Tagged float64  `gorm:"type:binary_double"`
+	Ptr    *float32 `gorm:"type:binary_float"`
+	Exact  float64  `gorm:"precision:10;scale:2"`
+}
+
+func (TestTableBinaryFloat) TableName() string {
+	return "test_binary_float"
+}
+
+func TestBinaryFloatTypes(t *testing.T) {
+	sch, err := schema.Parse(&TestTableBinaryFloat{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+
+	d := Dialector{Config: &Config{}}
+	assert.Equal(t, "FLOAT", d.DataTypeOf(sch.LookUpField("Single")))
+	assert.Equal(t, "FLOAT", d.DataTypeOf(sch.LookUpField("Double")))
+	assert.Equal(t, "BINARY_DOUBLE", d.DataTypeOf(sch.LookUpField("Tagged")))
+	assert.Equal(t, "BINARY_FLOAT", d.DataTypeOf(sch.LookUpField("Ptr")))
+	d = Dialector{Config: &Config{UseBinaryFloatTypes: true}}
+	assert.Equal(t, "BINARY_FLOAT", d.DataTypeOf(sch.LookUpField("Single")))
+	assert.Equal(t, "BINARY_DOUBLE", d.DataTypeOf(sch.LookUpField("Double")))
+	assert.Equal(t, "NUMBER(10,2)", d.DataTypeOf(sch.LookUpField("Exact")))
+
+	tagged := sch.LookUpField("Tagged")
+	assert.Equal(t, clause.Expr{SQL: "TO_BINARY_DOUBLE(?)", Vars: []any{"INF"}}, convertToBind(nil, tagged, math.Inf(1)))
+	assert.Equal(t, clause.Expr{SQL: "TO_BINARY_DOUBLE(?)", Vars: []any{"-INF"}}, convertToBind(nil, tagged, math.Inf(-1)))
+	assert.Equal(t, clause.Expr{SQL: "TO_BINARY_DOUBLE(?)", Vars: []any{"NaN"}}, convertToBind(nil, tagged, math.NaN()))
+	assert.Equal(t, 1.5, convertToBind(nil, tagged, 1.5))
+	nan := float32(math.NaN())
+	assert.Equal(t, clause.Expr{SQL: "TO_BINARY_FLOAT(?)", Vars: []any{"NaN"}}, convertToBind(nil, sch.LookUpField("Ptr"), &nan))
+	assert.Equal(t, math.Inf(1), convertToBind(nil, sch.LookUpField("Double"), math.Inf(1)), "expecting FLOAT fields left alone without UseBinaryFloatTypes")
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+	cfg := db.Dialector.(*Dialector).Config
+	defer func(use bool) { cfg.UseBinaryFloatTypes = use }(cfg.UseBinaryFloatTypes)
+	cfg.UseBinaryFloatTypes = true
+
+	_ = db.Migrator().DropTable(&TestTableBinaryFloat{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableBinaryFloat{}))
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableBinaryFloat{}), "expecting the binary float columns to migrate again")
+	columnTypes, err := db.Migrator().ColumnTypes(&TestTableBinaryFloat{})
+	require.NoError(t, err)
+	for _, ct := range columnTypes {
+		switch strings.ToUpper(ct.Name()) {
+		case "SINGLE", "PTR":
+			assert.Equal(t, "BINARY_FLOAT", ct.DatabaseTypeName(), ct.Name())
+		case "DOUBLE", "TAGGED":
+			assert.Equal(t, "BINARY_DOUBLE", ct.DatabaseTypeName(), ct.Name())
+		}
+	}
+
+	rows := []TestTableBinaryFloat{
+		{ID: 1, Single: float32(math.Inf(1)), Double: math.Inf(-1), Tagged: math.NaN(), Ptr: &nan},
+		{ID: 2, Single: 0.1, Double: 0.1, Tagged: math.MaxFloat64, Exact: 12.34},
+	}
+	require.NoError(t, db.Create(&rows).Error)
+
+	var got []TestTableBinaryFloat
+	require.NoError(t, db.Order("id").Find(&got).Error)
+	require.Len(t, got, 2)
+	assert.True(t, math.IsInf(float64(got[0].Single), 1))
+	assert.True(t, math.IsInf(got[0].Double, -1))
+	assert.True(t, math.IsNaN(got[0].Tagged))
+	require.NotNil(t, got[0].Ptr)
+	assert.True(t, math.IsNaN(float64(*got[0].Ptr)))
+	assert.Equal(t, rows[1], got[1], "expecting finite values to round-trip exactly")
+
+	require.NoError(t, db.Create(&TestTableBinaryFloat{ID: 3, Double: math.Inf(1)}).Error, "expecting a single row with TO_BINARY_DOUBLE(?) bound")
+	var single TestTableBinaryFloat
+	require.NoError(t, db.First(&single, 3).Error)
+	assert.True(t, math.IsInf(single.Double, 1))
+
+	var found TestTableBinaryFloat
+	require.NoError(t, db.Where(&TestTableBinaryFloat{Double: math.Inf(-1)}).First(&found).Error)
+	assert.EqualValues(t, 1, found.ID)
+	require.NoError(t, db.Where("tagged = ?", math.NaN()).First(&found).Error, "expecting NaN to equal NaN in a binary double column")
+	assert.EqualValues(t, 1, found.ID)
+}
+
+type TestTableVector struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement:false"`
+	Embedding []float32 `gorm:"type:vector(3,FLOAT32)"`
+	Extra     Vector
+}
+
+func (TestTableVector) TableName() string {
+	return "test_vector"
+}
+
+func TestVectorType(t *testing.T) {
+	sch, err := schema.Parse(&TestTableVector{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	embedding, extra := sch.LookUpField("Embedding"), sch.LookUpField("Extra")
+	assert.True(t, isVectorField(embedding))
+	assert.True(t, isVectorField(extra))
+
+	d := Dialector{Config: &Config{DBVer: "23.5.0.24.07"}}
+	assert.Equal(t, "VECTOR(3,FLOAT32)", d.DataTypeOf(embedding))
+	assert.Equal(t, "VECTOR", d.DataTypeOf(extra))
+	assert.Equal(t, "CLOB", Dialector{Config: &Config{DBVer: "19.3.0.0.0"}}.DataTypeOf(embedding))
+
+	assert.Equal(t, "[1.5,-2,0.1]", formatVector([]float32{1.5, -2, 0.1}))
+	parsed, err := parseVector(" [1.5, -2,1.0E-001 ] ")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1.5, -2, 0.1}, parsed)
+	parsed, err = parseVector("[]")
+	require.NoError(t, err)
+	assert.Empty(t, parsed)
+	_, err = parseVector("1,2")
+	assert.Error(t, err)
+
+	var v Vector
+	require.NoError(t, v.Scan("[1,2,3]"))
+	assert.Equal(t, Vector{1, 2, 3}, v)
+	require.NoError(t, v.Scan(nil))
+	assert.Nil(t, v)
+	value, err := Vector{0.5}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[0.5]", value)
+
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: d}}}
+	assert.Equal(t, clause.Expr{SQL: "TO_VECTOR(?)", Vars: []any{"[1,2,3]"}}, convertToBind(stmt, embedding, []float32{1, 2, 3}))
+	assert.Equal(t, clause.Expr{SQL: "TO_VECTOR(?)", Vars: []any{"[1,2,3]"}}, convertToBind(stmt, extra, Vector{1, 2, 3}))
+	assert.Nil(t, convertToBind(stmt, embedding, []float32(nil)))
+	assert.Equal(t, "[1,2,3]", convertToBind(nil, embedding, []float32{1, 2, 3}), "expecting the literal itself before Oracle 23ai")
+	scanned, err := scannedVector(extra, "[4,5]")
+	require.NoError(t, err)
+	assert.Equal(t, Vector{4, 5}, scanned)
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	if !db.Dialector.(*Dialector).nativeVectors() {
+		t.Skipf("VECTOR needs Oracle 23ai, have %s", db.Dialector.(*Dialector).DBVer)
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableVector{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableVector{}))
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableVector{}), "expecting the vector columns to migrate again")
+	columnTypes, err := db.Migrator().ColumnTypes(&TestTableVector{})
+	require.NoError(t, err)
+	for _, ct := range columnTypes {
+		if name := strings.ToUpper(ct.Name()); name == "EMBEDDING" || name == "EXTRA" {
+			assert.Equal(t, "VECTOR", ct.DatabaseTypeName(), ct.Name())
+		}
+	}
+
+	rows := []TestTableVector{
+		{ID: 1, Embedding: []float32{1, 0, 0}, Extra: Vector{0.25, -1.5}},
+		{ID: 2, Embedding: []float32{0, 1, 0}},
+	}
+	require.NoError(t, db.Create(&rows).Error)
+
+	var got []TestTableVector
+	require.NoError(t, db.Order("id").Find(&got).Error)
+	require.Equal(t, rows, got, "expecting the embeddings to round-trip")
+
+	single := TestTableVector{ID: 3, Embedding: []float32{0, 0, 1}, Extra: Vector{2}}
+	require.NoError(t, db.Create(&single).Error, "expecting a single row with TO_VECTOR(?) bound")
+	var gotSingle TestTableVector
+	require.NoError(t, db.First(&gotSingle, 3).Error)
+	require.Equal(t, single, gotSingle)
+
+	var nearest TestTableVector
+	require.NoError(t, db.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "VECTOR_DISTANCE(embedding, TO_VECTOR(?), COSINE)",
+		Vars: []any{Vector{0.1, 0.9, 0}},
+	}}).First(&nearest).Error)
+	assert.EqualValues(t, 2, nearest.ID, "expecting the closest embedding first")
+
+	require.NoError(t, db.Model(&TestTableVector{ID: 2}).Update("embedding", []float32{0, 0, 1}).Error)
+	require.NoError(t, db.First(&nearest, 2).Error)
+	assert.Equal(t, []float32{0, 0, 1}, nearest.Embedding)
+}
+
+func TestHavingTimeConversion(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+	_ = db.Migrator().DropTable(&TestTableTime{})
+	require.NoError(t, db.Migrator().AutoMigrate(TestTableTime{}), "expecting no error")
+
+	base := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	alpha, beta := "alpha", "beta"
+	rows := []*TestTableTime{
+		{Name: &alpha, Date: base, Timestamp: base, TimestampTZ: base, TimestampLTZ: base},
+		{Name: &alpha, Date: base, Timestamp: base.Add(2 * time.Hour), TimestampTZ: base, TimestampLTZ: base},
+		{Name: &beta, Date: base, Timestamp: base.Add(-time.Hour), TimestampTZ: base, TimestampLTZ: base},
+	}
+	require.NoError(t, db.Create(rows).Error, "expecting no error")
+
+	var names []string
+	result := db.Model(&TestTableTime{}).
+		Select("name").
+		Group("name").
+		Having(`max("TIMESTAMP") > ?`, base.Add(time.Hour)).
+		Order("name").
+		Pluck("name", &names)
+	require.NoError(t, result.Error, "expecting no error")
+	assert.Equal(t, []string{"alpha"}, names)
+
+	sch, err := schema.Parse(&TestTableTime{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	field := sch.LookUpField("Timestamp")
+	require.Equal(t, field, lookUpHavingField(sch, clause.Expr{SQL: "MAX(timestamp) > ?", Vars: []any{base}}))
+	require.Equal(t, field, lookUpHavingField(sch, clause.Expr{SQL: `min( "timestamp" )<=?`, Vars: []any{base}}))
+	require.Nil(t, lookUpHavingField(sch, clause.Expr{SQL: "count(timestamp) > ?", Vars: []any{1}}))
+	require.Nil(t, lookUpHavingField(sch, clause.Expr{SQL: "max(timestamp) > ? AND min(date) < ?", Vars: []any{base, base}}))
+
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&TestTableTime{}).
+		Select("name").Group("name").Having(`max("TIMESTAMP") > ?`, base).Find(&[]TestTableTime{}).Statement
+	require.Len(t, stmt.Vars, 1)
+	assert.Equal(t, convertToLiteral(stmt, base, reflect.Value{}, stmt.Schema.LookUpField("Timestamp")), stmt.Vars[0])
+}
+
+func TestTimePtrTypes(t *testing.T) {
+	ctx := currentContext()
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(ctx)
+	_ = db.Migrator().DropTable(&TestTableTimePtrs{})
+	err := db.Migrator().AutoMigrate(TestTableTimePtrs{})
+	require.NoError(t, err, "expecting no error")
+
+	nowTime := ptr(db.NowFunc())
+	nowPrimeTime := ptr(db.NowFunc())
+	loc := db.Dialector.(*Dialector).sessionLocation
+	if loc == nil {
+		loc = time.Local
+	}
+	test0Name := "test0"
+	test00Name := "test00"
+	test0 := &TestTableTimePtrs{
+		ID:           0,
 		Name:         &test0Name,
 		Date:         nowTime,
 		Timestamp:    nowTime,
@@ -1053,6 +3111,156 @@ func TestDeleteReturningBehavior(t *testing.T) {
 	})
 }
 
+type TestTableSoftDelete struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"size:50"`
+	DeletedAt gorm.DeletedAt
+}
+
+func (TestTableSoftDelete) TableName() string {
+	return "test_soft_delete"
+}
+
+func TestUnscopedDelete(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableSoftDelete{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableSoftDelete{}), "expecting no error")
+
+	t.Run("SoftDeleteBuildsUpdate", func(t *testing.T) {
+		toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Delete(&TestTableSoftDelete{ID: 1})
+		})
+		assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "UPDATE "), "expecting soft delete: %s", toSQL)
+	})
+
+	t.Run("UnscopedBuildsDelete", func(t *testing.T) {
+		toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Unscoped().Delete(&TestTableSoftDelete{ID: 1})
+		})
+		assert.True(t, strings.HasPrefix(strings.ToUpper(toSQL), "DELETE "), "expecting hard delete: %s", toSQL)
+		assert.NotContains(t, strings.ToUpper(toSQL), " RETURNING ")
+	})
+
+	t.Run("UnscopedReturningNonAddressableOmitsClause", func(t *testing.T) {
+		toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Unscoped().Clauses(clause.Returning{}).Where("? = ?", clause.Column{Name: "id"}, 1).Delete(TestTableSoftDelete{})
+		})
+		assert.NotContains(t, strings.ToUpper(toSQL), " RETURNING", "expecting no dangling RETURNING: %s", toSQL)
+	})
+
+	t.Run("UnscopedWithoutWhere", func(t *testing.T) {
+		require.NoError(t, db.Create(&TestTableSoftDelete{Name: "keep"}).Error, "expecting no error")
+
+		result := db.Unscoped().Delete(&TestTableSoftDelete{})
+		require.ErrorIs(t, result.Error, gorm.ErrMissingWhereClause)
+
+		result = db.Unscoped().Clauses(clause.Returning{}).Delete(&TestTableSoftDelete{})
+		require.ErrorIs(t, result.Error, gorm.ErrMissingWhereClause)
+
+		var count int64
+		require.NoError(t, db.Unscoped().Model(&TestTableSoftDelete{}).Count(&count).Error, "expecting no error")
+		assert.EqualValues(t, 1, count, "expecting nothing deleted")
+	})
+
+	t.Run("UnscopedHardDeletesSoftDeletedRow", func(t *testing.T) {
+		model := &TestTableSoftDelete{Name: "soft"}
+		require.NoError(t, db.Create(model).Error, "expecting no error")
+		require.NoError(t, db.Delete(model).Error, "expecting no error")
+
+		var count int64
+		require.NoError(t, db.Unscoped().Model(&TestTableSoftDelete{}).Where("? = ?", clause.Column{Name: "id"}, model.ID).Count(&count).Error, "expecting no error")
+		require.EqualValues(t, 1, count, "expecting soft-deleted row to remain")
+
+		result := db.Unscoped().Where("? = ?", clause.Column{Name: "id"}, model.ID).Delete(&TestTableSoftDelete{})
+		require.NoError(t, result.Error, "expecting no error")
+		assert.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
+
+		require.NoError(t, db.Unscoped().Model(&TestTableSoftDelete{}).Where("? = ?", clause.Column{Name: "id"}, model.ID).Count(&count).Error, "expecting no error")
+		assert.EqualValues(t, 0, count, "expecting row to be gone")
+	})
+
+	t.Run("UnscopedReturning", func(t *testing.T) {
+		model := &TestTableSoftDelete{Name: "returned"}
+		require.NoError(t, db.Create(model).Error, "expecting no error")
+
+		deleted := &TestTableSoftDelete{ID: model.ID}
+		result := db.Unscoped().Clauses(clause.Returning{}).Delete(deleted)
+		require.NoError(t, result.Error, "expecting no error")
+		assert.EqualValues(t, 1, result.RowsAffected, "expecting 1 row affected")
+		assert.Equal(t, "returned", deleted.Name, "expecting RETURNING to populate the deleted row")
+	})
+}
+
+type TestTableDeleteReturning struct {
+	ID   uint64 `gorm:"primaryKey;autoIncrement"`
+	Name string `gorm:"size:50"`
+}
+
+func (TestTableDeleteReturning) TableName() string {
+	return "test_delete_returning"
+}
+
+func Test_isReturningSlice(t *testing.T) {
+	sch, err := schema.Parse(&TestTableDeleteReturning{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	stmt := func(dest any, returning bool) *gorm.Statement {
+		s := &gorm.Statement{Schema: sch, Clauses: map[string]clause.Clause{}, ReflectValue: reflect.ValueOf(dest)}
+		if returning {
+			s.Clauses["RETURNING"] = clause.Clause{Name: "RETURNING", Expression: clause.Returning{}}
+		}
+		return s
+	}
+	two := []TestTableDeleteReturning{{ID: 1}, {ID: 2}}
+	assert.True(t, isReturningSlice(stmt(&two, true)))
+	assert.False(t, isReturningSlice(stmt(&two, false)), "expecting a single statement without RETURNING")
+	assert.False(t, isReturningSlice(stmt(&[]TestTableDeleteReturning{{ID: 1}}, true)), "expecting one element to bind as a struct does")
+	assert.False(t, isReturningSlice(stmt(&[]TestTableDeleteReturning{}, true)))
+	assert.False(t, isReturningSlice(stmt(&TestTableDeleteReturning{ID: 1}, true)))
+}
+
+func TestDeleteReturningSlice(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableDeleteReturning{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableDeleteReturning{}), "expecting no error")
+
+	rows := []TestTableDeleteReturning{{Name: "one"}, {Name: "two"}, {Name: "three"}}
+	require.NoError(t, db.Create(&rows).Error, "expecting no error")
+
+	// the keys alone, plus an element without one, which is skipped
+	deleted := []*TestTableDeleteReturning{{ID: rows[0].ID}, {}, {ID: rows[2].ID}}
+	result := db.Clauses(clause.Returning{}).Delete(&deleted)
+	require.NoError(t, result.Error, "expecting no error")
+	assert.EqualValues(t, 2, result.RowsAffected, "expecting 2 rows affected")
+	assert.Equal(t, TestTableDeleteReturning{ID: rows[0].ID, Name: "one"}, *deleted[0], "expecting RETURNING to populate each deleted row")
+	assert.Equal(t, TestTableDeleteReturning{}, *deleted[1])
+	assert.Equal(t, TestTableDeleteReturning{ID: rows[2].ID, Name: "three"}, *deleted[2])
+
+	var left []TestTableDeleteReturning
+	require.NoError(t, db.Find(&left).Error, "expecting no error")
+	require.Len(t, left, 1)
+	assert.Equal(t, rows[1], left[0])
+
+	// a single struct and an empty slice keep the single statement
+	single := TestTableDeleteReturning{ID: rows[1].ID}
+	result = db.Clauses(clause.Returning{}).Delete(&single)
+	require.NoError(t, result.Error, "expecting no error")
+	assert.Equal(t, "two", single.Name)
+	require.ErrorIs(t, db.Clauses(clause.Returning{}).Delete(&[]TestTableDeleteReturning{}).Error, gorm.ErrMissingWhereClause)
+}
+
 func TestUpdateReturningBehavior(t *testing.T) {
 	db := dbNamingCase
 	if db == nil {
@@ -1222,6 +3430,38 @@ func TestCreateReturningDefaultValues(t *testing.T) {
 	assert.Equal(t, 7, model.Count, "expecting default Count to be returned")
 }
 
+func TestCreateReturningServerDefaults(t *testing.T) {
+	sch, err := schema.Parse(&TestTableServerDefaults{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	assert.Equal(t, 4000, returningSize(sch.LookUpField("Token")), "expecting room for any VARCHAR2")
+	assert.Equal(t, 2000, returningSize(sch.LookUpField("Ref")), "expecting room for any RAW")
+	assert.Equal(t, 50, returningSize(sch.LookUpField("Name")))
+
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableServerDefaults{})
+	require.NoError(t, db.Migrator().AutoMigrate(TestTableServerDefaults{}), "expecting no error")
+
+	models := []TestTableServerDefaults{{Name: "Alpha"}, {Name: "Beta"}, {Name: "Gamma"}}
+	require.NoError(t, db.Create(&models[0]).Error, "expecting no error")
+	batch := models[1:]
+	require.NoError(t, db.Create(&batch).Error, "expecting no error")
+	for _, model := range models {
+		assert.Len(t, model.Token, 32, "expecting the SYS_GUID() default returned as hex")
+		assert.Len(t, model.Ref, 16, "expecting the SYS_GUID() default returned as RAW")
+	}
+	assert.NotEqual(t, models[0].Token, models[1].Token)
+
+	var got TestTableServerDefaults
+	require.NoError(t, db.First(&got, models[0].ID).Error)
+	assert.Equal(t, models[0], got)
+}
+
 func TestUpdateMapVsStructWithExprAndZeroValues(t *testing.T) {
 	db := dbNamingCase
 	if db == nil {
@@ -1234,18 +3474,59 @@ func TestUpdateMapVsStructWithExprAndZeroValues(t *testing.T) {
 	require.NoError(t, db.Migrator().AutoMigrate(TestTableDefaultValues{}), "expecting no error")
 
 	model := &TestTableDefaultValues{Name: "Alpha"}
-	require.NoError(t, db.Create(model).Error, "expecting no error")
+	require.NoError(t, db.Create(model).Error, "expecting no error")
+
+	res := db.Model(model).Clauses(clause.Returning{}).Updates(map[string]any{
+		"count": gorm.Expr("count + 1"),
+	})
+	require.NoError(t, res.Error, "expecting no error")
+	assert.Equal(t, 8, model.Count, "expecting Count to be incremented")
+
+	model.Count = 0
+	res = db.Model(model).Clauses(clause.Returning{}).Select("count").Updates(model)
+	require.NoError(t, res.Error, "expecting no error")
+	assert.Equal(t, 0, model.Count, "expecting Count to be set to zero")
+}
+
+func TestUpdateColumnSkipsAutoUpdateTime(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	_ = db.Migrator().DropTable(&TestTableDefaultValues{})
+	require.NoError(t, db.Migrator().AutoMigrate(TestTableDefaultValues{}), "expecting no error")
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(now time.Time) *gorm.DB {
+		return db.Session(&gorm.Session{NowFunc: func() time.Time { return now }})
+	}
+
+	model := &TestTableDefaultValues{Name: "Alpha"}
+	require.NoError(t, at(created).Create(model).Error, "expecting no error")
 
-	res := db.Model(model).Clauses(clause.Returning{}).Updates(map[string]any{
-		"count": gorm.Expr("count + 1"),
+	toSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&TestTableDefaultValues{ID: model.ID}).UpdateColumn("name", "Beta")
 	})
-	require.NoError(t, res.Error, "expecting no error")
-	assert.Equal(t, 8, model.Count, "expecting Count to be incremented")
+	assert.NotContains(t, strings.ToUpper(toSQL), "UPDATED_AT", "expecting UpdateColumn to leave the auto update time out: %s", toSQL)
 
-	model.Count = 0
-	res = db.Model(model).Clauses(clause.Returning{}).Select("count").Updates(model)
-	require.NoError(t, res.Error, "expecting no error")
-	assert.Equal(t, 0, model.Count, "expecting Count to be set to zero")
+	require.NoError(t, at(created.Add(time.Hour)).Model(model).UpdateColumn("name", "Beta").Error, "expecting no error")
+	require.NoError(t, at(created.Add(2*time.Hour)).Model(model).UpdateColumns(map[string]any{"count": 9}).Error, "expecting no error")
+	require.NoError(t, at(created.Add(3*time.Hour)).Model(model).UpdateColumns(TestTableDefaultValues{Count: 10}).Error, "expecting no error")
+
+	var got TestTableDefaultValues
+	require.NoError(t, db.First(&got, model.ID).Error, "expecting no error")
+	assert.Equal(t, "Beta", got.Name)
+	assert.Equal(t, 10, got.Count)
+	assert.True(t, created.Equal(got.UpdatedAt), "expecting UpdateColumn(s) to leave updated_at at %v, got %v", created, got.UpdatedAt)
+
+	updated := created.Add(4 * time.Hour)
+	require.NoError(t, at(updated).Model(model).Update("name", "Gamma").Error, "expecting no error")
+	require.NoError(t, db.First(&got, model.ID).Error, "expecting no error")
+	assert.Equal(t, "Gamma", got.Name)
+	assert.True(t, updated.Equal(got.UpdatedAt), "expecting Update to bump updated_at to %v, got %v", updated, got.UpdatedAt)
 }
 
 func TestMergeCreateNoReturning(t *testing.T) {
@@ -1533,6 +3814,51 @@ func TestReturningInto(t *testing.T) {
 	assert.EqualValuesf(t, "charlie", model.Account, "expecting Account to be 'charlie' was %s", model.Account)
 }
 
+type testSetExprModel struct {
+	ID       uint `gorm:"primaryKey;autoIncrement:false"`
+	UserType int
+	Name     string `gorm:"size:50"`
+}
+
+func TestUpdateUnquotedExpr(t *testing.T) {
+	db := dbNamingCase
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	snake, err := gorm.Open(New(Config{Conn: sqlDB, PreferredCase: SnakeCase}), &gorm.Config{})
+	require.NoError(t, err)
+	snake = snake.WithContext(currentContext())
+
+	updateSQL := snake.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&testSetExprModel{ID: 1}).Updates(map[string]any{
+			"user_type": gorm.Expr("NVL(user_type, 0) + ?", 1),
+			"name":      gorm.Expr("name || 'user_type' || t.name || :name"),
+		})
+	})
+	assert.Contains(t, updateSQL, `"user_type"=NVL("user_type", 0) + 1`, "expecting the bare column quoted, the function left alone")
+	assert.Contains(t, updateSQL, `"name"="name" || 'user_type' || t.name || :name`, "expecting literals, qualified names and binds left alone")
+
+	m := snake.Migrator()
+	_ = m.DropTable(&testSetExprModel{})
+	require.NoError(t, m.AutoMigrate(&testSetExprModel{}))
+	defer m.DropTable(&testSetExprModel{})
+	require.NoError(t, snake.Create(&testSetExprModel{ID: 1, UserType: 1, Name: "a"}).Error)
+
+	model := &testSetExprModel{ID: 1}
+	require.NoError(t, snake.Model(model).Update("user_type", gorm.Expr("user_type + 1")).Error, "expecting the unquoted column to resolve")
+	require.NoError(t, snake.Model(model).Updates(map[string]any{"user_type": gorm.Expr("user_type * ?", 10), "name": "b"}).Error)
+	require.NoError(t, snake.Model(model).Clauses(clause.Returning{}).Update("user_type", gorm.Expr("user_type + 1")).Error)
+	assert.Equal(t, 21, model.UserType, "expecting the returned value")
+
+	var got testSetExprModel
+	require.NoError(t, snake.First(&got, 1).Error)
+	assert.Equal(t, 21, got.UserType)
+	assert.Equal(t, "b", got.Name)
+}
+
 func TestReturning(t *testing.T) {
 	//db := openTestConnection(nil, t, true, true, false)
 	db := dbNamingCase
@@ -1595,57 +3921,436 @@ func TestReturning(t *testing.T) {
 	assert.Equal(t, modelId, model.ID)
 	assert.Equal(t, "b", model.Sex, "expecting 'b'")
 
-	model.Penabled = ptr(false)
-	res = db.WithContext(currentContext()).Model(&model).Clauses(clause.Returning{}).Updates(map[string]any{
-		"name":      "charlie",
-		"sex":       "m",
-		"enabled":   true,
-		"penabled":  ptr(true),
-		"user_type": gorm.Expr(`user_type + 1`),
-	})
-	assert.NoError(t, res.Error, "expecting no error")
-	assert.Equal(t, modelId, model.ID)
-	assert.Equal(t, "charlie", model.Name, "expecting 'charlie'")
-	assert.Equal(t, "m", model.Sex, "expecting 'm'")
-	assert.Equal(t, true, model.Enabled, "expecting 'true'")
-	assert.Equal(t, ptr(true), model.Penabled, "expecting '*true'")
+	model.Penabled = ptr(false)
+	res = db.WithContext(currentContext()).Model(&model).Clauses(clause.Returning{}).Updates(map[string]any{
+		"name":      "charlie",
+		"sex":       "m",
+		"enabled":   true,
+		"penabled":  ptr(true),
+		"user_type": gorm.Expr(`user_type + 1`),
+	})
+	assert.NoError(t, res.Error, "expecting no error")
+	assert.Equal(t, modelId, model.ID)
+	assert.Equal(t, "charlie", model.Name, "expecting 'charlie'")
+	assert.Equal(t, "m", model.Sex, "expecting 'm'")
+	assert.Equal(t, true, model.Enabled, "expecting 'true'")
+	assert.Equal(t, ptr(true), model.Penabled, "expecting '*true'")
+
+	tm := &TestTableUser{
+		ID:   modelId,
+		Name: "doug",
+	}
+	res = db.WithContext(currentContext()).Model(tm).Clauses(clause.Returning{}).Updates(tm)
+	assert.NoError(t, res.Error, "expecting no error")
+	assert.Equal(t, model.ID, tm.ID)
+	assert.Equal(t, "doug", tm.Name, "expecting 'doug'")
+	assert.Equal(t, "m", tm.Sex, "expecting 'm'")
+	assert.Equal(t, true, tm.Enabled, "expecting 'true'")
+	assert.Equal(t, 2, tm.UserType, "expecting '2'")
+	assert.Equal(t, theBirthday.Format("2006-01-02 15:04:05"), tm.Birthday.Format("2006-01-02 15:04:05"), "expecting '1978-05-01 00:00:00'")
+	assert.Equal(t, true, *tm.Penabled, "expecting '(*bool)true'")
+
+	ttm := &TestTableUser{
+		ID:   modelId,
+		Name: "evan",
+	}
+	res = db.WithContext(currentContext()).Updates(ttm)
+	assert.NoError(t, res.Error, "expecting no error")
+	assert.Equal(t, modelId, model.ID)
+	assert.Equal(t, "evan", ttm.Name, "expecting 'evan'")
+	assert.Equal(t, "", ttm.Sex, "expecting ''")
+	assert.Equal(t, false, ttm.Enabled, "expecting 'false'")
+	assert.Equal(t, 0, ttm.UserType, "expecting '0'")
+	assert.Nil(t, ttm.Birthday, "expecting '(*time.Time)nil'")
+	assert.Nil(t, ttm.Penabled, "expecting '(*bool)nil'")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// ==== SQL generation and session behavior ====
+
+func TestLimit(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	TestMergeCreate(t)
+
+	type args struct {
+		offset, limit int
+		order         string
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{name: "OffsetLimit0", args: args{offset: 0, limit: 0}},
+		{name: "Offset10", args: args{offset: 10, limit: 0}},
+		{name: "Limit10", args: args{offset: 0, limit: 10}},
+		{name: "Offset10Limit10", args: args{offset: 10, limit: 10}},
+		{name: "Offset10Limit10Order", args: args{offset: 10, limit: 10, order: `id`}},
+		{name: "Offset10Limit10OrderDESC", args: args{offset: 10, limit: 10, order: `id DESC`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []TestTableUser
+			result := db.Model(&TestTableUser{}).
+				Offset(tt.args.offset).
+				Limit(tt.args.limit).
+				Order(tt.args.order).
+				Find(&data)
+			if err = result.Error; err != nil {
+				t.Fatal(err)
+			}
+			dataBytes, _ := json.MarshalIndent(data, "", "  ")
+			t.Logf("Offset(%d) Limit(%d) got size = %d, data = %s",
+				tt.args.offset, tt.args.limit, len(data), dataBytes)
+		})
+	}
+}
+
+func TestAddSessionParams(t *testing.T) {
+	db, err := dbIgnoreCase, dbErrors[1]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		params map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{name: "TimeParams", args: args{params: map[string]string{
+			"TIME_ZONE":               "+08:00",                       // alter session set TIME_ZONE = '+08:00';
+			"NLS_DATE_FORMAT":         "YYYY-MM-DD",                   // alter session set NLS_DATE_FORMAT = 'YYYY-MM-DD';
+			"NLS_TIME_FORMAT":         "HH24:MI:SSXFF",                // alter session set NLS_TIME_FORMAT = 'HH24:MI:SS.FF3';
+			"NLS_TIMESTAMP_FORMAT":    "YYYY-MM-DD HH24:MI:SSXFF",     // alter session set NLS_TIMESTAMP_FORMAT = 'YYYY-MM-DD HH24:MI:SS.FF3';
+			"NLS_TIME_TZ_FORMAT":      "HH24:MI:SS.FF TZR",            // alter session set NLS_TIME_TZ_FORMAT = 'HH24:MI:SS.FF3 TZR';
+			"NLS_TIMESTAMP_TZ_FORMAT": "YYYY-MM-DD HH24:MI:SSXFF TZR", // alter session set NLS_TIMESTAMP_TZ_FORMAT = 'YYYY-MM-DD HH24:MI:SS.FF3 TZR';
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//queryTime := `SELECT SYSDATE FROM DUAL`
+			queryTime := `SELECT CAST(SYSDATE AS VARCHAR(30)) AS D FROM DUAL`
+			var timeStr string
+			if err = db.Raw(queryTime).Row().Scan(&timeStr); err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("SYSDATE 1: %s", timeStr)
+
+			var keys []string
+			if keys, err = AddSessionParams(sqlDB, tt.args.params); err != nil {
+				t.Fatalf("AddSessionParams() error = %v", err)
+			}
+			if err = db.Raw(queryTime).Row().Scan(&timeStr); err != nil {
+				t.Fatal(err)
+			}
+			defer DelSessionParams(sqlDB, keys)
+			t.Logf("SYSDATE 2: %s", timeStr)
+			t.Logf("keys: %#v", keys)
+		})
+	}
+}
+
+func TestSetModule(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	err = db.Connection(func(tx *gorm.DB) error {
+		if err := SetModule(tx, "gorm-oracle", "test-module"); err != nil {
+			return err
+		}
+		var module, action string
+		if err := tx.Raw("SELECT SYS_CONTEXT('USERENV','MODULE'), SYS_CONTEXT('USERENV','ACTION') FROM DUAL").Row().Scan(&module, &action); err != nil {
+			return err
+		}
+		assert.Equal(t, "gorm-oracle", module)
+		assert.Equal(t, "test-module", action)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		t.Fatal(err)
+	}
+	tagged, err := gorm.Open(New(Config{Conn: sqlDB, TagActionWithCallback: true}), &gorm.Config{NamingStrategy: &NamingStrategy{}})
+	require.NoError(t, err)
+
+	err = tagged.Connection(func(tx *gorm.DB) error {
+		var action string
+		if err := tx.Raw("SELECT SYS_CONTEXT('USERENV','ACTION') FROM DUAL").Row().Scan(&action); err != nil {
+			return err
+		}
+		assert.Equal(t, "gorm:row", action)
+		if err := tx.Raw("SELECT SYS_CONTEXT('USERENV','ACTION') FROM DUAL").Find(&action).Error; err != nil {
+			return err
+		}
+		assert.Equal(t, "gorm:query", action)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestSetClientIdentifier(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	const query = "SELECT NVL(SYS_CONTEXT('USERENV','CLIENT_IDENTIFIER'), ' ') FROM DUAL"
+	err = db.Connection(func(tx *gorm.DB) error {
+		if err := SetClientIdentifier(tx, "user-42"); err != nil {
+			return err
+		}
+		var id string
+		if err := tx.Raw(query).Row().Scan(&id); err != nil {
+			return err
+		}
+		assert.Equal(t, "user-42", id)
+
+		if err := SetClientIdentifier(tx, ""); err != nil {
+			return err
+		}
+		if err := tx.Raw(query).Row().Scan(&id); err != nil {
+			return err
+		}
+		assert.Equal(t, " ", id)
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = WithClientIdentifier(db, "user-43", func(tx *gorm.DB) error {
+		var id string
+		if err := tx.Raw(query).Row().Scan(&id); err != nil {
+			return err
+		}
+		assert.Equal(t, "user-43", id)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+type TestTableSessionTimezone struct {
+	ID     uint64     `gorm:"primaryKey"`
+	At     time.Time  `gorm:"type:timestamp with time zone"`
+	Local  time.Time  `gorm:"type:timestamp with local time zone"`
+	Booked *time.Time `gorm:"type:date"`
+}
+
+func (TestTableSessionTimezone) TableName() string {
+	return "test_session_timezone"
+}
+
+func TestWithSessionTimezone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	ctx := WithSessionTimezone(context.Background(), ny)
+	assert.Nil(t, sessionTimezone(context.Background()))
+	assert.Equal(t, ny, sessionTimezone(ctx))
+	assert.Equal(t, ny, sessionLocation(&gorm.Statement{Context: ctx}))
+	assert.Equal(t, "America/New_York", timezoneName(ny))
+	assert.Equal(t, "+02:00", timezoneName(time.FixedZone("", 2*60*60)))
+	assert.Equal(t, "+08:00", timezoneName(time.FixedZone("UTC+8", 8*60*60)), "expecting a name Oracle does not know mapped to its offset")
+	assert.Equal(t, "UTC", timezoneName(time.UTC))
+	assert.Equal(t, "ALTER SESSION SET TIME_ZONE = 'x'' OR ''1'", setTimezoneStatement("x' OR '1"))
+
+	// MERGE casts the times of an upsert in the overridden zone too
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: Dialector{Config: &Config{}}}}, Context: ctx}
+	utc := time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC)
+	assert.Equal(t, castTime(utc.In(ny), "DATE", 0, nil), castValue(stmt, utc, "DATE", 0, false))
+	assert.Equal(t, castTime(utc.In(ny), "TIMESTAMP WITH LOCAL TIME ZONE", 0, nil), castValue(stmt, utc, "TIMESTAMP WITH LOCAL TIME ZONE", 0, false))
+	assert.Equal(t, castTime(utc, "TIMESTAMP WITH TIME ZONE", 0, nil), castValue(stmt, utc, "TIMESTAMP WITH TIME ZONE", 0, false), "expecting a time with its zone kept")
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	db = db.WithContext(currentContext())
+
+	var poolZone string
+	require.NoError(t, db.Raw("SELECT SESSIONTIMEZONE FROM DUAL").Find(&poolZone).Error)
+	require.NotEqual(t, "America/New_York", poolZone)
+
+	_ = db.Migrator().DropTable(&TestTableSessionTimezone{})
+	require.NoError(t, db.Migrator().AutoMigrate(&TestTableSessionTimezone{}))
+
+	tenant := db.WithContext(WithSessionTimezone(currentContext(), ny))
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, ny)
+	require.NoError(t, tenant.Create(&TestTableSessionTimezone{ID: 1, At: at, Local: at}).Error)
+
+	var row TestTableSessionTimezone
+	require.NoError(t, tenant.First(&row, 1).Error)
+	assert.True(t, at.Equal(row.At), "expecting %v, got %v", at, row.At)
+	_, offset := row.At.Zone()
+	assert.Equal(t, -5*60*60, offset, "expecting the timestamp read back in the overridden zone")
+	assert.True(t, at.Equal(row.Local), "expecting %v, got %v", at, row.Local)
+
+	// an upsert updating row 1 and inserting row 2 writes the wall clock of the overridden zone
+	booked := time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC)
+	require.NoError(t, tenant.Clauses(clause.OnConflict{UpdateAll: true}).Create(&[]TestTableSessionTimezone{
+		{ID: 1, At: booked, Local: booked, Booked: &booked},
+		{ID: 2, At: booked, Local: booked, Booked: &booked},
+	}).Error)
+	var wallClocks []string
+	require.NoError(t, db.Model(&TestTableSessionTimezone{}).Order("id").Pluck("TO_CHAR(booked, 'HH24:MI:SS')", &wallClocks).Error)
+	assert.Equal(t, []string{"03:04:05", "03:04:05"}, wallClocks, "expecting the DATE written in America/New_York")
+	var merged []TestTableSessionTimezone
+	require.NoError(t, tenant.Order("id").Find(&merged).Error)
+	require.Len(t, merged, 2)
+	for _, m := range merged {
+		assert.True(t, booked.Equal(m.At), "expecting %v, got %v", booked, m.At)
+		assert.True(t, booked.Equal(m.Local), "expecting %v, got %v", booked, m.Local)
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var zone string
+		if err := tx.WithContext(WithSessionTimezone(currentContext(), ny)).Raw("SELECT SESSIONTIMEZONE FROM DUAL").Find(&zone).Error; err != nil {
+			return err
+		}
+		assert.Equal(t, "America/New_York", zone)
+		if err := tx.Raw("SELECT SESSIONTIMEZONE FROM DUAL").Find(&zone).Error; err != nil {
+			return err
+		}
+		assert.Equal(t, poolZone, zone, "expecting the session zone restored")
+
+		// the zone the session had is restored, not the pool's
+		if err := tx.Exec("ALTER SESSION SET TIME_ZONE = '+03:00'").Error; err != nil {
+			return err
+		}
+		var rows []TestTableSessionTimezone
+		if err := tx.WithContext(WithSessionTimezone(currentContext(), ny)).Find(&rows).Error; err != nil {
+			return err
+		}
+		if err := tx.Raw("SELECT SESSIONTIMEZONE FROM DUAL").Find(&zone).Error; err != nil {
+			return err
+		}
+		assert.Equal(t, "+03:00", zone, "expecting the previous session zone restored")
+		return tx.Exec("ALTER SESSION SET TIME_ZONE = '" + poolZone + "'").Error
+	})
+	require.NoError(t, err)
+}
+
+func TestCallTimeout(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		t.Fatal(err)
+	}
+	timed, err := gorm.Open(New(Config{Conn: sqlDB, CallTimeout: time.Second}), &gorm.Config{NamingStrategy: &NamingStrategy{}, TranslateError: true})
+	require.NoError(t, err)
+
+	start := time.Now()
+	err = timed.Exec("BEGIN DBMS_SESSION.SLEEP(10); END;").Error
+	elapsed := time.Since(start)
+	require.Error(t, err, "expected the sleeping call to be aborted")
+	assert.Less(t, elapsed, 5*time.Second, "expected the call to be aborted at the timeout")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	t.Logf("aborted after %s: %v", elapsed, err)
+
+	var one int
+	require.NoError(t, timed.Raw("SELECT 1 FROM DUAL").Row().Scan(&one), "expected short calls to complete")
+	assert.Equal(t, 1, one)
+}
+
+func TestTranslateTimeoutErrors(t *testing.T) {
+	d := Dialector{Config: &Config{}}
+	for _, code := range []int{40, 3156, 51616} {
+		err := d.Translate(network.NewOracleError(code))
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "ORA-%05d", code)
+	}
+	assert.NotErrorIs(t, d.Translate(network.NewOracleError(1)), context.DeadlineExceeded)
+}
 
-	tm := &TestTableUser{
-		ID:   modelId,
-		Name: "doug",
+func TestContextCancel(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
 	}
-	res = db.WithContext(currentContext()).Model(tm).Clauses(clause.Returning{}).Updates(tm)
-	assert.NoError(t, res.Error, "expecting no error")
-	assert.Equal(t, model.ID, tm.ID)
-	assert.Equal(t, "doug", tm.Name, "expecting 'doug'")
-	assert.Equal(t, "m", tm.Sex, "expecting 'm'")
-	assert.Equal(t, true, tm.Enabled, "expecting 'true'")
-	assert.Equal(t, 2, tm.UserType, "expecting '2'")
-	assert.Equal(t, theBirthday.Format("2006-01-02 15:04:05"), tm.Birthday.Format("2006-01-02 15:04:05"), "expecting '1978-05-01 00:00:00'")
-	assert.Equal(t, true, *tm.Penabled, "expecting '(*bool)true'")
 
-	ttm := &TestTableUser{
-		ID:   modelId,
-		Name: "evan",
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		t.Fatal(err)
 	}
-	res = db.WithContext(currentContext()).Updates(ttm)
-	assert.NoError(t, res.Error, "expecting no error")
-	assert.Equal(t, modelId, model.ID)
-	assert.Equal(t, "evan", ttm.Name, "expecting 'evan'")
-	assert.Equal(t, "", ttm.Sex, "expecting ''")
-	assert.Equal(t, false, ttm.Enabled, "expecting 'false'")
-	assert.Equal(t, 0, ttm.UserType, "expecting '0'")
-	assert.Nil(t, ttm.Birthday, "expecting '(*time.Time)nil'")
-	assert.Nil(t, ttm.Penabled, "expecting '(*bool)nil'")
+	tx, err := gorm.Open(New(Config{Conn: sqlDB}), &gorm.Config{NamingStrategy: &NamingStrategy{}, TranslateError: true})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second, cancel)
+	start := time.Now()
+	err = tx.WithContext(ctx).Exec("BEGIN DBMS_SESSION.SLEEP(10); END;").Error
+	require.Error(t, err, "expected the sleeping call to be canceled")
+	assert.Less(t, time.Since(start), 5*time.Second, "expected the call to be canceled promptly")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
 }
 
-func ptr[T any](v T) *T {
-	return &v
+func TestTranslateContextErrors(t *testing.T) {
+	d := Dialector{Config: &Config{}}
+	assert.ErrorIs(t, d.Translate(network.NewOracleError(1013)), context.Canceled)
+	assert.ErrorIs(t, d.Translate(context.DeadlineExceeded), context.DeadlineExceeded)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	db := &gorm.DB{
+		Config:    &gorm.Config{TranslateError: true},
+		Statement: &gorm.Statement{Context: ctx},
+		Error:     d.Translate(network.NewOracleError(1013)),
+	}
+	translateContextError(db)
+	assert.ErrorIs(t, db.Error, context.DeadlineExceeded)
+	assert.NotErrorIs(t, db.Error, context.Canceled)
 }
 
-// ==== SQL generation and session behavior ====
+func TestTranslateDistinctOrderBy(t *testing.T) {
+	d := Dialector{Config: &Config{}}
+	err := d.Translate(network.NewOracleError(1791))
+	assert.ErrorContains(t, err, "ORDER BY of a DISTINCT query")
+	assert.Equal(t, 1791, oracleErrorCode(err), "expecting the ORA error wrapped")
 
-func TestLimit(t *testing.T) {
 	db, err := dbNamingCase, dbErrors[0]
 	if err != nil {
 		t.Fatal(err)
@@ -1654,44 +4359,75 @@ func TestLimit(t *testing.T) {
 		t.Log("db is nil!")
 		return
 	}
-	TestMergeCreate(t)
+	_ = db.Migrator().DropTable(&TestTableDefaultValues{})
+	require.NoError(t, db.Migrator().AutoMigrate(TestTableDefaultValues{}), "expecting no error")
 
-	type args struct {
-		offset, limit int
-		order         string
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		t.Fatal(err)
 	}
-	tests := []struct {
-		name string
-		args args
-	}{
-		{name: "OffsetLimit0", args: args{offset: 0, limit: 0}},
-		{name: "Offset10", args: args{offset: 10, limit: 0}},
-		{name: "Limit10", args: args{offset: 0, limit: 10}},
-		{name: "Offset10Limit10", args: args{offset: 10, limit: 10}},
-		{name: "Offset10Limit10Order", args: args{offset: 10, limit: 10, order: `id`}},
-		{name: "Offset10Limit10OrderDESC", args: args{offset: 10, limit: 10, order: `id DESC`}},
+	translated, err := gorm.Open(New(Config{Conn: sqlDB}), &gorm.Config{NamingStrategy: &NamingStrategy{}, TranslateError: true})
+	require.NoError(t, err)
+
+	var names []string
+	err = translated.Model(&TestTableDefaultValues{}).Distinct("name").Order("count").Pluck("name", &names).Error
+	require.Error(t, err, "expecting ORA-01791")
+	assert.ErrorContains(t, err, "select the column or drop Distinct")
+
+	require.NoError(t, translated.Model(&TestTableDefaultValues{}).Distinct("name").Order("name").Pluck("name", &names).Error)
+}
+
+func TestTranslateConstraintErrors(t *testing.T) {
+	d := Dialector{Config: &Config{}}
+	for code, sentinel := range map[int]error{
+		1:    gorm.ErrDuplicatedKey,
+		2291: gorm.ErrForeignKeyViolated,
+		2292: gorm.ErrForeignKeyViolated,
+		2290: gorm.ErrCheckConstraintViolated,
+		1400: ErrNotNullViolated,
+		1407: ErrNotNullViolated,
+	} {
+		oraErr := network.NewOracleError(code)
+		err := d.Translate(oraErr)
+		assert.ErrorIs(t, err, sentinel, "ORA-%05d", code)
+		assert.Equal(t, oraErr, errors.Unwrap(err), "expecting the ORA error as the cause of ORA-%05d", code)
+		assert.Equal(t, code, oracleErrorCode(err))
+		assert.ErrorContains(t, err, oraErr.Error())
 	}
+	assert.NotErrorIs(t, d.Translate(network.NewOracleError(942)), gorm.ErrDuplicatedKey)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			var data []TestTableUser
-			result := db.Model(&TestTableUser{}).
-				Offset(tt.args.offset).
-				Limit(tt.args.limit).
-				Order(tt.args.order).
-				Find(&data)
-			if err = result.Error; err != nil {
-				t.Fatal(err)
-			}
-			dataBytes, _ := json.MarshalIndent(data, "", "  ")
-			t.Logf("Offset(%d) Limit(%d) got size = %d, data = %s",
-				tt.args.offset, tt.args.limit, len(data), dataBytes)
-		})
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
 	}
+	translated, err := gorm.Open(New(Config{Conn: sqlDB}), &gorm.Config{NamingStrategy: &NamingStrategy{}, TranslateError: true})
+	require.NoError(t, err)
+
+	_ = translated.Migrator().DropTable(&TestTableLockOrder{}, &TestTableLockCustomer{})
+	require.NoError(t, translated.Migrator().AutoMigrate(&TestTableLockCustomer{}, &TestTableLockOrder{}))
+	require.NoError(t, translated.Create(&TestTableLockCustomer{ID: 1, Name: "c"}).Error)
+
+	err = translated.Create(&TestTableLockCustomer{ID: 1, Name: "d"}).Error
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	err = translated.Omit("Customer").Create(&TestTableLockOrder{ID: 1, CustomerID: 2}).Error
+	assert.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+	err = translated.Exec("INSERT INTO \"TEST_LOCK_CUSTOMER\" (\"ID\") VALUES (NULL)").Error
+	assert.ErrorIs(t, err, ErrNotNullViolated)
 }
 
-func TestAddSessionParams(t *testing.T) {
-	db, err := dbIgnoreCase, dbErrors[1]
+func TestDummyTable(t *testing.T) {
+	assert.Equal(t, "DUAL", Dialector{Config: &Config{}}.DummyTableName())
+	assert.Equal(t, "MY_DUAL", Dialector{Config: &Config{DummyTable: "MY_DUAL"}}.DummyTableName())
+
+	db, err := dbNamingCase, dbErrors[0]
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1699,48 +4435,58 @@ func TestAddSessionParams(t *testing.T) {
 		t.Log("db is nil!")
 		return
 	}
+
 	var sqlDB *sql.DB
 	if sqlDB, err = db.DB(); err != nil {
 		t.Fatal(err)
 	}
-	type args struct {
-		params map[string]string
+	custom, err := gorm.Open(New(Config{Conn: sqlDB, DummyTable: "MY_DUAL"}), &gorm.Config{NamingStrategy: &NamingStrategy{}})
+	require.NoError(t, err)
+	dryRun := custom.Session(&gorm.Session{DryRun: true})
+
+	// a configured DummyTable is read from on Oracle 23 too, see TestDummyFrom
+	var rows []map[string]any
+	stmt := dryRun.Table("test_user").Limit(1).Find(&rows).Statement
+	assert.Contains(t, stmt.SQL.String(), "(SELECT NULL FROM MY_DUAL)")
+
+	stmt = dryRun.Clauses(clause.OnConflict{UpdateAll: true}).Create(&TestTableUserUnique{ID: 1, UID: "U1", Name: "Alpha"}).Statement
+	assert.NotContains(t, stmt.SQL.String(), "FROM DUAL")
+	assert.Contains(t, stmt.SQL.String(), "FROM MY_DUAL")
+}
+
+func TestDummyFrom(t *testing.T) {
+	assert.Equal(t, " FROM DUAL", Dialector{Config: &Config{DBVer: "19.3.0.0.0"}}.dummyFrom())
+	assert.Equal(t, " FROM MY_DUAL", Dialector{Config: &Config{DBVer: "21.3.0.0.0", DummyTable: "MY_DUAL"}}.dummyFrom())
+	assert.Equal(t, "", Dialector{Config: &Config{DBVer: "23.5.0.24.07"}}.dummyFrom())
+	assert.Equal(t, " FROM MY_DUAL", Dialector{Config: &Config{DBVer: "23.5.0.24.07", DummyTable: "MY_DUAL"}}.dummyFrom(),
+		"expecting a configured DummyTable on Oracle 23 too")
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
 	}
-	tests := []struct {
-		name string
-		args args
-	}{
-		{name: "TimeParams", args: args{params: map[string]string{
-			"TIME_ZONE":               "+08:00",                       // alter session set TIME_ZONE = '+08:00';
-			"NLS_DATE_FORMAT":         "YYYY-MM-DD",                   // alter session set NLS_DATE_FORMAT = 'YYYY-MM-DD';
-			"NLS_TIME_FORMAT":         "HH24:MI:SSXFF",                // alter session set NLS_TIME_FORMAT = 'HH24:MI:SS.FF3';
-			"NLS_TIMESTAMP_FORMAT":    "YYYY-MM-DD HH24:MI:SSXFF",     // alter session set NLS_TIMESTAMP_FORMAT = 'YYYY-MM-DD HH24:MI:SS.FF3';
-			"NLS_TIME_TZ_FORMAT":      "HH24:MI:SS.FF TZR",            // alter session set NLS_TIME_TZ_FORMAT = 'HH24:MI:SS.FF3 TZR';
-			"NLS_TIMESTAMP_TZ_FORMAT": "YYYY-MM-DD HH24:MI:SSXFF TZR", // alter session set NLS_TIMESTAMP_TZ_FORMAT = 'YYYY-MM-DD HH24:MI:SS.FF3 TZR';
-		}}},
+	if db == nil {
+		t.Log("db is nil!")
+		return
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			//queryTime := `SELECT SYSDATE FROM DUAL`
-			queryTime := `SELECT CAST(SYSDATE AS VARCHAR(30)) AS D FROM DUAL`
-			var timeStr string
-			if err = db.Raw(queryTime).Row().Scan(&timeStr); err != nil {
-				t.Fatal(err)
-			}
-			t.Logf("SYSDATE 1: %s", timeStr)
-
-			var keys []string
-			if keys, err = AddSessionParams(sqlDB, tt.args.params); err != nil {
-				t.Fatalf("AddSessionParams() error = %v", err)
-			}
-			if err = db.Raw(queryTime).Row().Scan(&timeStr); err != nil {
-				t.Fatal(err)
-			}
-			defer DelSessionParams(sqlDB, keys)
-			t.Logf("SYSDATE 2: %s", timeStr)
-			t.Logf("keys: %#v", keys)
-		})
+	d := db.Dialector.(*Dialector)
+	if dbVer, _ := strconv.Atoi(strings.Split(d.DBVer, ".")[0]); dbVer < 23 {
+		t.Skipf("SELECT without FROM needs Oracle 23, have %s", d.DBVer)
 	}
+
+	model := TestTableUserUnique{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model))
+
+	users := []TestTableUserUnique{{UID: "U1", Name: "Alpha"}, {UID: "U2", Name: "Beta"}}
+	onConflict := clause.OnConflict{Columns: []clause.Column{{Name: "uid"}}, DoUpdates: clause.AssignmentColumns([]string{"name"})}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Clauses(onConflict).Create(&users).Statement
+	assert.Contains(t, stmt.SQL.String(), "MERGE INTO")
+	assert.NotContains(t, stmt.SQL.String(), "FROM DUAL")
+
+	res := db.Clauses(onConflict).Create(&users)
+	require.NoError(t, res.Error)
+	assert.EqualValues(t, 2, res.RowsAffected)
 }
 
 func TestGetStringExpr(t *testing.T) {
@@ -1847,6 +4593,356 @@ func (TestTableUserVarcharSize) TableName() string {
 	return "test_user_varchar_size"
 }
 
+func TestIsReservedWord(t *testing.T) {
+	for _, word := range []string{"DATE", "date", " Level ", "ROWID", "UID"} {
+		assert.True(t, IsReservedWord(word), word)
+	}
+	for _, word := range []string{"", "NAME", "DATES", "PRIMARY KEY", "DATE DESC", "LONG RAW", "TIMESTAMP WITH LOCAL TIME ZONE"} {
+		assert.False(t, IsReservedWord(word), word)
+	}
+
+	assert.False(t, IsSafeOracleUnquoted("LEVEL"))
+	assert.False(t, IsSafeOracleUnquoted("LONG RAW"))
+	assert.True(t, IsSafeOracleUnquoted("LEVELS"))
+
+	for _, typ := range []string{
+		"TIMESTAMP WITH TIME ZONE", "timestamp(6) with local time zone", "INTERVAL DAY(2) TO SECOND(6)",
+		"INTERVAL YEAR TO MONTH", "LONG  RAW", "DOUBLE PRECISION", "NATIONAL CHARACTER VARYING(20)",
+	} {
+		assert.True(t, IsTypePhrase(typ), typ)
+	}
+	for _, typ := range []string{"", "DATE", "RAW(16)", "VARCHAR2(10 CHAR)", "PRIMARY KEY", "TIMESTAMP WITH ZONE"} {
+		assert.False(t, IsTypePhrase(typ), typ)
+	}
+
+	assert.Equal(t, len("TIMESTAMP(3) WITH LOCAL TIME ZONE"), typePhraseLen("TIMESTAMP(3) WITH LOCAL TIME ZONE) + zone"))
+	assert.Equal(t, len("interval day(2) to second"), typePhraseLen("interval day(2) to second, x"))
+	assert.Zero(t, typePhraseLen("zone || 'x'"))
+	assert.Zero(t, typePhraseLen("TIMESTAMP WITH ZONE"))
+
+	// the NULL of a multi-word type is cast like any other
+	for typ, want := range map[string]any{
+		"TIMESTAMP(3) WITH TIME ZONE":  clause.Expr{SQL: "CAST(NULL AS TIMESTAMP(3) WITH TIME ZONE)"},
+		"interval day(9) to second(9)": clause.Expr{SQL: "CAST(NULL AS INTERVAL DAY(9) TO SECOND(9))"},
+		"LONG RAW":                     clause.Expr{SQL: "CAST(NULL AS LONG RAW)"},
+		"RAW(16)":                      clause.Expr{SQL: "CAST(NULL AS RAW(16))"},
+		"PRIMARY KEY":                  nil,
+	} {
+		assert.Equal(t, want, castNullExpr(typ), typ)
+	}
+
+	sch, err := schema.Parse(&testPhraseExprModel{}, &sync.Map{}, &NamingStrategy{})
+	require.NoError(t, err)
+	stmt := &gorm.Statement{DB: &gorm.DB{Config: &gorm.Config{Dialector: Dialector{Config: &Config{namingStrategy: &NamingStrategy{}}}}}, Schema: sch}
+	assert.Equal(t, `CAST(`+stmt.Quote("zone")+` AS TIMESTAMP WITH LOCAL TIME ZONE)`, quoteExprColumns(stmt, "CAST(zone AS TIMESTAMP WITH LOCAL TIME ZONE)"),
+		"expecting the column quoted, not the words of the type")
+}
+
+// testPhraseExprModel has a column named like a word of TIMESTAMP WITH LOCAL TIME ZONE
+type testPhraseExprModel struct {
+	ID   uint
+	Zone string
+}
+
+func FuzzGeneratedNames(f *testing.F) {
+	f.Add("users", "name")
+	f.Add("1users", "2col")
+	f.Add("ünïcode_表", "列_名")
+	f.Add(`"Weird"`, "9")
+	f.Add("owner.orders", "customer id")
+	f.Add("", "")
+	f.Add(strings.Repeat("X", 200), strings.Repeat("é", 40))
+	f.Fuzz(func(t *testing.T, table, column string) {
+		for _, ns := range []*NamingStrategy{{}, {IdentifierMaxLength: 30}, {IdentifierMaxLength: 128}} {
+			names := map[string]string{
+				"IDX": ns.IndexName(table, column),
+				"UK":  ns.UniqueName(table, column),
+				"CK":  ns.CheckerName(table, column),
+				"FK": ns.RelationshipFKName(schema.Relationship{
+					Schema:     &schema.Schema{Table: table},
+					References: []*schema.Reference{{ForeignKey: &schema.Field{DBName: column}}},
+				}),
+			}
+			for kind, name := range names {
+				require.True(t, strings.HasPrefix(name, kind+"_"), "expecting %s to start with %s_", name, kind)
+				require.LessOrEqual(t, len(name), ns.maxLength(), name)
+				require.True(t, IsSafeOracleUnquoted(name), "expecting %q to be a safe unquoted identifier", name)
+			}
+		}
+	})
+}
+
+func TestGeneratedNamesKeepDistinctInputsApart(t *testing.T) {
+	ns := &NamingStrategy{IdentifierMaxLength: 30}
+	assert.Equal(t, "IDX_USERS_NAME", ns.IndexName("users", "name"))
+	a, b := ns.IndexName("t", "é"), ns.IndexName("t", "è")
+	assert.NotEqual(t, a, b, "expecting the hash to tell replaced characters apart")
+	assert.True(t, strings.HasPrefix(a, "IDX_T___"), a)
+}
+
+func TestShortenMultibyteIdentifier(t *testing.T) {
+	ns := &NamingStrategy{IdentifierMaxLength: 30}
+	// 2-byte runes: the 21-byte cut before the hash suffix lands inside a rune
+	name := strings.Repeat("é", 20)
+	got := ns.shortenIfNeeded(name)
+	assert.True(t, utf8.ValidString(got), got)
+	assert.LessOrEqual(t, len(got), 30)
+	assert.True(t, strings.HasPrefix(got, strings.Repeat("é", 10)+"_"), got)
+
+	// 3-byte runes through the qualified path
+	got = ns.joinQualified([]qualifier{{name: strings.Repeat("表", 15), quoted: true}})
+	assert.True(t, utf8.ValidString(got), got)
+	assert.LessOrEqual(t, len(got), 32)
+}
+
+// TestGeneratedNamesGolden pins generated names: HasIndex/HasConstraint look up the names
+// created by earlier migrations, so any change here breaks existing schemas.
+func TestGeneratedNamesGolden(t *testing.T) {
+	fk := schema.Relationship{
+		Name:       "Customer",
+		Schema:     &schema.Schema{Table: "orders"},
+		References: []*schema.Reference{{ForeignKey: &schema.Field{DBName: "customer_id"}}},
+	}
+	longTable, longColumn := "customer_shipping_addresses_archive_history", "primary_contact_email_address_verified"
+	tests := []struct {
+		name string
+		max  int
+		gen  func(ns *NamingStrategy) string
+		want string
+	}{
+		{"index", 30, func(ns *NamingStrategy) string { return ns.IndexName("users", "name") }, "IDX_USERS_NAME"},
+		{"index owner", 30, func(ns *NamingStrategy) string { return ns.IndexName("app.users", "email") }, "IDX_USERS_EMAIL"},
+		{"unique", 30, func(ns *NamingStrategy) string { return ns.UniqueName("users", "email") }, "UK_USERS_EMAIL"},
+		{"check", 30, func(ns *NamingStrategy) string { return ns.CheckerName("orders", "chk_total") }, "CK_ORDERS_CHK_TOTAL"},
+		{"foreign key", 30, func(ns *NamingStrategy) string { return ns.RelationshipFKName(fk) }, "FK_ORDERS_CUSTOMER_ID"},
+		{"index hashed", 30, func(ns *NamingStrategy) string {
+			return ns.IndexName("customer_shipping_addresses", "postal_code_and_region")
+		}, "IDX_CUSTOMER_SHIPPING_7838553D"},
+		{"unique hashed", 30, func(ns *NamingStrategy) string { return ns.UniqueName(longTable, longColumn) }, "UK_CUSTOMER_SHIPPING__81969CDD"},
+		{"unique 128", 128, func(ns *NamingStrategy) string { return ns.UniqueName(longTable, longColumn) },
+			"UK_CUSTOMER_SHIPPING_ADDRESSES_ARCHIVE_HISTORY_PRIMARY_CONTACT_EMAIL_ADDRESS_VERIFIED"},
+		{"replaced characters", 30, func(ns *NamingStrategy) string { return ns.IndexName("t", "é") }, "IDX_T___FA8527A5"},
+		{"shortened", 30, func(ns *NamingStrategy) string {
+			return ns.shortenIfNeeded("a_very_long_table_name_that_exceeds_thirty_bytes")
+		}, "a_very_long_table_nam_739FB244"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &NamingStrategy{IdentifierMaxLength: tt.max}
+			assert.Equal(t, tt.want, tt.gen(ns))
+			assert.Equal(t, tt.want, tt.gen(&NamingStrategy{IdentifierMaxLength: tt.max}), "expecting a fresh strategy to generate the same name")
+		})
+	}
+}
+
+func TestHashSuffixLength(t *testing.T) {
+	long := "a_very_long_table_name_that_exceeds_thirty_bytes"
+	def := &NamingStrategy{IdentifierMaxLength: 30}
+	assert.Equal(t, def.shortenIfNeeded(long), (&NamingStrategy{IdentifierMaxLength: 30, HashSuffixLength: 8}).shortenIfNeeded(long))
+
+	short := &NamingStrategy{IdentifierMaxLength: 30, HashSuffixLength: 4}
+	got := short.shortenIfNeeded(long)
+	assert.Len(t, got, 30)
+	assert.Equal(t, long[:25]+"_739F", got, "expecting the leading digits of the default hash")
+	assert.Equal(t, "IDX_CUSTOMER_SHIPPING_ADD_7838", short.IndexName("customer_shipping_addresses", "postal_code_and_region"))
+
+	// the suffix is capped to the hash size
+	capped := &NamingStrategy{IdentifierMaxLength: 30, HashSuffixLength: 32}
+	assert.Equal(t, def.shortenIfNeeded(long), capped.shortenIfNeeded(long))
+
+	wide := &NamingStrategy{IdentifierMaxLength: 30, HashSuffixLength: 16, NewHash: func() hash.Hash { return fnv.New64a() }}
+	got = wide.shortenIfNeeded(long)
+	assert.Len(t, got, 30)
+	assert.True(t, strings.HasPrefix(got, long[:13]+"_"), got)
+	name := wide.UniqueName("customer_shipping_addresses_archive_history", "primary_contact_email_address_verified")
+	assert.Len(t, name, 30)
+	assert.Regexp(t, `^UK_CUSTOMER_S_[0-9A-F]{16}$`, name)
+
+	// a cap shorter than the suffix keeps only the hash
+	tiny := &NamingStrategy{IdentifierMaxLength: 6}
+	assert.Equal(t, "739FB2", tiny.shortenIfNeeded(long))
+}
+
+type testKeywordColumns struct {
+	ID        uint `gorm:"primaryKey"`
+	Partition string
+	Pivot     int
+	Fetch     bool
+	Level     int
+}
+
+func (testKeywordColumns) TableName() string {
+	return "test_keyword_columns"
+}
+
+func TestStrictReservedWords(t *testing.T) {
+	assert.False(t, IsReservedWord("PARTITION"))
+	assert.True(t, IsStrictReservedWord("partition"))
+	assert.True(t, IsReservedWord("MLSLABEL"))
+	assert.True(t, IsStrictReservedWord("LEVEL"), "expecting reserved words to stay reserved in strict mode")
+	assert.False(t, IsStrictReservedWord("NAME"))
+
+	lenient, strict := &NamingStrategy{IdentifierMaxLength: 30}, &NamingStrategy{IdentifierMaxLength: 30, StrictReservedWords: true}
+	assert.Equal(t, "PARTITION", lenient.normalizeQualified("partition"))
+	assert.Equal(t, `"PARTITION"`, strict.normalizeQualified("partition"))
+	assert.Equal(t, `"LEVEL"`, lenient.normalizeQualified("level"))
+	assert.Equal(t, "NAME", strict.normalizeQualified("name"))
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		t.Fatal(err)
+	}
+	strictDB, err := gorm.Open(New(Config{Conn: sqlDB, StrictReservedWords: true}), &gorm.Config{})
+	require.NoError(t, err)
+	strictDB = strictDB.WithContext(currentContext())
+
+	_ = strictDB.Migrator().DropTable(&testKeywordColumns{})
+	require.NoError(t, strictDB.AutoMigrate(&testKeywordColumns{}), "expecting no error")
+	require.NoError(t, strictDB.AutoMigrate(&testKeywordColumns{}), "expecting re-migration to find the quoted columns")
+
+	toSQL := strictDB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(&testKeywordColumns{Partition: "p1"}).Find(&[]testKeywordColumns{})
+	})
+	assert.Contains(t, toSQL, `"PARTITION"`, "expecting the keyword column quoted: %s", toSQL)
+
+	row := testKeywordColumns{ID: 1, Partition: "p1", Pivot: 2, Fetch: true, Level: 3}
+	require.NoError(t, strictDB.Create(&row).Error, "expecting no error")
+	var found testKeywordColumns
+	require.NoError(t, strictDB.Where(&testKeywordColumns{Partition: "p1"}).First(&found).Error, "expecting no error")
+	assert.Equal(t, row, found)
+}
+
+type testRegisteredWordColumns struct {
+	ID  uint   `gorm:"primaryKey"`
+	Sku string `gorm:"size:64"`
+}
+
+func (testRegisteredWordColumns) TableName() string {
+	return "test_registered_word_columns"
+}
+
+func TestRegisterReservedWords(t *testing.T) {
+	ns := &NamingStrategy{IdentifierMaxLength: 30}
+	require.False(t, IsReservedWord("sku"))
+	require.Equal(t, "SKU", ns.normalizeQualified("sku"))
+
+	RegisterReservedWords("Sku", "two words", " ")
+	defer UnregisterReservedWords("sku")
+	assert.True(t, IsReservedWord("SKU"))
+	assert.False(t, IsReservedWord("two words"), "expecting phrases to be ignored")
+	assert.Equal(t, `"SKU"`, ns.normalizeQualified("sku"))
+
+	// Oracle's own reserved words stay reserved
+	UnregisterReservedWords("LEVEL")
+	assert.True(t, IsReservedWord("level"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			word := fmt.Sprintf("SITE_WORD_%d", i)
+			RegisterReservedWords(word)
+			assert.True(t, IsReservedWord(word))
+			UnregisterReservedWords(word)
+			assert.False(t, IsReservedWord(word))
+		}(i)
+	}
+	wg.Wait()
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	rec := newSQLRecorder()
+	_ = db.Session(&gorm.Session{DryRun: true, Logger: rec}).Migrator().CreateTable(&testRegisteredWordColumns{})
+	ddl := rec.matching("CREATE TABLE")
+	require.NotEmpty(t, ddl)
+	assert.Contains(t, ddl[0], `"SKU" VARCHAR2`, "expecting the registered word quoted: %s", ddl[0])
+
+	UnregisterReservedWords("sku")
+	rec = newSQLRecorder()
+	_ = db.Session(&gorm.Session{DryRun: true, Logger: rec}).Migrator().CreateTable(&testRegisteredWordColumns{})
+	ddl = rec.matching("CREATE TABLE")
+	require.NotEmpty(t, ddl)
+	assert.NotContains(t, ddl[0], `"SKU"`, "expecting the column unquoted once unregistered: %s", ddl[0])
+}
+
+type traceCapture struct {
+	logger.Interface
+	errs []error
+}
+
+func (c *traceCapture) LogMode(logger.LogLevel) logger.Interface { return c }
+
+func (c *traceCapture) Trace(_ context.Context, _ time.Time, _ func() (string, int64), err error) {
+	if err != nil {
+		c.errs = append(c.errs, err)
+	}
+}
+
+func TestErrorCodeLogger(t *testing.T) {
+	capture := &traceCapture{Interface: logger.Discard}
+	var codes []int
+	l := NewErrorCodeLogger(capture)
+	l.OnError = func(_ context.Context, code int, sql string, _ error) {
+		assert.Equal(t, "INSERT", sql)
+		codes = append(codes, code)
+	}
+	trace := func(err error) {
+		l.LogMode(logger.Info).Trace(context.Background(), time.Now(), func() (string, int64) { return "INSERT", 0 }, err)
+	}
+	trace(fmt.Errorf("insert: %w", network.NewOracleError(1)))
+	trace(errors.New("not an oracle error"))
+	trace(nil)
+
+	require.Len(t, capture.errs, 2)
+	var oraErr *ORAError
+	require.ErrorAs(t, capture.errs[0], &oraErr)
+	assert.Equal(t, 1, oraErr.Code)
+	assert.True(t, strings.HasPrefix(oraErr.Error(), "[ora_code=ORA-00001] "), oraErr.Error())
+	assert.Equal(t, "ORA-00001", oraErr.LogValue().Group()[0].Value.String())
+	var netErr *network.OracleError
+	assert.ErrorAs(t, capture.errs[0], &netErr, "expecting the driver error to stay reachable")
+	assert.EqualError(t, capture.errs[1], "not an oracle error")
+	assert.Equal(t, []int{1}, codes)
+
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Log("db is nil!")
+		return
+	}
+
+	model := TestTableUserUnique{}
+	_ = db.Migrator().DropTable(model)
+	require.NoError(t, db.Migrator().AutoMigrate(model))
+	require.NoError(t, db.Create(&TestTableUserUnique{UID: "U1", Name: "Alpha"}).Error)
+
+	capture.errs = nil
+	logged := db.Session(&gorm.Session{Logger: NewErrorCodeLogger(capture)})
+	require.Error(t, logged.Create(&TestTableUserUnique{UID: "U1", Name: "Beta"}).Error)
+	require.NotEmpty(t, capture.errs)
+	require.ErrorAs(t, capture.errs[0], &oraErr)
+	assert.Equal(t, 1, oraErr.Code, "expecting ORA-00001 for the unique constraint violation")
+}
+
 // ==== Reflection utilities ====
 
 func Test_reflectDereference(t *testing.T) {
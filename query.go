@@ -10,12 +10,16 @@ import (
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 	"gorm.io/gorm/utils"
 )
 
 func Query(db *gorm.DB) {
 	if db.Error == nil {
+		if db.Statement.SQL.Len() == 0 {
+			lockLimitedRows(db)
+		}
 		callbacks.BuildQuerySQL(db)
 
 		if !db.DryRun && db.Error == nil {
@@ -36,6 +40,266 @@ func Query(db *gorm.DB) {
 	}
 }
 
+// lockLimitedRows rewrites a limited query with a locking clause, which Oracle rejects (ORA-02014), into
+// a lock of the rows selected by the limited query:
+//
+//	db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).Where("state = ?", "new").Order("id").Limit(10).Find(&jobs)
+//	// SELECT * FROM "JOBS" WHERE "JOBS".ROWID IN (SELECT ROW_ID FROM (SELECT "JOBS".ROWID AS ROW_ID FROM "JOBS"
+//	//   WHERE state = 'new' ORDER BY id FETCH NEXT 10 ROWS ONLY)) ORDER BY id FOR UPDATE SKIP LOCKED
+//
+// The rows are picked before they are locked, so with SKIP LOCKED fewer rows than the limit may come back.
+func lockLimitedRows(db *gorm.DB) {
+	stmt := db.Statement
+	if _, ok := stmt.Clauses["FOR"].Expression.(clause.Locking); !ok {
+		return
+	}
+	limit, ok := stmt.Clauses["LIMIT"].Expression.(clause.Limit)
+	if !ok {
+		return
+	}
+	if (limit.Limit == nil || *limit.Limit < 0) && limit.Offset <= 0 {
+		return
+	}
+
+	query := db.Session(&gorm.Session{NewDB: true})
+	if isCaseSensitive(stmt) {
+		query = CaseSensitive(query)
+	}
+	if stmt.Model != nil {
+		query = query.Model(stmt.Model)
+	}
+	query = query.Table(stmt.Table)
+	query.Statement.TableExpr = stmt.TableExpr
+	// relation joins select the columns of the joined table unless omitted
+	query.Statement.Joins = append(stmt.Joins[:0:0], stmt.Joins...)
+	for i := range query.Statement.Joins {
+		query.Statement.Joins[i].Selects = nil
+		query.Statement.Joins[i].Omits = []string{"*"}
+	}
+	for _, name := range []string{"FROM", "WHERE", "ORDER BY", "LIMIT"} {
+		if c, ok := stmt.Clauses[name]; ok {
+			query.Statement.Clauses[name] = c
+		}
+	}
+
+	var rowID strings.Builder
+	stmt.QuoteTo(&rowID, stmt.Table)
+	query = query.Select(rowID.String() + ".ROWID AS ROW_ID")
+
+	delete(stmt.Clauses, "LIMIT")
+	stmt.Clauses["WHERE"] = clause.Clause{Name: "WHERE", Expression: clause.Where{Exprs: []clause.Expression{
+		clause.Expr{SQL: rowID.String() + ".ROWID IN (SELECT ROW_ID FROM (?))", Vars: []any{query}},
+	}}}
+}
+
+// RewriteSelect builds the SELECT clause, see rewriteReadColumns, and writes the optimizer hints set on
+// the statement right after SELECT, see optimizerHint. Hints land in the query itself, inside the
+// wrapping of the LIMIT rewrites. Values bound by a computed select, db.Select("?", t), are bound by
+// their Go type, see castSelectVar.
+func (d Dialector) RewriteSelect(c clause.Clause, builder clause.Builder) {
+	// clause.Select merges a computed select into the clause as its bare expression
+	if expr, ok := c.Expression.(clause.Expr); ok && len(expr.Vars) > 0 {
+		vars := make([]any, len(expr.Vars))
+		for i, v := range expr.Vars {
+			vars[i] = castSelectVar(v)
+		}
+		expr.Vars = vars
+		c.Expression = expr
+	}
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		d.rewriteReadColumns(c, builder)
+		return
+	}
+	hint := optimizerHint(stmt)
+	if hint == "" {
+		d.rewriteReadColumns(c, builder)
+		return
+	}
+
+	start := stmt.SQL.Len()
+	d.rewriteReadColumns(c, builder)
+	built := stmt.SQL.String()
+	if !strings.HasPrefix(built[start:], "SELECT ") {
+		return
+	}
+	at := start + len("SELECT ")
+	stmt.SQL.Reset()
+	stmt.SQL.WriteString(built[:at])
+	stmt.SQL.WriteString("/*+ ")
+	stmt.SQL.WriteString(hint)
+	stmt.SQL.WriteString(" */ ")
+	stmt.SQL.WriteString(built[at:])
+}
+
+// rewriteReadColumns selects the columns of the statement's model that are not read as stored
+// through their read expression, see readExpression, under their own name.
+func (d Dialector) rewriteReadColumns(c clause.Clause, builder clause.Builder) {
+	sel, ok := c.Expression.(clause.Select)
+	if !ok || sel.Expression != nil {
+		c.Build(builder)
+		return
+	}
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok || stmt.Schema == nil {
+		c.Build(builder)
+		return
+	}
+
+	readColumns := make(map[string]string)
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || !field.Readable {
+			continue
+		}
+		if expr := readExpression(field); expr != "" {
+			readColumns[field.DBName] = expr
+		} else if isVectorField(field) && d.nativeVectors() {
+			// go-ora cannot decode VECTOR values, so embeddings are read as their literal
+			readColumns[field.DBName] = "FROM_VECTOR(? RETURNING CLOB)"
+		}
+	}
+	if len(readColumns) == 0 {
+		c.Build(builder)
+		return
+	}
+
+	columns := sel.Columns
+	if len(columns) == 0 {
+		if hasJoins(stmt) {
+			c.Build(builder)
+			return
+		}
+		columns = make([]clause.Column, 0, len(stmt.Schema.DBNames))
+		for _, name := range stmt.Schema.DBNames {
+			columns = append(columns, clause.Column{Table: clause.CurrentTable, Name: name})
+		}
+	}
+
+	_, _ = builder.WriteString("SELECT ")
+	if sel.Distinct {
+		_, _ = builder.WriteString("DISTINCT ")
+	}
+	for idx, column := range columns {
+		if idx > 0 {
+			_ = builder.WriteByte(',')
+		}
+		expr, ok := readColumns[column.Name]
+		if !ok || column.Raw || !isCurrentTable(stmt, column.Table) {
+			builder.WriteQuoted(column)
+			continue
+		}
+		alias := column.Alias
+		if alias == "" {
+			alias = column.Name
+		}
+		column.Alias = ""
+		for i, part := range strings.Split(expr, "?") {
+			if i > 0 {
+				builder.WriteQuoted(column)
+			}
+			_, _ = builder.WriteString(part)
+		}
+		_ = builder.WriteByte(' ')
+		builder.WriteQuoted(alias)
+	}
+}
+
+// readExpression returns the SQL selecting field, ? standing for its column, or "" to select the
+// column as stored:
+//   - go-ora cannot decode XMLTYPE objects, so XMLTYPE columns are read as text through XMLSERIALIZE
+//   - go-ora reads intervals as text to the microsecond, so time.Duration fields are read as a
+//     number of nanoseconds
+//   - a column tagged `readDefault:0` reads NULL as the given SQL value through NVL, so it scans into
+//     a non-pointer field
+func readExpression(field *schema.Field) string {
+	if isXMLField(field) {
+		return "XMLSERIALIZE(CONTENT ? AS CLOB)"
+	}
+	if isIntervalField(field) {
+		return "CAST((EXTRACT(DAY FROM ?)*86400+EXTRACT(HOUR FROM ?)*3600+EXTRACT(MINUTE FROM ?)*60+EXTRACT(SECOND FROM ?))*1000000000 AS NUMBER(19))"
+	}
+	if def := strings.TrimSpace(field.TagSettings["READDEFAULT"]); def != "" {
+		return "NVL(?," + def + ")"
+	}
+	return ""
+}
+
+// optimizerHint returns the hints set on the statement under "gorm:oracle_hint", a string or a
+// []string, followed by those of TableHint clauses and the Parallel clause, joined with spaces; the
+// /*+ */ delimiters are optional.
+//
+//	db.Set("gorm:oracle_hint", []string{"INDEX(users idx_users_name)", "FIRST_ROWS(10)"}).Find(&users)
+//	// SELECT /*+ INDEX(users idx_users_name) FIRST_ROWS(10) */ * FROM "USERS" ...
+func optimizerHint(stmt *gorm.Statement) string {
+	var hints []string
+	if value, ok := stmt.Settings.Load("gorm:oracle_hint"); ok {
+		switch v := value.(type) {
+		case string:
+			hints = []string{v}
+		case []string:
+			hints = v
+		}
+	}
+	if th, ok := stmt.Clauses["TABLE HINT"].Expression.(tableHints); ok {
+		hints = append(append(hints[:0:0], hints...), th...)
+	}
+	if p, ok := stmt.Clauses["PARALLEL"].Expression.(parallel); ok {
+		hints = append(append(hints[:0:0], hints...), p.hint())
+	}
+
+	parts := make([]string, 0, len(hints))
+	for _, h := range hints {
+		h = strings.TrimSpace(h)
+		h = strings.TrimPrefix(h, "/*+")
+		h = strings.TrimSuffix(h, "*/")
+		if h = strings.TrimSpace(h); h != "" {
+			parts = append(parts, h)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// TableHint adds an optimizer hint on table to the query. Oracle reads hints right after SELECT,
+// naming the table, or its alias, as first argument; table is put there unless the hint already
+// starts with it:
+//
+//	db.Table("users u").Clauses(oracle.TableHint("u", "INDEX(idx_users_name)"), oracle.TableHint("u", "FULL")).Find(&users)
+//	// SELECT /*+ INDEX(u idx_users_name) FULL(u) */ * FROM users u
+//
+//goland:noinspection GoUnusedExportedFunction
+func TableHint(table, hint string) clause.Expression {
+	hint = strings.TrimSpace(hint)
+	name, args, ok := strings.Cut(hint, "(")
+	if !ok {
+		return tableHints{hint + "(" + table + ")"}
+	}
+	args = strings.TrimSpace(args)
+	if first := strings.FieldsFunc(args, func(r rune) bool { return r == ' ' || r == ')' || r == ',' }); len(first) > 0 && strings.EqualFold(first[0], table) {
+		return tableHints{hint}
+	}
+	return tableHints{strings.TrimSpace(name) + "(" + table + " " + args}
+}
+
+// tableHints are the hints of the TableHint clauses of a statement, see optimizerHint
+type tableHints []string
+
+func (tableHints) Name() string {
+	return "TABLE HINT"
+}
+
+func (h tableHints) Build(builder clause.Builder) {
+	_, _ = builder.WriteString("/*+ ")
+	_, _ = builder.WriteString(strings.Join(h, " "))
+	_, _ = builder.WriteString(" */")
+}
+
+func (h tableHints) MergeClause(c *clause.Clause) {
+	if prev, ok := c.Expression.(tableHints); ok {
+		h = append(append(prev[:0:0], prev...), h...)
+	}
+	c.Expression = h
+}
+
 func Scan(rows gorm.Rows, db *gorm.DB, mode gorm.ScanMode) {
 	var (
 		columns, _          = rows.Columns()
@@ -325,7 +589,12 @@ func scanIntoMap(mapValue map[string]interface{}, values []interface{}, columns
 
 func scanIntoStruct(db *gorm.DB, rows gorm.Rows, reflectValue reflect.Value, values []interface{}, fields []*schema.Field, joinFields [][]*schema.Field) {
 	for idx, field := range fields {
-		if field != nil {
+		if isIntervalField(field) || isVectorField(field) {
+			// a number of nanoseconds or the interval as text, see scannedInterval, or a vector literal
+			values[idx] = new(interface{})
+		} else if isEncryptedField(field) {
+			values[idx] = new(WrappedBytes)
+		} else if field != nil {
 			values[idx] = field.NewValuePool.Get()
 		} else if len(fields) == 1 {
 			if reflectValue.CanAddr() {
@@ -344,6 +613,29 @@ func scanIntoStruct(db *gorm.DB, rows gorm.Rows, reflectValue reflect.Value, val
 			continue
 		}
 
+		if isIntervalField(field) {
+			value, err := scannedInterval(field, values[idx])
+			if err != nil {
+				_ = db.AddError(err)
+				continue
+			}
+			values[idx] = value
+		} else if isVectorField(field) {
+			value, err := scannedVector(field, values[idx])
+			if err != nil {
+				_ = db.AddError(err)
+				continue
+			}
+			values[idx] = value
+		} else if isEncryptedField(field) {
+			value, err := decryptValue(db.Statement, field, *values[idx].(*WrappedBytes))
+			if err != nil {
+				_ = db.AddError(err)
+				continue
+			}
+			values[idx] = value
+		}
+
 		if len(joinFields) == 0 || len(joinFields[idx]) == 0 {
 			_ = db.AddError(field.Set(db.Statement.Context, reflectValue, convertToLiteral(db.Statement, values[idx], reflectValue, field)))
 		} else { // joinFields count is larger than 2 when using join
@@ -380,7 +672,9 @@ func scanIntoStruct(db *gorm.DB, rows gorm.Rows, reflectValue reflect.Value, val
 		}
 
 		// release data to pool
-		field.NewValuePool.Put(values[idx])
+		if !isIntervalField(field) && !isVectorField(field) && !isEncryptedField(field) {
+			field.NewValuePool.Put(values[idx])
+		}
 	}
 }
 
@@ -1,17 +1,149 @@
 package oracle
 
 import (
+	"regexp"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/emirpasic/gods/v2/sets/hashset"
 )
 
+// ReservedWords holds the words IsReservedWord reports, Oracle's reserved words and those added
+// through RegisterReservedWords; change it through those functions only, they guard it for
+// concurrent use.
 var ReservedWords = hashset.New[string](ReservedWordsList...)
 
+var (
+	reservedWordsMu sync.RWMutex
+	// registeredWords are the words added to ReservedWords by RegisterReservedWords
+	registeredWords = hashset.New[string]()
+)
+
+// RegisterReservedWords adds words to the reserved words, ignoring case, so that identifiers named
+// after them are quoted like Oracle's own, e.g. keywords of a newer release or site-specific words:
+//
+//	oracle.RegisterReservedWords("SKU")
+//	// CREATE TABLE ITEMS (..."SKU" VARCHAR2(64)...)
+//
+// The change affects every identifier quoted afterward, in DDL and DML alike, so register words
+// before migrating: a column created quoted must be referenced quoted. Phrases are ignored.
+//
+//goland:noinspection GoUnusedExportedFunction
+func RegisterReservedWords(words ...string) {
+	reservedWordsMu.Lock()
+	defer reservedWordsMu.Unlock()
+	for _, w := range words {
+		if w = reservedWordKey(w); w != "" && !ReservedWords.Contains(w) {
+			ReservedWords.Add(w)
+			registeredWords.Add(w)
+		}
+	}
+}
+
+// UnregisterReservedWords removes words added by RegisterReservedWords, ignoring case. Oracle's own
+// reserved words stay reserved, they cannot be written unquoted.
+//
+//goland:noinspection GoUnusedExportedFunction
+func UnregisterReservedWords(words ...string) {
+	reservedWordsMu.Lock()
+	defer reservedWordsMu.Unlock()
+	for _, w := range words {
+		if w = reservedWordKey(w); registeredWords.Contains(w) {
+			registeredWords.Remove(w)
+			ReservedWords.Remove(w)
+		}
+	}
+}
+
+// reservedWordKey is the upper case form of the identifier v, or "" for a phrase
+func reservedWordKey(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" || strings.ContainsFunc(v, unicode.IsSpace) {
+		return ""
+	}
+	return strings.ToUpper(v)
+}
+
+// Keywords holds the SQL keywords Oracle lists in V$RESERVED_WORDS without reserving them: they
+// are valid unquoted identifiers, yet a column named after one can be misread in clauses where
+// the keyword is expected (e.g. PARTITION BY, PIVOT, FETCH FIRST). They are only quoted in strict
+// mode, see Config.StrictReservedWords.
+var Keywords = hashset.New[string](KeywordsList...)
+
+// IsReservedWord reports whether the identifier v is an Oracle reserved word and must be quoted,
+// ignoring case. An identifier is a single token: a phrase never is a reserved word (it cannot be
+// written unquoted anyway), see IsTypePhrase for multi-word type names such as LONG RAW.
 func IsReservedWord(v string) bool {
-	parts := strings.Split(v, " ")
+	return isReservedWord(v, false)
+}
 
-	return ReservedWords.Contains(parts...)
+// IsStrictReservedWord reports whether the identifier v is quoted in strict mode, see
+// Config.StrictReservedWords: it is a reserved word, see IsReservedWord, or one of the Keywords.
+func IsStrictReservedWord(v string) bool {
+	return isReservedWord(v, true)
+}
+
+func isReservedWord(v string, strict bool) bool {
+	if v = reservedWordKey(v); v == "" {
+		return false
+	}
+	reservedWordsMu.RLock()
+	defer reservedWordsMu.RUnlock()
+	return ReservedWords.Contains(v) || (strict && Keywords.Contains(v))
+}
+
+// typePhrases are the Oracle data types named by several words, some of them reserved on their own
+var typePhrases = hashset.New[string](
+	"TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE",
+	"INTERVAL YEAR TO MONTH", "INTERVAL DAY TO SECOND",
+	"LONG RAW", "LONG VARCHAR", "DOUBLE PRECISION",
+	"CHARACTER VARYING", "CHAR VARYING",
+	"NATIONAL CHARACTER", "NATIONAL CHAR", "NATIONAL CHARACTER VARYING", "NATIONAL CHAR VARYING", "NCHAR VARYING",
+)
+
+var typeArgs = regexp.MustCompile(`\([^)]*\)`)
+
+// IsTypePhrase reports whether v names a multi-word Oracle data type, e.g.
+// TIMESTAMP(6) WITH LOCAL TIME ZONE or INTERVAL DAY(2) TO SECOND(6); size and precision
+// arguments and case are ignored.
+func IsTypePhrase(v string) bool {
+	words := strings.Fields(strings.ToUpper(typeArgs.ReplaceAllString(v, " ")))
+	return len(words) > 1 && typePhrases.Contains(strings.Join(words, " "))
+}
+
+// typePhraseLen returns the length of the multi-word data type s starts with, see IsTypePhrase,
+// arguments included, or 0 when it starts with none
+func typePhraseLen(s string) int {
+	var ends []int
+	for i := 0; len(ends) < 5; {
+		j := i
+		for j < len(s) && (s[j] == ' ' || s[j] == '\t' || s[j] == '\r' || s[j] == '\n') {
+			j++
+		}
+		k := j
+		for k < len(s) && (s[k] == '_' || 'a' <= s[k] && s[k] <= 'z' || 'A' <= s[k] && s[k] <= 'Z') {
+			k++
+		}
+		if k == j {
+			break
+		}
+		if rest := strings.TrimLeft(s[k:], " \t"); strings.HasPrefix(rest, "(") {
+			end := strings.IndexByte(rest, ')')
+			if end < 0 {
+				break
+			}
+			k = len(s) - len(rest) + end + 1
+		}
+		ends = append(ends, k)
+		i = k
+	}
+	for w := len(ends) - 1; w > 0; w-- {
+		if IsTypePhrase(s[:ends[w]]) {
+			return ends[w]
+		}
+	}
+	return 0
 }
 
 var ReservedWordsList = []string{
@@ -43,4 +175,17 @@ var ReservedWordsList = []string{
 	"DESC", "MAXEXTENTS", "ROWLABEL", "WHENEVER",
 	"DISTINCT", "MINUS", "ROWNUM", "WHERE",
 	"DROP", "MODE", "ROWS", "WITH",
+	"MLSLABEL",
+}
+
+// KeywordsList are the keywords of modern Oracle SQL (analytic, MERGE, row limiting, PIVOT and
+// JSON syntax) that V$RESERVED_WORDS reports as non-reserved
+var KeywordsList = []string{
+	"CASE", "WHEN", "END", "JOIN", "INNER", "OUTER", "LEFT", "RIGHT", "FULL", "CROSS", "NATURAL", "USING",
+	"PARTITION", "OVER", "WINDOW", "RANGE", "PRECEDING", "FOLLOWING", "UNBOUNDED",
+	"PIVOT", "UNPIVOT", "MODEL", "SAMPLE", "LATERAL", "APPLY",
+	"MERGE", "MATCHED", "RETURNING", "RETURN",
+	"OFFSET", "FETCH", "FIRST", "NEXT", "ONLY", "TIES", "PERCENT",
+	"INTERVAL", "TIMESTAMP", "ZONE", "KEY", "PRIMARY", "FOREIGN", "REFERENCES", "CONSTRAINT",
+	"JSON", "JSON_TABLE", "XMLTABLE", "NESTED", "PATH", "COLUMNS", "EXCEPT",
 }
@@ -71,62 +71,14 @@ func (returning Returning) Name() string {
 
 func (returning Returning) Build(builder clause.Builder) {
 	stmt, ok := builder.(*gorm.Statement)
-	if !ok || stmt.Schema == nil {
+	if !ok {
 		return
 	}
-
-	// Collect fields
-	if len(returning.fields) == 0 {
-		if len(returning.Names) > 0 {
-			for _, n := range returning.Names {
-				if f := stmt.Schema.LookUpField(n); f != nil && isReturnableField(f) {
-					returning.fields = append(returning.fields, f)
-				}
-			}
-		} else {
-			for _, f := range stmt.Schema.Fields {
-				if isReturnableField(f) {
-					returning.Names = append(returning.Names, f.DBName)
-					returning.fields = append(returning.fields, f)
-				}
-			}
-		}
-	} else if len(returning.Names) == 0 {
-		for _, f := range returning.fields {
-			if isReturnableField(f) {
-				returning.Names = append(returning.Names, f.DBName)
-			}
-		}
-	}
-
-	if len(returning.fields) == 0 {
-		return
-	}
-
-	rv := stmt.ReflectValue
-
-	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
-		if rv.IsNil() {
-			return
-		}
-		rv = rv.Elem()
-	}
-	isSlice := rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
-
-	filteredFields := make([]*schema.Field, 0, len(returning.fields))
-	for _, f := range returning.fields {
-		if !isReturnableField(f) {
-			continue
-		}
-		if !canBindReturningField(stmt, rv, f) {
-			continue
-		}
-		filteredFields = append(filteredFields, f)
-	}
-
+	filteredFields, rv := returning.bindableFields(stmt)
 	if len(filteredFields) == 0 {
 		return
 	}
+	isSlice := rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
 
 	// Build RETURNING clause
 	for i, f := range filteredFields {
@@ -146,19 +98,8 @@ func (returning Returning) Build(builder clause.Builder) {
 			val    reflect.Value
 			valVal any
 			ok     bool
-			size   = max(1, f.Size)
+			size   = returningSize(f)
 		)
-		if f.Size == 0 {
-			dt := f.DataType
-			if match, err := stringTypeWithSize.FindStringMatch(strings.ToLower(string(dt))); err == nil && match != nil {
-				if match.GroupByNumber(1) != nil {
-					size, err = strconv.Atoi(match.GroupByNumber(1).String())
-					if err != nil {
-						size = 128
-					}
-				}
-			}
-		}
 		if isSlice {
 			rows := rv.Len()
 
@@ -201,6 +142,90 @@ func (returning Returning) Build(builder clause.Builder) {
 	}
 }
 
+// returningSize is the size of the out bind of f: its size tag, else the size of its sized type,
+// else the largest VARCHAR2 or RAW for strings and bytes with neither, e.g. a column defaulting to
+// SYS_GUID()
+func returningSize(f *schema.Field) int {
+	if f.Size > 0 {
+		return f.Size
+	}
+	if match, err := stringTypeWithSize.FindStringMatch(strings.ToLower(string(f.DataType))); err == nil && match != nil {
+		if match.GroupByNumber(1) != nil {
+			size, err := strconv.Atoi(match.GroupByNumber(1).String())
+			if err != nil {
+				return 128
+			}
+			return size
+		}
+	}
+	switch t := f.IndirectFieldType; {
+	case t.Kind() == reflect.String:
+		return 4000
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return 2000
+	}
+	return 1
+}
+
+// bindableFields collects the RETURNING fields that can be bound into the statement's
+// destination, along with the dereferenced destination. It returns no fields when nothing
+// can be returned, in which case the RETURNING clause must be omitted entirely.
+func (returning Returning) bindableFields(stmt *gorm.Statement) ([]*schema.Field, reflect.Value) {
+	if stmt.Schema == nil {
+		return nil, reflect.Value{}
+	}
+
+	// Collect fields
+	if len(returning.fields) == 0 {
+		if len(returning.Names) > 0 {
+			for _, n := range returning.Names {
+				if f := stmt.Schema.LookUpField(n); f != nil && isReturnableField(f) {
+					returning.fields = append(returning.fields, f)
+				}
+			}
+		} else {
+			for _, f := range stmt.Schema.Fields {
+				if isReturnableField(f) {
+					returning.Names = append(returning.Names, f.DBName)
+					returning.fields = append(returning.fields, f)
+				}
+			}
+		}
+	} else if len(returning.Names) == 0 {
+		for _, f := range returning.fields {
+			if isReturnableField(f) {
+				returning.Names = append(returning.Names, f.DBName)
+			}
+		}
+	}
+
+	if len(returning.fields) == 0 {
+		return nil, reflect.Value{}
+	}
+
+	rv := stmt.ReflectValue
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+
+	filteredFields := make([]*schema.Field, 0, len(returning.fields))
+	for _, f := range returning.fields {
+		if !isReturnableField(f) {
+			continue
+		}
+		if !canBindReturningField(stmt, rv, f) {
+			continue
+		}
+		filteredFields = append(filteredFields, f)
+	}
+
+	return filteredFields, rv
+}
+
 func ensureInitialized(v reflect.Value) reflect.Value {
 	if !v.IsValid() {
 		return v
@@ -0,0 +1,84 @@
+package oracle
+
+import (
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Sample reads a random sample of about percent of the rows of the queried table, for Oracle
+// 0.000001 <= percent < 100:
+//
+//	db.Clauses(oracle.Sample(10)).Where("active = ?", true).Limit(100).Find(&users)
+//	// SELECT * FROM "USERS" SAMPLE(10) WHERE active = 1 FETCH NEXT 100 ROWS ONLY
+//
+// The sample is taken from the table before WHERE filters its rows. In a joined query only the
+// table of the model is sampled.
+//
+//goland:noinspection GoUnusedExportedFunction
+func Sample(percent float64) clause.Expression {
+	return sample{Percent: percent}
+}
+
+type sample struct {
+	Percent float64
+}
+
+func (sample) Name() string {
+	return "SAMPLE"
+}
+
+func (s sample) Build(builder clause.Builder) {
+	_, _ = builder.WriteString("SAMPLE(")
+	_, _ = builder.WriteString(strconv.FormatFloat(s.Percent, 'f', -1, 64))
+	_ = builder.WriteByte(')')
+}
+
+func (s sample) MergeClause(c *clause.Clause) {
+	c.Expression = s
+}
+
+// RewriteFrom builds the FROM clause, writing the SAMPLE clause of the statement, see Sample,
+// right after the table of the model, ahead of any join.
+func (d Dialector) RewriteFrom(c clause.Clause, builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+	s, ok := stmt.Clauses["SAMPLE"].Expression.(sample)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+	if s.Percent < 0.000001 || s.Percent >= 100 {
+		_ = stmt.AddError(fmt.Errorf("oracle: sample percent %v out of range [0.000001, 100)", s.Percent))
+		c.Build(builder)
+		return
+	}
+
+	from, _ := c.Expression.(clause.From)
+	_, _ = builder.WriteString("FROM ")
+	if len(from.Tables) > 0 {
+		for idx, table := range from.Tables {
+			if idx > 0 {
+				_ = builder.WriteByte(',')
+			}
+			builder.WriteQuoted(table)
+			if idx == 0 {
+				_ = builder.WriteByte(' ')
+				s.Build(builder)
+			}
+		}
+	} else {
+		builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+		_ = builder.WriteByte(' ')
+		s.Build(builder)
+	}
+	for _, join := range from.Joins {
+		_ = builder.WriteByte(' ')
+		join.Build(builder)
+	}
+}
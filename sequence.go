@@ -0,0 +1,293 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// AutoIncrementStrategy selects how auto-increment columns are fed, see Config.AutoIncrementStrategy
+type AutoIncrementStrategy int
+
+const (
+	// AutoIncrementAuto declares identity columns, unless the database is Oracle 11g, which has none
+	// and feeds the columns from a sequence and trigger
+	AutoIncrementAuto AutoIncrementStrategy = iota
+	// AutoIncrementIdentity declares identity columns, GENERATED BY DEFAULT AS IDENTITY
+	AutoIncrementIdentity
+	// AutoIncrementSequenceTrigger feeds the columns from a sequence SEQ_<TABLE>_<COLUMN> assigned by
+	// a BEFORE INSERT trigger, as on Oracle 11g
+	AutoIncrementSequenceTrigger
+)
+
+// sequencesForAutoIncrement reports whether auto-increment columns are fed from a sequence and
+// trigger rather than declared as identity columns, by Config.AutoIncrementStrategy or
+// Config.UseSequencesForAutoIncrement
+func (c *Config) sequencesForAutoIncrement() bool {
+	if c == nil {
+		return false
+	}
+	switch c.AutoIncrementStrategy {
+	case AutoIncrementIdentity:
+		return false
+	case AutoIncrementSequenceTrigger:
+		return true
+	}
+	if c.UseSequencesForAutoIncrement {
+		return true
+	}
+	dbVer, err := strconv.Atoi(strings.Split(c.DBVer, ".")[0])
+	return err == nil && dbVer < 12
+}
+
+// sequenceName returns the sequence feeding field: the name of its sequence tag, or SEQ_<TABLE>_<COLUMN>
+// for an auto-increment field when auto-increment columns are fed from sequences, see
+// Config.AutoIncrementStrategy; empty for identity and plain columns.
+//
+//	ID uint `gorm:"primaryKey;sequence:USERS_SEQ"`
+func sequenceName(ns *NamingStrategy, useSequences bool, field *schema.Field) string {
+	if !usesSequence(useSequences, field) {
+		return ""
+	}
+	if name := strings.TrimSpace(field.TagSettings["SEQUENCE"]); name != "" {
+		return name
+	}
+	return generatedTableObjectName(ns, "SEQ", field)
+}
+
+// usesSequence reports whether field is fed by a sequence rather than being an identity column
+func usesSequence(useSequences bool, field *schema.Field) bool {
+	if field == nil {
+		return false
+	}
+	return strings.TrimSpace(field.TagSettings["SEQUENCE"]) != "" || useSequences && field.AutoIncrement && field.Schema != nil
+}
+
+// sequenceTriggerName names the BEFORE INSERT trigger assigning the sequence of field: TRG_<TABLE>_<COLUMN>
+func sequenceTriggerName(ns *NamingStrategy, field *schema.Field) string {
+	return generatedTableObjectName(ns, "TRG", field)
+}
+
+// generatedTableObjectName names an object generated for a column, in the schema of its table
+func generatedTableObjectName(ns *NamingStrategy, kind string, field *schema.Field) string {
+	name := ns.genToken(kind, field.Schema.Table, field.DBName)
+	if owner, _, hasOwner := ns.dictQualifiedParts(field.Schema.Table); hasOwner {
+		return owner + "." + name
+	}
+	return name
+}
+
+// sequenceOf returns the sequence feeding field, see sequenceName
+func (m Migrator) sequenceOf(field *schema.Field) string {
+	return sequenceName(getNS(m.DB, m.Dialector), m.useSequences(), field)
+}
+
+func (m Migrator) useSequences() bool {
+	return m.config().sequencesForAutoIncrement()
+}
+
+// sequenceFields returns the fields of sch fed by a sequence
+func (m Migrator) sequenceFields(sch *schema.Schema) []*schema.Field {
+	if sch == nil {
+		return nil
+	}
+	var fields []*schema.Field
+	for _, f := range sch.Fields {
+		if f.DBName != "" && !f.IgnoreMigration && usesSequence(m.useSequences(), f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// hasSequence reports whether the sequence name, possibly owner-qualified, exists
+func (m Migrator) hasSequence(name string) bool {
+	ns := getNS(m.DB, m.Dialector)
+	owner, seq, hasOwner := ns.dictQualifiedParts(name)
+
+	var exists int
+	var err error
+	if hasOwner {
+		err = m.DB.Raw(
+			`SELECT 1 FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = :owner AND SEQUENCE_NAME = :seq AND ROWNUM = 1`,
+			sql.Named("owner", owner), sql.Named("seq", seq),
+		).Scan(&exists).Error
+	} else {
+		err = m.DB.Raw(
+			`SELECT 1 FROM USER_SEQUENCES WHERE SEQUENCE_NAME = :seq AND ROWNUM = 1`,
+			sql.Named("seq", seq),
+		).Scan(&exists).Error
+	}
+	return err == nil && exists == 1
+}
+
+// ensureSequence creates the sequence of field unless it exists, starting past the largest value
+// already in the column, and the trigger assigning it to rows inserted without a value unless one
+// assigning that sequence exists.
+func (m Migrator) ensureSequence(stmt *gorm.Statement, field *schema.Field) error {
+	ns := getNS(m.DB, m.Dialector)
+	seq := m.sequenceOf(field)
+
+	var create strings.Builder
+	if !m.hasSequence(seq) {
+		var start int64
+		var query strings.Builder
+		query.WriteString("SELECT NVL(MAX(")
+		m.DB.Dialector.QuoteTo(&query, field.DBName)
+		query.WriteString("), 0) + 1 FROM ")
+		m.DB.Dialector.QuoteTo(&query, stmt.Table)
+		if err := m.DB.Raw(query.String()).Scan(&start).Error; err != nil {
+			return err
+		}
+
+		create.WriteString("CREATE SEQUENCE ")
+		create.WriteString(ns.dictQualifiedName(seq))
+		create.WriteString(" START WITH ")
+		create.WriteString(strconv.FormatInt(max(start, 1), 10))
+		if err := m.DB.Exec(create.String()).Error; err != nil {
+			return err
+		}
+		create.Reset()
+	}
+
+	trigger := sequenceTriggerName(ns, field)
+	if m.hasSequenceTrigger(trigger, seq) {
+		return nil
+	}
+
+	// BEFORE INSERT ON <table> FOR EACH ROW WHEN (NEW.<col> IS NULL) BEGIN :NEW.<col> := <seq>.NEXTVAL; END;
+	var column strings.Builder
+	m.DB.Dialector.QuoteTo(&column, field.DBName)
+	create.WriteString("CREATE OR REPLACE TRIGGER ")
+	create.WriteString(ns.dictQualifiedName(trigger))
+	create.WriteString(" BEFORE INSERT ON ")
+	m.DB.Dialector.QuoteTo(&create, stmt.Table)
+	create.WriteString(" FOR EACH ROW WHEN (NEW.")
+	create.WriteString(column.String())
+	create.WriteString(" IS NULL) BEGIN :NEW.")
+	create.WriteString(column.String())
+	create.WriteString(" := ")
+	create.WriteString(ns.dictQualifiedName(seq))
+	create.WriteString(".NEXTVAL; END;")
+	return m.DB.Exec(create.String()).Error
+}
+
+// hasSequenceTrigger reports whether the trigger, possibly owner-qualified, exists and its body assigns
+// the sequence seq, see ensureSequence
+func (m Migrator) hasSequenceTrigger(trigger, seq string) bool {
+	ns := getNS(m.DB, m.Dialector)
+	owner, name, hasOwner := ns.dictQualifiedParts(trigger)
+
+	// TRIGGER_BODY is a LONG, read as a string
+	var body string
+	var err error
+	if hasOwner {
+		err = m.DB.Raw(
+			`SELECT TRIGGER_BODY FROM ALL_TRIGGERS WHERE OWNER = :owner AND TRIGGER_NAME = :trg`,
+			sql.Named("owner", owner), sql.Named("trg", name),
+		).Scan(&body).Error
+	} else {
+		err = m.DB.Raw(
+			`SELECT TRIGGER_BODY FROM USER_TRIGGERS WHERE TRIGGER_NAME = :trg`,
+			sql.Named("trg", name),
+		).Scan(&body).Error
+	}
+	return err == nil && strings.Contains(strings.ToUpper(body), strings.ToUpper(ns.dictQualifiedName(seq)+".NEXTVAL"))
+}
+
+// dropSequences drops the generated SEQ_<TABLE>_<COLUMN> sequences of the fields of stmt's schema;
+// their triggers went with the table. A sequence named in a sequence tag belongs to the user, who
+// may share it between tables, and is kept.
+func (m Migrator) dropSequences(stmt *gorm.Statement) error {
+	ns := getNS(m.DB, m.Dialector)
+	for _, f := range m.sequenceFields(stmt.Schema) {
+		if strings.TrimSpace(f.TagSettings["SEQUENCE"]) != "" {
+			continue
+		}
+		seq := m.sequenceOf(f)
+		if !m.hasSequence(seq) {
+			continue
+		}
+		var drop strings.Builder
+		drop.WriteString("DROP SEQUENCE ")
+		drop.WriteString(ns.dictQualifiedName(seq))
+		if err := m.DB.Exec(drop.String()).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CurrentSequenceValue returns the high-water mark of the sequence feeding the primary key of value,
+// its own sequence or the system sequence of an identity column: LAST_NUMBER, the next value not yet
+// handed out to a session cache. It only grows, by up to the sequence's CACHE size at a time.
+func (m Migrator) CurrentSequenceValue(value interface{}) (int64, error) {
+	var current int64
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return gorm.ErrModelValueRequired
+		}
+		field := stmt.Schema.PrioritizedPrimaryField
+		if field == nil {
+			return fmt.Errorf("oracle: CurrentSequenceValue: %q has no single primary key", stmt.Table)
+		}
+
+		ns := getNS(m.DB, m.Dialector)
+		var owner, seq string
+		var hasOwner bool
+		if usesSequence(m.useSequences(), field) {
+			owner, seq, hasOwner = ns.dictQualifiedParts(m.sequenceOf(field))
+		} else {
+			var tab string
+			owner, tab, hasOwner = ns.dictQualifiedParts(stmt.Table)
+			col := ns.dictCasePart(field.DBName)
+			var names []string
+			var err error
+			if hasOwner {
+				err = m.DB.Raw(`
+					SELECT SEQUENCE_NAME FROM ALL_TAB_IDENTITY_COLS
+					 WHERE OWNER = :owner AND TABLE_NAME = :tab AND COLUMN_NAME = :col`,
+					sql.Named("owner", owner), sql.Named("tab", tab), sql.Named("col", col)).Scan(&names).Error
+			} else {
+				err = m.DB.Raw(`
+					SELECT SEQUENCE_NAME FROM USER_TAB_IDENTITY_COLS
+					 WHERE TABLE_NAME = :tab AND COLUMN_NAME = :col`,
+					sql.Named("tab", tab), sql.Named("col", col)).Scan(&names).Error
+			}
+			if err != nil {
+				return err
+			}
+			if len(names) == 0 {
+				return fmt.Errorf("oracle: CurrentSequenceValue: %s.%s is neither an identity nor sequence-backed column", stmt.Table, field.DBName)
+			}
+			seq = names[0]
+		}
+
+		var values []int64
+		var err error
+		if hasOwner {
+			err = m.DB.Raw(
+				`SELECT LAST_NUMBER FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = :owner AND SEQUENCE_NAME = :seq`,
+				sql.Named("owner", owner), sql.Named("seq", seq),
+			).Scan(&values).Error
+		} else {
+			err = m.DB.Raw(
+				`SELECT LAST_NUMBER FROM USER_SEQUENCES WHERE SEQUENCE_NAME = :seq`,
+				sql.Named("seq", seq),
+			).Scan(&values).Error
+		}
+		if err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("oracle: CurrentSequenceValue: sequence %q not found", seq)
+		}
+		current = values[0]
+		return nil
+	})
+	return current, err
+}
@@ -0,0 +1,294 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	setModuleSQL = "BEGIN DBMS_APPLICATION_INFO.SET_MODULE(?, ?); END;"
+	setActionSQL = "BEGIN DBMS_APPLICATION_INFO.SET_ACTION(?); END;"
+
+	setIdentifierSQL   = "BEGIN DBMS_SESSION.SET_IDENTIFIER(?); END;"
+	clearIdentifierSQL = "BEGIN DBMS_SESSION.CLEAR_IDENTIFIER; END;"
+
+	setTimezoneSQL = "ALTER SESSION SET TIME_ZONE = '%s'"
+	getTimezoneSQL = "SELECT SESSIONTIMEZONE FROM DUAL"
+)
+
+// SetModule sets the module and action reported in V$SESSION (and SYS_CONTEXT('USERENV','MODULE'))
+// through DBMS_APPLICATION_INFO.SET_MODULE.
+//
+// Module and action belong to the database session, so db should be bound to a single connection,
+// e.g. inside db.Transaction or db.Connection; on a pooled *gorm.DB they land on whichever
+// connection happens to run the call.
+//
+//goland:noinspection GoUnusedExportedFunction
+func SetModule(db *gorm.DB, module, action string) error {
+	return db.Exec(setModuleSQL, module, action).Error
+}
+
+// SetClientIdentifier sets the client identifier (SYS_CONTEXT('USERENV','CLIENT_IDENTIFIER')) used by
+// VPD policies and auditing through DBMS_SESSION.SET_IDENTIFIER; an empty id clears it.
+//
+// Like SetModule it applies to the session running the call, see WithClientIdentifier to scope it
+// to a single connection and clear it before the connection returns to the pool.
+func SetClientIdentifier(db *gorm.DB, id string) error {
+	if id == "" {
+		return db.Exec(clearIdentifierSQL).Error
+	}
+	return db.Exec(setIdentifierSQL, id).Error
+}
+
+// WithClientIdentifier runs fc on a single connection identified by id, clearing the identifier
+// again before the connection is released to the pool.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithClientIdentifier(db *gorm.DB, id string, fc func(tx *gorm.DB) error) error {
+	return db.Connection(func(tx *gorm.DB) (err error) {
+		if err = SetClientIdentifier(tx, id); err != nil {
+			return
+		}
+		defer func() {
+			if clearErr := SetClientIdentifier(tx, ""); err == nil {
+				err = clearErr
+			}
+		}()
+		return fc(tx)
+	})
+}
+
+// registerActionCallbacks tags each statement with the name of the GORM callback executing it
+// (gorm:create, gorm:query, ...) as the session action, see Config.TagActionWithCallback.
+func registerActionCallbacks(db *gorm.DB) (err error) {
+	cb := db.Callback()
+	if err = cb.Create().Before("gorm:create").Register("oracle:set_action", setAction("gorm:create")); err != nil {
+		return
+	}
+	if err = cb.Query().Before("gorm:query").Register("oracle:set_action", setAction("gorm:query")); err != nil {
+		return
+	}
+	if err = cb.Update().Before("gorm:update").Register("oracle:set_action", setAction("gorm:update")); err != nil {
+		return
+	}
+	if err = cb.Delete().Before("gorm:delete").Register("oracle:set_action", setAction("gorm:delete")); err != nil {
+		return
+	}
+	if err = cb.Row().Before("gorm:row").Register("oracle:set_action", setAction("gorm:row")); err != nil {
+		return
+	}
+	return cb.Raw().Before("gorm:raw").Register("oracle:set_action", setAction("gorm:raw"))
+}
+
+func setAction(action string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.DryRun || !isPinnedConnPool(db.Statement.ConnPool) {
+			return
+		}
+		// bypass the callbacks, this one included, binding the variable as the dialector does
+		stmt := &gorm.Statement{DB: db}
+		clause.Expr{SQL: setActionSQL, Vars: []interface{}{action}}.Build(stmt)
+		if _, err := db.Statement.ConnPool.ExecContext(db.Statement.Context, stmt.SQL.String(), stmt.Vars...); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
+
+// isPinnedConnPool reports whether pool runs every statement on the same session (a transaction or
+// a *sql.Conn); a pooled *sql.DB may run the next statement on another connection.
+func isPinnedConnPool(pool gorm.ConnPool) bool {
+	if stmtDB, ok := pool.(*gorm.PreparedStmtDB); ok {
+		pool = stmtDB.ConnPool
+	}
+	_, pooled := pool.(*sql.DB)
+	return !pooled
+}
+
+type sessionTimezoneKey struct{}
+
+// WithSessionTimezone returns a context running the statements of a *gorm.DB in loc instead of the
+// SessionTimezone of the pool, e.g. for a tenant:
+//
+//	tx := db.WithContext(oracle.WithSessionTimezone(ctx, tenantLocation))
+//	tx.Create(&event) // ALTER SESSION SET TIME_ZONE = 'America/New_York', then the INSERT
+//
+// Create, Query, Update and Delete set TIME_ZONE on the session running the statement and restore
+// the zone the session had afterward; outside a transaction the statement is pinned to a single connection
+// for that. Row, Rows and Raw statements run in the pool's zone.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithSessionTimezone(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, sessionTimezoneKey{}, loc)
+}
+
+// sessionTimezone returns the time zone set on ctx by WithSessionTimezone, if any
+func sessionTimezone(ctx context.Context) *time.Location {
+	if ctx == nil {
+		return nil
+	}
+	loc, _ := ctx.Value(sessionTimezoneKey{}).(*time.Location)
+	return loc
+}
+
+// sessionLocation returns the time zone of the session running stmt: the one of its context, see
+// WithSessionTimezone, else the SessionTimezone of the dialector.
+func sessionLocation(stmt *gorm.Statement) *time.Location {
+	if loc := sessionTimezone(stmt.Context); loc != nil {
+		return loc
+	}
+	return poolLocation(stmt.DB)
+}
+
+// poolLocation returns the SessionTimezone the connections of db are opened in
+func poolLocation(db *gorm.DB) *time.Location {
+	if d, ok := db.Dialector.(*Dialector); ok && d.sessionLocation != nil {
+		return d.sessionLocation
+	}
+	return time.Local
+}
+
+// timezoneName returns the TIME_ZONE value of loc: its region name, or its current offset for
+// time.Local and zones that are not a known region, e.g. time.FixedZone("UTC+8", 8*60*60), which
+// Oracle does not know by name.
+func timezoneName(loc *time.Location) string {
+	if name := loc.String(); name != "Local" && isRegionName(name) {
+		return name
+	}
+	return time.Now().In(loc).Format("-07:00")
+}
+
+// regionNames caches the outcome of isRegionName by name
+var regionNames sync.Map
+
+// isRegionName reports whether name is a time zone region of the tz database, e.g. America/New_York
+func isRegionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	if known, ok := regionNames.Load(name); ok {
+		return known.(bool)
+	}
+	_, err := time.LoadLocation(name)
+	regionNames.Store(name, err == nil)
+	return err == nil
+}
+
+// setTimezoneStatement returns the ALTER SESSION setting TIME_ZONE to name, quotes escaped
+func setTimezoneStatement(name string) string {
+	return fmt.Sprintf(setTimezoneSQL, strings.ReplaceAll(name, "'", "''"))
+}
+
+// timezoneSession is the session a statement altered the time zone of, see setSessionTimezone
+type timezoneSession struct {
+	pool gorm.ConnPool
+	// conn is the connection pinned for the statement, released by restoreSessionTimezone
+	conn *sql.Conn
+	// previous is the TIME_ZONE of the session before the statement, restored after it; empty
+	// when the session already was in the zone of the statement, e.g. for a nested statement
+	previous string
+}
+
+// registerSessionTimezoneCallbacks applies the time zone of the statement context, see
+// WithSessionTimezone, around Create, Query, Update and Delete.
+func registerSessionTimezoneCallbacks(db *gorm.DB) (err error) {
+	cb := db.Callback()
+	if err = cb.Create().Before("*").Register("oracle:set_session_timezone", setSessionTimezone); err != nil {
+		return
+	}
+	if err = cb.Create().After("*").Register("oracle:restore_session_timezone", restoreSessionTimezone); err != nil {
+		return
+	}
+	if err = cb.Query().Before("*").Register("oracle:set_session_timezone", setSessionTimezone); err != nil {
+		return
+	}
+	if err = cb.Query().After("*").Register("oracle:restore_session_timezone", restoreSessionTimezone); err != nil {
+		return
+	}
+	if err = cb.Update().Before("*").Register("oracle:set_session_timezone", setSessionTimezone); err != nil {
+		return
+	}
+	if err = cb.Update().After("*").Register("oracle:restore_session_timezone", restoreSessionTimezone); err != nil {
+		return
+	}
+	if err = cb.Delete().Before("*").Register("oracle:set_session_timezone", setSessionTimezone); err != nil {
+		return
+	}
+	return cb.Delete().After("*").Register("oracle:restore_session_timezone", restoreSessionTimezone)
+}
+
+func setSessionTimezone(db *gorm.DB) {
+	loc := sessionTimezone(db.Statement.Context)
+	if loc == nil || db.Error != nil || db.DryRun {
+		return
+	}
+
+	session := &timezoneSession{pool: db.Statement.ConnPool}
+	if !isPinnedConnPool(session.pool) {
+		pool := session.pool
+		if stmtDB, ok := pool.(*gorm.PreparedStmtDB); ok {
+			pool = stmtDB.ConnPool
+		}
+		sqlDB, ok := pool.(*sql.DB)
+		if !ok {
+			return
+		}
+		conn, err := sqlDB.Conn(db.Statement.Context)
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		session.pool, session.conn = conn, conn
+		db.Statement.ConnPool = conn
+	}
+
+	db.InstanceSet("oracle:session_timezone", session)
+
+	var previous string
+	if err := session.pool.QueryRowContext(db.Statement.Context, getTimezoneSQL).Scan(&previous); err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	if name := timezoneName(loc); previous != name {
+		if _, err := session.pool.ExecContext(db.Statement.Context, setTimezoneStatement(name)); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		session.previous = previous
+	}
+}
+
+func restoreSessionTimezone(db *gorm.DB) {
+	v, ok := db.InstanceGet("oracle:session_timezone")
+	if !ok {
+		return
+	}
+	session := v.(*timezoneSession)
+
+	var err error
+	if session.previous != "" {
+		// the statement context may be done by now; the session has to be restored regardless
+		ctx := context.WithoutCancel(db.Statement.Context)
+		if _, err = session.pool.ExecContext(ctx, setTimezoneStatement(session.previous)); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+	if session.conn == nil {
+		return
+	}
+	if err != nil {
+		// keep a connection in the wrong time zone out of the pool
+		_ = session.conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	_ = session.conn.Close()
+	if db.Statement.ConnPool == session.conn {
+		db.Statement.ConnPool = db.ConnPool
+	}
+}
@@ -3,6 +3,7 @@ package oracle
 import (
 	"reflect"
 	"sort"
+	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -78,6 +79,83 @@ func checkMissingWhereConditions(db *gorm.DB) {
 	}
 }
 
+// RewriteSet builds the SET clause, quoting the bare column names of expression values, e.g. the
+// user_type of gorm.Expr("user_type + 1"), through the naming strategy, so they name the columns
+// of case-sensitive tables as the assigned columns do.
+func (d Dialector) RewriteSet(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.Schema != nil {
+		if set, ok := c.Expression.(clause.Set); ok {
+			set = append(clause.Set(nil), set...)
+			for i, a := range set {
+				if expr, ok := a.Value.(clause.Expr); ok {
+					expr.SQL = quoteExprColumns(stmt, expr.SQL)
+					set[i].Value = expr
+				}
+			}
+			c.Expression = set
+		}
+	}
+	c.Build(builder)
+}
+
+// quoteExprColumns quotes the bare identifiers of sql naming a column of stmt's schema. Literals,
+// quoted identifiers, bind variables, qualified names, function calls, multi-word data types, see
+// IsTypePhrase, and reserved words, which may as well be keywords or pseudo-columns, are left alone.
+func quoteExprColumns(stmt *gorm.Statement, sql string) string {
+	isIdentStart := func(r byte) bool { return r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' }
+	isIdentPart := func(r byte) bool { return isIdentStart(r) || r >= '0' && r <= '9' || r == '$' || r == '#' }
+
+	var out strings.Builder
+	for i := 0; i < len(sql); {
+		switch ch := sql[i]; {
+		case ch == '\'' || ch == '"':
+			end := strings.IndexByte(sql[i+1:], ch)
+			if end < 0 {
+				out.WriteString(sql[i:])
+				return out.String()
+			}
+			out.WriteString(sql[i : i+end+2])
+			i += end + 2
+		case isIdentStart(ch):
+			// CAST(x AS TIMESTAMP WITH LOCAL TIME ZONE): no word of the type names a column
+			if n := typePhraseLen(sql[i:]); n > 0 {
+				out.WriteString(sql[i : i+n])
+				i += n
+				continue
+			}
+			j := i + 1
+			for j < len(sql) && isIdentPart(sql[j]) {
+				j++
+			}
+			word := sql[i:j]
+			next := strings.TrimLeft(sql[j:], " \t\r\n")
+			prev := byte(0)
+			if i > 0 {
+				prev = sql[i-1]
+			}
+			if prev != '.' && prev != ':' && prev != '@' && !strings.HasPrefix(next, "(") && !strings.HasPrefix(next, ".") && !IsReservedWord(word) {
+				if f := lookUpColumnField(stmt.Schema, word); f != nil && strings.EqualFold(f.DBName, word) {
+					word = stmt.Quote(f.DBName)
+				}
+			}
+			out.WriteString(word)
+			i = j
+		case ch >= '0' && ch <= '9':
+			// a number, exponent included
+			j := i + 1
+			for j < len(sql) && (isIdentPart(sql[j]) || sql[j] == '.') {
+				j++
+			}
+			out.WriteString(sql[i:j])
+			i = j
+		default:
+			out.WriteByte(ch)
+			i++
+		}
+	}
+	return out.String()
+}
+
 func fieldsForName(s *schema.Schema, dbName ...string) (fields []*schema.Field) {
 	for _, name := range dbName {
 		if field := s.LookUpField(name); field != nil {
@@ -170,7 +248,7 @@ func ConvertToAssignments(stmt *gorm.Statement) (set clause.Set) {
 				if field := stmt.Schema.LookUpField(k); field != nil {
 					if field.DBName != "" {
 						if v, ok := selectColumns[field.DBName]; (ok && v) || (!ok && !restricted) {
-							set = append(set, clause.Assignment{Column: clause.Column{Name: field.DBName}, Value: convertToLiteral(stmt, kv, stmt.ReflectValue, field)})
+							set = append(set, clause.Assignment{Column: clause.Column{Name: field.DBName}, Value: encryptValue(stmt, field, convertToBind(stmt, field, convertToLiteral(stmt, kv, stmt.ReflectValue, field)))})
 							assignValue(field, value[k])
 						}
 					} else if v, ok := selectColumns[field.Name]; (ok && v) || (!ok && !restricted) {
@@ -240,7 +318,7 @@ func ConvertToAssignments(stmt *gorm.Statement) (set clause.Set) {
 							}
 
 							if (ok || !isZero) && field.Updatable {
-								assignmentValue := convertToLiteral(stmt, innerValue, updatingValue, field)
+								assignmentValue := encryptValue(stmt, field, convertToBind(stmt, field, convertToLiteral(stmt, innerValue, updatingValue, field)))
 								set = append(set, clause.Assignment{Column: clause.Column{Name: field.DBName}, Value: assignmentValue})
 								assignField := field
 								if isDiffSchema {
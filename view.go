@@ -0,0 +1,73 @@
+package oracle
+
+import (
+	"database/sql"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// CreateView creates the view name, possibly owner-qualified, over option.Query, replacing an
+// existing view when option.Replace is set:
+//
+//	db.Migrator().CreateView("active_users", gorm.ViewOption{Query: db.Model(&User{}).Where("active = ?", true)})
+//	// CREATE VIEW ACTIVE_USERS AS SELECT * FROM USERS WHERE active = 1
+//
+// option.CheckOption is appended as written, e.g. WITH CHECK OPTION or WITH READ ONLY. The bind
+// variables of the query are inlined, DDL takes none.
+func (m Migrator) CreateView(name string, option gorm.ViewOption) error {
+	if option.Query == nil {
+		return gorm.ErrSubQueryRequired
+	}
+	stmt := &gorm.Statement{DB: m.DB}
+	_, _ = stmt.WriteString("CREATE ")
+	if option.Replace {
+		_, _ = stmt.WriteString("OR REPLACE ")
+	}
+	_, _ = stmt.WriteString("VIEW ")
+	m.DB.Dialector.QuoteTo(stmt, name)
+	_, _ = stmt.WriteString(" AS ")
+	stmt.AddVar(stmt, option.Query)
+	if option.CheckOption != "" {
+		_ = stmt.WriteByte(' ')
+		_, _ = stmt.WriteString(option.CheckOption)
+	}
+	if stmt.Error != nil {
+		return stmt.Error
+	}
+	return m.DB.Exec(m.DB.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)).Error
+}
+
+// ReplaceView creates the view name over query, replacing the view when it exists, see CreateView
+func (m Migrator) ReplaceView(name string, query *gorm.DB) error {
+	return m.CreateView(name, gorm.ViewOption{Replace: true, Query: query})
+}
+
+// DropView drops the view name, possibly owner-qualified
+func (m Migrator) DropView(name string) error {
+	var drop strings.Builder
+	drop.WriteString("DROP VIEW ")
+	m.DB.Dialector.QuoteTo(&drop, name)
+	return m.DB.Exec(drop.String()).Error
+}
+
+// HasView reports whether the view name, possibly owner-qualified, exists
+func (m Migrator) HasView(name string) bool {
+	ns := getNS(m.DB, m.Dialector)
+	owner, view, hasOwner := ns.dictQualifiedParts(name)
+
+	var exists int
+	var err error
+	if hasOwner {
+		err = m.DB.Raw(
+			`SELECT 1 FROM ALL_VIEWS WHERE OWNER = :owner AND VIEW_NAME = :view AND ROWNUM = 1`,
+			sql.Named("owner", owner), sql.Named("view", view),
+		).Scan(&exists).Error
+	} else {
+		err = m.DB.Raw(
+			`SELECT 1 FROM USER_VIEWS WHERE VIEW_NAME = :view AND ROWNUM = 1`,
+			sql.Named("view", view),
+		).Scan(&exists).Error
+	}
+	return err == nil && exists == 1
+}
@@ -0,0 +1,60 @@
+package oracle
+
+import (
+	"gorm.io/gorm/clause"
+)
+
+// With is a WITH clause (subquery factoring) heading a query; gorm's clause.With is an empty
+// placeholder. Each CTE query is a *gorm.DB or a clause.Expr:
+//
+//	db.Clauses(oracle.With{CTEs: []oracle.CTE{{Name: "big_orders", Query: db.Model(&Order{}).Where("amount > ?", 100)}}}).
+//		Table("big_orders").Limit(10).Find(&orders)
+//	// WITH "BIG_ORDERS" AS (SELECT * FROM "ORDERS" WHERE amount > 100) SELECT * FROM "BIG_ORDERS" ... FETCH NEXT 10 ROWS ONLY
+//
+// Oracle has no RECURSIVE keyword: a CTE referencing itself needs its column list and a UNION ALL.
+//
+//	oracle.CTE{Name: "n", Columns: []string{"i"}, Query: clause.Expr{SQL: "SELECT 1 FROM DUAL UNION ALL SELECT i + 1 FROM n WHERE i < ?", Vars: []any{5}}}
+type With struct {
+	CTEs []CTE
+}
+
+// CTE is a named subquery of a With clause
+type CTE struct {
+	Name    string
+	Columns []string
+	Query   interface{}
+}
+
+func (With) Name() string {
+	return "WITH"
+}
+
+func (w With) Build(builder clause.Builder) {
+	for i, cte := range w.CTEs {
+		if i > 0 {
+			_, _ = builder.WriteString(", ")
+		}
+		builder.WriteQuoted(clause.Table{Name: cte.Name})
+		if len(cte.Columns) > 0 {
+			_ = builder.WriteByte('(')
+			for j, column := range cte.Columns {
+				if j > 0 {
+					_ = builder.WriteByte(',')
+				}
+				builder.WriteQuoted(clause.Column{Name: column})
+			}
+			_ = builder.WriteByte(')')
+		}
+		_, _ = builder.WriteString(" AS (")
+		builder.AddVar(builder, cte.Query)
+		_ = builder.WriteByte(')')
+	}
+}
+
+// MergeClause appends the CTEs to those of an earlier With clause
+func (w With) MergeClause(c *clause.Clause) {
+	if prev, ok := c.Expression.(With); ok {
+		w.CTEs = append(append(prev.CTEs[:0:0], prev.CTEs...), w.CTEs...)
+	}
+	c.Expression = w
+}
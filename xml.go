@@ -0,0 +1,143 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/cmmoran/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// XML is an XMLTYPE column holding a serialized XML document.
+//
+//	Doc oracle.XML                    // XMLTYPE
+//	Raw string `gorm:"type:xmltype"` // XMLTYPE
+//
+// Documents are written through XMLTYPE(?) and read back as text with XMLSERIALIZE.
+type XML string
+
+// GormDataType gorm common data type
+func (XML) GormDataType() string {
+	return "xmltype"
+}
+
+// Value return the serialized document, implement driver.Valuer interface
+func (x XML) Value() (driver.Value, error) {
+	if x == "" {
+		return nil, nil
+	}
+	return string(x), nil
+}
+
+// Scan the serialized document, implements sql.Scanner interface
+func (x *XML) Scan(val interface{}) error {
+	switch v := val.(type) {
+	case nil:
+		*x = ""
+	case string:
+		*x = XML(v)
+	case []byte:
+		*x = XML(v)
+	case go_ora.Clob:
+		*x = XML(v.String)
+	case go_ora.NClob:
+		*x = XML(v.String)
+	default:
+		return fmt.Errorf("oracle: cannot scan %T into XML", val)
+	}
+	return nil
+}
+
+// GormValue binds the document through the XMLTYPE constructor
+func (x XML) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	return xmlValue(string(x))
+}
+
+// xmlValue wraps a serialized document in the XMLTYPE constructor.
+func xmlValue(doc string) clause.Expr {
+	if doc == "" {
+		return clause.Expr{SQL: "CAST(NULL AS XMLTYPE)"}
+	}
+	if len(doc) > 2000 {
+		return clause.Expr{SQL: "XMLTYPE(?)", Vars: []any{go_ora.Clob{String: doc, Valid: true}}}
+	}
+	return clause.Expr{SQL: "XMLTYPE(?)", Vars: []any{doc}}
+}
+
+// isXMLField reports whether field is stored as XMLTYPE, either as XML or through `type:xmltype`.
+func isXMLField(field *schema.Field) bool {
+	return field != nil && strings.EqualFold(string(field.DataType), "xmltype")
+}
+
+func isCurrentTable(stmt *gorm.Statement, table string) bool {
+	return table == "" || table == clause.CurrentTable || table == stmt.Table || table == stmt.Schema.Table
+}
+
+// XMLColumn is a column projected by XMLTable.
+type XMLColumn struct {
+	// Name is the projected column name, matched against the destination's columns
+	Name string
+	// Type is the SQL type of the column, defaults to VARCHAR2(4000)
+	Type string
+	// Path is the XPath of the value relative to each row node, defaults to Name
+	Path string
+}
+
+// xmlTableAlias names the XMLTABLE row source joined by XMLTable.
+const xmlTableAlias = "XT"
+
+// XMLTable shreds the XML document stored in xmlColumn of db's model into one row per node
+// matched by xpath, projecting columns so the rows can be scanned into a struct:
+//
+//	var items []Item
+//	err := oracle.XMLTable(db.Model(&Order{}), "doc", "/order/items/item",
+//		oracle.XMLColumn{Name: "sku", Type: "VARCHAR2(20)"},
+//		oracle.XMLColumn{Name: "qty", Type: "NUMBER", Path: "@qty"},
+//	).Where("id = ?", 1).Scan(&items).Error
+//
+// generates
+//
+//	SELECT "XT"."SKU","XT"."QTY" FROM "ORDERS" , XMLTABLE('/order/items/item' PASSING "ORDERS"."DOC"
+//	  COLUMNS "SKU" VARCHAR2(20) PATH 'sku', "QTY" NUMBER PATH '@qty') "XT" WHERE ...
+//
+//goland:noinspection GoUnusedExportedFunction
+func XMLTable(db *gorm.DB, xmlColumn, xpath string, columns ...XMLColumn) *gorm.DB {
+	var (
+		sql     strings.Builder
+		vars    = []any{clause.Column{Table: clause.CurrentTable, Name: xmlColumn}}
+		selects = make([]clause.Column, 0, len(columns))
+	)
+	sql.WriteString(", XMLTABLE(")
+	sql.WriteString(sqlLiteral(xpath))
+	sql.WriteString(" PASSING ? COLUMNS ")
+	for idx, column := range columns {
+		if idx > 0 {
+			sql.WriteString(", ")
+		}
+		typ, path := column.Type, column.Path
+		if typ == "" {
+			typ = "VARCHAR2(4000)"
+		}
+		if path == "" {
+			path = column.Name
+		}
+		sql.WriteString("? ")
+		sql.WriteString(typ)
+		sql.WriteString(" PATH ")
+		sql.WriteString(sqlLiteral(path))
+		vars = append(vars, clause.Column{Name: column.Name})
+		selects = append(selects, clause.Column{Table: xmlTableAlias, Name: column.Name})
+	}
+	sql.WriteString(") ?")
+	vars = append(vars, clause.Table{Name: xmlTableAlias})
+
+	tx := db.Clauses(clause.Select{Columns: selects}).Joins(sql.String(), vars...)
+	if len(columns) == 0 {
+		_ = tx.AddError(fmt.Errorf("oracle: XMLTable requires at least one column"))
+	}
+	return tx
+}